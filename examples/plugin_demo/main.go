@@ -238,7 +238,8 @@ func setupSchedulerRoutes(engine *gin.Engine, schedulerPlugin *plugin.SchedulerP
 					Type     string `json:"type" binding:"required"` // "interval", "once", "cron"
 					Interval string `json:"interval,omitempty"`      // e.g., "30s"
 					Delay    string `json:"delay,omitempty"`         // e.g., "10s"
-					Cron     string `json:"cron,omitempty"`          // e.g., "@every 1m"
+					Cron     string `json:"cron,omitempty"`          // e.g., "@every 1m", "0 */15 9-18 * * MON-FRI"
+					TimeZone string `json:"time_zone,omitempty"`     // e.g., "Asia/Shanghai"
 					MaxRuns  int64  `json:"max_runs,omitempty"`
 				} `json:"schedule" binding:"required"`
 			}
@@ -287,7 +288,11 @@ func setupSchedulerRoutes(engine *gin.Engine, schedulerPlugin *plugin.SchedulerP
 					httpTransport.BadRequest(c, "Cron expression is required for cron type")
 					return
 				}
-				taskBuilder.Cron(req.Schedule.Cron)
+				if _, err := plugin.ParseCron(req.Schedule.Cron); err != nil {
+					httpTransport.BadRequest(c, "Invalid cron expression: "+err.Error())
+					return
+				}
+				taskBuilder.Cron(req.Schedule.Cron).TimeZone(req.Schedule.TimeZone)
 
 			default:
 				httpTransport.BadRequest(c, "Unsupported schedule type: "+req.Schedule.Type)
@@ -546,7 +551,7 @@ func setupDemoRoutes(engine *gin.Engine, redisConfig, kafkaConfig, etcdConfig, s
 					"Event-driven notifications",
 				},
 				"schedule_types": map[string]string{
-					"cron":     "Use cron expressions like '@every 1m', '@daily'",
+					"cron":     "Use 5/6-field cron expressions like '0 */15 9-18 * * MON-FRI', '@daily' or '@every 1m'",
 					"interval": "Use time.Duration like '30s', '5m', '1h'",
 					"once":     "Execute once after specified delay",
 				},
@@ -7,10 +7,13 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/gin-gonic/gin"
 	"github.com/qiaojinxia/distributed-service/framework/cache"
+	"github.com/qiaojinxia/distributed-service/framework/common/idgen"
 	"github.com/qiaojinxia/distributed-service/framework/component"
 	"github.com/qiaojinxia/distributed-service/framework/config"
 	"github.com/qiaojinxia/distributed-service/framework/logger"
+	localgrpc "github.com/qiaojinxia/distributed-service/framework/transport/grpc"
 	"github.com/qiaojinxia/distributed-service/framework/transport/http"
 )
 
@@ -886,6 +889,11 @@ func (b *Builder) build() error {
 	// 将组件管理器添加到应用
 	b.app.AddComponent(&ComponentWrapper{manager: b.componentManager})
 
+	// 注册ID生成服务的对外接口
+	if err := b.setupIDGenServer(); err != nil {
+		return fmt.Errorf("failed to setup idgen server: %w", err)
+	}
+
 	// 初始化HTTP传输层
 	if b.app.opts.EnableHTTP {
 		if err := b.setupHTTPTransport(); err != nil {
@@ -903,6 +911,73 @@ func (b *Builder) build() error {
 	return nil
 }
 
+// setupIDGenServer 按配置在HTTP/gRPC传输层上注册ID生成服务
+func (b *Builder) setupIDGenServer() error {
+	service, ok := b.componentManager.GetIDGenService().(*idgen.FrameworkIDGenService)
+	if !ok || service == nil || service.Config() == nil {
+		return nil
+	}
+	serverConfig := service.Config().Server
+	if !serverConfig.HTTP && !serverConfig.GRPC {
+		return nil
+	}
+
+	// 认证和业务标识管理接口均需显式开启，管理接口必须在认证之后
+	jwtManager := b.componentManager.GetAuth()
+	if serverConfig.Auth && jwtManager == nil {
+		return fmt.Errorf("idgen server auth requires jwt to be configured")
+	}
+	if !serverConfig.Auth {
+		jwtManager = nil
+	}
+	management := serverConfig.Management
+	if management && jwtManager == nil {
+		logger.Warn(context.Background(), "⚠️ IDGen management API requires auth, biz tag management disabled")
+		management = false
+	}
+
+	if serverConfig.HTTP {
+		if b.app.opts.EnableHTTP {
+			prefix := serverConfig.HTTPPrefix
+			if prefix == "" {
+				prefix = "/idgen"
+			}
+			routes := http.IDGenRoutes(service, http.IDGenRouteOptions{JWTManager: jwtManager, Management: management})
+			b.httpHandlers = append(b.httpHandlers, func(r interface{}) {
+				if engine, ok := r.(*gin.Engine); ok {
+					routes(engine.Group(prefix))
+				}
+			})
+			logger.Info(context.Background(), "✅ IDGen REST API registered",
+				logger.String("prefix", prefix),
+				logger.Bool("auth", jwtManager != nil),
+				logger.Bool("management", management))
+		} else {
+			logger.Warn(context.Background(), "⚠️ IDGen HTTP server enabled but HTTP transport is disabled")
+		}
+	}
+
+	if serverConfig.GRPC {
+		if b.app.opts.EnableGRPC {
+			grpcServer := idgen.NewGRPCServer(service).WithManagement(management)
+			if jwtManager != nil {
+				grpcServer.WithAuth(idgen.JWTGRPCAuth(jwtManager, http.AdminRole))
+			}
+			b.grpcHandlers = append(b.grpcHandlers, func(s interface{}) {
+				if server, ok := s.(*localgrpc.Server); ok {
+					grpcServer.Register(server.GetServer())
+				}
+			})
+			logger.Info(context.Background(), "✅ IDGen gRPC service registered",
+				logger.Bool("auth", jwtManager != nil),
+				logger.Bool("management", management))
+		} else {
+			logger.Warn(context.Background(), "⚠️ IDGen gRPC server enabled but gRPC transport is disabled")
+		}
+	}
+	return nil
+}
+
 // setupHTTPTransport 设置HTTP传输层
 func (b *Builder) setupHTTPTransport() error {
 
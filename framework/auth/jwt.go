@@ -17,6 +17,7 @@ var (
 type Claims struct {
 	UserID   uint   `json:"user_id"`
 	Username string `json:"username"`
+	Role     string `json:"role,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -34,9 +35,15 @@ func NewJWTManager(secretKey, issuer string) *JWTManager {
 
 // GenerateToken generates a new JWT token
 func (m *JWTManager) GenerateToken(ctx context.Context, userID uint, username string) (string, error) {
+	return m.GenerateTokenWithRole(ctx, userID, username, "")
+}
+
+// GenerateTokenWithRole generates a new JWT token carrying the user's role
+func (m *JWTManager) GenerateTokenWithRole(ctx context.Context, userID uint, username, role string) (string, error) {
 	claims := &Claims{
 		UserID:   userID,
 		Username: username,
+		Role:     role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    m.issuer,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -92,5 +99,5 @@ func (m *JWTManager) RefreshToken(ctx context.Context, tokenString string) (stri
 	}
 
 	// Generate new token with same user info but extended expiration
-	return m.GenerateToken(ctx, claims.UserID, claims.Username)
+	return m.GenerateTokenWithRole(ctx, claims.UserID, claims.Username, claims.Role)
 }
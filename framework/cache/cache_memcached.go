@@ -0,0 +1,461 @@
+package cache
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// memcachedMaxKeyLength Memcached键的最大长度
+const memcachedMaxKeyLength = 250
+
+// memcachedMaxRelativeExpiration 超过30天的过期时间会被Memcached当作Unix时间戳
+const memcachedMaxRelativeExpiration = 30 * 24 * time.Hour
+
+// MemcachedConfig Memcached缓存配置
+type MemcachedConfig struct {
+	Servers      []string      `json:"servers" yaml:"servers"`               // 节点地址，按一致性哈希分布
+	KeyPrefix    string        `json:"key_prefix" yaml:"key_prefix"`         // 键前缀
+	Timeout      time.Duration `json:"timeout" yaml:"timeout"`               // 连接及读写超时，默认500ms
+	MaxIdleConns int           `json:"max_idle_conns" yaml:"max_idle_conns"` // 每个节点保留的空闲连接数，默认8
+	VirtualNodes int           `json:"virtual_nodes" yaml:"virtual_nodes"`   // 每个节点在哈希环上的虚拟节点数，默认160
+}
+
+// MemcachedCache Memcached缓存实现，键按一致性哈希分布到多个节点
+//
+// 与Redis缓存一致，Get返回string类型的值。
+type MemcachedCache struct {
+	config    MemcachedConfig
+	ring      *hashRing
+	servers   map[string]*memcachedServer
+	statsMu   sync.Mutex
+	stats     Stats
+	keyPrefix string
+}
+
+// NewMemcachedCache 创建Memcached缓存，不会立即建立连接
+func NewMemcachedCache(config MemcachedConfig) (*MemcachedCache, error) {
+	if len(config.Servers) == 0 {
+		return nil, fmt.Errorf("memcached servers are required")
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 500 * time.Millisecond
+	}
+	if config.MaxIdleConns <= 0 {
+		config.MaxIdleConns = 8
+	}
+	if config.VirtualNodes <= 0 {
+		config.VirtualNodes = 160
+	}
+
+	servers := make(map[string]*memcachedServer, len(config.Servers))
+	for _, addr := range config.Servers {
+		servers[addr] = newMemcachedServer(addr, config.Timeout, config.MaxIdleConns)
+	}
+
+	return &MemcachedCache{
+		config:    config,
+		ring:      newHashRing(config.Servers, config.VirtualNodes),
+		servers:   servers,
+		stats:     Stats{LastUpdated: time.Now()},
+		keyPrefix: config.KeyPrefix,
+	}, nil
+}
+
+// addKeyPrefix 添加键前缀并校验键是否合法
+func (m *MemcachedCache) addKeyPrefix(key string) (string, error) {
+	if m.keyPrefix != "" {
+		key = m.keyPrefix + ":" + key
+	}
+	if len(key) == 0 || len(key) > memcachedMaxKeyLength {
+		return "", fmt.Errorf("%w: memcached key length must be 1-%d: %q", ErrInvalidKey, memcachedMaxKeyLength, key)
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] <= ' ' || key[i] == 0x7f {
+			return "", fmt.Errorf("%w: memcached key contains whitespace or control characters: %q", ErrInvalidKey, key)
+		}
+	}
+	return key, nil
+}
+
+// serverFor 键所在的节点
+func (m *MemcachedCache) serverFor(prefixedKey string) *memcachedServer {
+	return m.servers[m.ring.get(prefixedKey)]
+}
+
+// Get 获取值
+func (m *MemcachedCache) Get(ctx context.Context, key string) (interface{}, error) {
+	prefixedKey, err := m.addKeyPrefix(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	err = m.serverFor(prefixedKey).do(ctx, func(c *memcachedConn) error {
+		return c.get([]string{prefixedKey}, func(_ string, data []byte) {
+			value = string(data)
+		})
+	})
+	if err != nil {
+		m.recordStats(func(s *Stats) { s.Errors++ })
+		return nil, err
+	}
+	if value == nil {
+		m.recordStats(func(s *Stats) { s.Misses++ })
+		return nil, ErrKeyNotFound
+	}
+
+	m.recordStats(func(s *Stats) { s.Hits++ })
+	return value, nil
+}
+
+// Set 设置值
+func (m *MemcachedCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return m.MSet(ctx, map[string]interface{}{key: value}, expiration)
+}
+
+// Delete 删除键
+func (m *MemcachedCache) Delete(ctx context.Context, key string) error {
+	return m.MDelete(ctx, []string{key})
+}
+
+// Exists 检查键是否存在（Memcached没有EXISTS命令，通过读取判断）
+func (m *MemcachedCache) Exists(ctx context.Context, key string) (bool, error) {
+	prefixedKey, err := m.addKeyPrefix(key)
+	if err != nil {
+		return false, err
+	}
+
+	found := false
+	err = m.serverFor(prefixedKey).do(ctx, func(c *memcachedConn) error {
+		return c.get([]string{prefixedKey}, func(string, []byte) {
+			found = true
+		})
+	})
+	if err != nil {
+		m.recordStats(func(s *Stats) { s.Errors++ })
+		return false, err
+	}
+	return found, nil
+}
+
+// Clear 清空缓存（注意：这会清空所有节点上的全部数据，包括其他前缀的键）
+func (m *MemcachedCache) Clear(ctx context.Context) error {
+	return m.eachServer(func(server *memcachedServer) error {
+		return server.do(ctx, func(c *memcachedConn) error {
+			return c.flushAll()
+		})
+	})
+}
+
+// Close 关闭所有空闲连接
+func (m *MemcachedCache) Close() error {
+	for _, server := range m.servers {
+		server.close()
+	}
+	return nil
+}
+
+// MGet 批量获取，每个节点一次get
+func (m *MemcachedCache) MGet(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	groups, originals, err := m.groupKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	data := make(map[string]interface{}, len(keys))
+	err = m.eachGroup(groups, func(server *memcachedServer, prefixedKeys []string) error {
+		return server.do(ctx, func(c *memcachedConn) error {
+			return c.get(prefixedKeys, func(prefixedKey string, value []byte) {
+				mu.Lock()
+				data[originals[prefixedKey]] = string(value) // 返回原始键名，不包含前缀
+				mu.Unlock()
+			})
+		})
+	})
+	if err != nil {
+		m.recordStats(func(s *Stats) { s.Errors++ })
+		return nil, err
+	}
+
+	m.recordStats(func(s *Stats) {
+		s.Hits += int64(len(data))
+		s.Misses += int64(len(originals) - len(data))
+	})
+	return data, nil
+}
+
+// MSet 批量设置，每个节点的set命令通过流水线发送
+func (m *MemcachedCache) MSet(ctx context.Context, keyValues map[string]interface{}, expiration time.Duration) error {
+	keys := make([]string, 0, len(keyValues))
+	for key := range keyValues {
+		keys = append(keys, key)
+	}
+	groups, originals, err := m.groupKeys(keys)
+	if err != nil {
+		return err
+	}
+
+	encoded := make(map[string][]byte, len(keyValues))
+	for prefixedKey, key := range originals {
+		data, err := memcachedValue(keyValues[key])
+		if err != nil {
+			return fmt.Errorf("failed to encode value for key %s: %w", key, err)
+		}
+		encoded[prefixedKey] = data
+	}
+	exptime := memcachedExptime(expiration)
+
+	err = m.eachGroup(groups, func(server *memcachedServer, prefixedKeys []string) error {
+		return server.do(ctx, func(c *memcachedConn) error {
+			for _, prefixedKey := range prefixedKeys {
+				if err := c.writeSet(prefixedKey, encoded[prefixedKey], exptime); err != nil {
+					return err
+				}
+			}
+			if err := c.rw.Flush(); err != nil {
+				return err
+			}
+			// 读完所有响应才能复用连接，NOT_STORED之外的错误直接返回并关闭连接
+			var firstErr error
+			for range prefixedKeys {
+				err := c.readSet()
+				if err != nil && !errors.Is(err, errMemcachedNotStored) {
+					return err
+				}
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+			}
+			return firstErr
+		})
+	})
+	if err != nil {
+		m.recordStats(func(s *Stats) { s.Errors++ })
+		return err
+	}
+
+	m.recordStats(func(s *Stats) { s.Sets += int64(len(keyValues)) })
+	return nil
+}
+
+// MDelete 批量删除，每个节点的delete命令通过流水线发送
+func (m *MemcachedCache) MDelete(ctx context.Context, keys []string) error {
+	groups, _, err := m.groupKeys(keys)
+	if err != nil {
+		return err
+	}
+
+	var deleted int64
+	var mu sync.Mutex
+	err = m.eachGroup(groups, func(server *memcachedServer, prefixedKeys []string) error {
+		return server.do(ctx, func(c *memcachedConn) error {
+			for _, prefixedKey := range prefixedKeys {
+				if _, err := fmt.Fprintf(c.rw, "delete %s\r\n", prefixedKey); err != nil {
+					return err
+				}
+			}
+			if err := c.rw.Flush(); err != nil {
+				return err
+			}
+			var firstErr error
+			for range prefixedKeys {
+				found, err := c.readDelete()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				if found {
+					mu.Lock()
+					deleted++
+					mu.Unlock()
+				}
+			}
+			return firstErr
+		})
+	})
+	m.recordStats(func(s *Stats) { s.Deletes += deleted })
+	if err != nil {
+		m.recordStats(func(s *Stats) { s.Errors++ })
+		return err
+	}
+	return nil
+}
+
+// groupKeys 为键添加前缀并按节点分组，返回带前缀的键到原始键的映射
+func (m *MemcachedCache) groupKeys(keys []string) (map[*memcachedServer][]string, map[string]string, error) {
+	groups := make(map[*memcachedServer][]string)
+	originals := make(map[string]string, len(keys))
+	for _, key := range keys {
+		prefixedKey, err := m.addKeyPrefix(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, dup := originals[prefixedKey]; dup {
+			continue
+		}
+		originals[prefixedKey] = key
+		server := m.serverFor(prefixedKey)
+		groups[server] = append(groups[server], prefixedKey)
+	}
+	return groups, originals, nil
+}
+
+// eachGroup 并发处理各节点的键，返回第一个错误
+func (m *MemcachedCache) eachGroup(groups map[*memcachedServer][]string, fn func(server *memcachedServer, prefixedKeys []string) error) error {
+	var wg sync.WaitGroup
+	errs := make(chan error, len(groups))
+	for server, prefixedKeys := range groups {
+		wg.Add(1)
+		go func(server *memcachedServer, prefixedKeys []string) {
+			defer wg.Done()
+			if err := fn(server, prefixedKeys); err != nil {
+				errs <- err
+			}
+		}(server, prefixedKeys)
+	}
+	wg.Wait()
+	close(errs)
+	return <-errs
+}
+
+// eachServer 并发在所有节点上执行fn
+func (m *MemcachedCache) eachServer(fn func(server *memcachedServer) error) error {
+	groups := make(map[*memcachedServer][]string, len(m.servers))
+	for _, server := range m.servers {
+		groups[server] = nil
+	}
+	return m.eachGroup(groups, func(server *memcachedServer, _ []string) error {
+		return fn(server)
+	})
+}
+
+// recordStats 在锁内更新统计
+func (m *MemcachedCache) recordStats(update func(s *Stats)) {
+	m.statsMu.Lock()
+	update(&m.stats)
+	m.statsMu.Unlock()
+}
+
+// GetStats 获取统计信息
+func (m *MemcachedCache) GetStats() Stats {
+	m.statsMu.Lock()
+	defer m.statsMu.Unlock()
+	return m.stats
+}
+
+// ResetStats 重置统计信息
+func (m *MemcachedCache) ResetStats() {
+	m.statsMu.Lock()
+	m.stats = Stats{LastUpdated: time.Now()}
+	m.statsMu.Unlock()
+}
+
+// memcachedValue 将值编码为字节，规则与go-redis写入参数一致
+func memcachedValue(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case int:
+		return strconv.AppendInt(nil, int64(v), 10), nil
+	case int8:
+		return strconv.AppendInt(nil, int64(v), 10), nil
+	case int16:
+		return strconv.AppendInt(nil, int64(v), 10), nil
+	case int32:
+		return strconv.AppendInt(nil, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(nil, v, 10), nil
+	case uint:
+		return strconv.AppendUint(nil, uint64(v), 10), nil
+	case uint8:
+		return strconv.AppendUint(nil, uint64(v), 10), nil
+	case uint16:
+		return strconv.AppendUint(nil, uint64(v), 10), nil
+	case uint32:
+		return strconv.AppendUint(nil, uint64(v), 10), nil
+	case uint64:
+		return strconv.AppendUint(nil, v, 10), nil
+	case float32:
+		return strconv.AppendFloat(nil, float64(v), 'f', -1, 64), nil
+	case float64:
+		return strconv.AppendFloat(nil, v, 'f', -1, 64), nil
+	case bool:
+		if v {
+			return []byte("1"), nil
+		}
+		return []byte("0"), nil
+	case time.Time:
+		return v.AppendFormat(nil, time.RFC3339Nano), nil
+	case encoding.BinaryMarshaler:
+		return v.MarshalBinary()
+	default:
+		return nil, fmt.Errorf("can't marshal %T (implement encoding.BinaryMarshaler)", value)
+	}
+}
+
+// memcachedExptime 将过期时间转换为Memcached的exptime，不足1秒按1秒计
+func memcachedExptime(expiration time.Duration) int64 {
+	if expiration <= 0 {
+		return 0
+	}
+	if expiration > memcachedMaxRelativeExpiration {
+		return time.Now().Add(expiration).Unix()
+	}
+	return int64((expiration + time.Second - 1) / time.Second)
+}
+
+// hashRing 一致性哈希环，增删节点时只有相邻区间的键会迁移
+type hashRing struct {
+	hashes []uint32
+	nodes  map[uint32]string
+}
+
+// newHashRing 创建哈希环，每个节点放置replicas个虚拟节点
+func newHashRing(nodes []string, replicas int) *hashRing {
+	ring := &hashRing{nodes: make(map[uint32]string, len(nodes)*replicas)}
+	for _, node := range nodes {
+		for i := 0; i < replicas; i++ {
+			hash := crc32.ChecksumIEEE([]byte(node + "#" + strconv.Itoa(i)))
+			if _, exists := ring.nodes[hash]; exists {
+				continue
+			}
+			ring.nodes[hash] = node
+			ring.hashes = append(ring.hashes, hash)
+		}
+	}
+	sort.Slice(ring.hashes, func(i, j int) bool { return ring.hashes[i] < ring.hashes[j] })
+	return ring
+}
+
+// get 键所在的节点：顺时针方向的第一个虚拟节点
+func (r *hashRing) get(key string) string {
+	hash := crc32.ChecksumIEEE([]byte(key))
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= hash })
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.nodes[r.hashes[i]]
+}
+
+// MemcachedBuilder Memcached缓存构建器
+type MemcachedBuilder struct{}
+
+// Build 构建缓存实例
+func (b *MemcachedBuilder) Build(config Config) (Cache, error) {
+	var memcachedConfig MemcachedConfig
+	if err := decodeSettings(config.Settings, &memcachedConfig); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal memcached config: %w", err)
+	}
+	return NewMemcachedCache(memcachedConfig)
+}
@@ -2,7 +2,6 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -19,6 +18,12 @@ const (
 	EvictionPolicyLRU    EvictionPolicy = "lru"    // 最近最少使用
 	EvictionPolicyTTL    EvictionPolicy = "ttl"    // 基于过期时间
 	EvictionPolicySimple EvictionPolicy = "simple" // 简单策略(go-cache)
+
+	EvictionPolicyLFU     EvictionPolicy = "lfu"     // 最不经常使用（支持频率衰减）
+	EvictionPolicyFIFO    EvictionPolicy = "fifo"    // 先进先出
+	EvictionPolicyRandom  EvictionPolicy = "random"  // 随机淘汰
+	EvictionPolicyARC     EvictionPolicy = "arc"     // 自适应替换缓存
+	EvictionPolicyTinyLFU EvictionPolicy = "tinylfu" // W-TinyLFU，抗扫描
 )
 
 type MemoryCache struct {
@@ -26,6 +31,8 @@ type MemoryCache struct {
 	lruCache    *lru.Cache[string, interface{}]     // LRU策略  
 	expireCache *expirable.LRU[string, interface{}] // 预留字段（未使用）
 	goCache     *gocache.Cache                      // TTL策略和Simple策略共用
+	store       *policyStore                        // LFU/FIFO/Random/ARC/TinyLFU策略
+	tags        *tagIndex                           // 标签索引
 
 	// 配置
 	config    MemoryConfig
@@ -39,11 +46,25 @@ type MemoryConfig struct {
 	DefaultTTL      time.Duration  `json:"default_ttl" yaml:"default_ttl"`           // 默认过期时间
 	CleanupInterval time.Duration  `json:"cleanup_interval" yaml:"cleanup_interval"` // 清理间隔
 	EvictionPolicy  EvictionPolicy `json:"eviction_policy" yaml:"eviction_policy"`   // 淘汰策略
+	ShardCount      int            `json:"shard_count" yaml:"shard_count"`           // 分片数量，大于1时使用分片缓存
+	PreAllocSize    int            `json:"pre_alloc_size" yaml:"pre_alloc_size"`     // 预分配大小
+
+	// 按字节数限制容量
+	MaxBytes int64 `json:"max_bytes" yaml:"max_bytes"` // 最大字节数，大于0时按条目大小淘汰
+	Sizer    Sizer `json:"-" yaml:"-"`                 // 条目大小计算函数，默认使用 DefaultSizer
+
+	// LFU策略配置
+	LFUDecayRate     float64       `json:"lfu_decay_rate" yaml:"lfu_decay_rate"`         // 每个衰减周期频率衰减的比例 [0, 1]，0表示不衰减
+	LFUDecayInterval time.Duration `json:"lfu_decay_interval" yaml:"lfu_decay_interval"` // 衰减周期，默认1分钟
+	LFUMinFreq       int64         `json:"lfu_min_freq" yaml:"lfu_min_freq"`             // 新条目的初始频率，也是衰减的下限
+
+	// 快照
+	SnapshotPath string `json:"snapshot_path" yaml:"snapshot_path"` // 快照文件路径，非空时创建时恢复、Close时导出
 }
 
 func NewMemoryCache(config MemoryConfig) (*MemoryCache, error) {
-	// 设置默认值
-	if config.MaxSize <= 0 {
+	// 设置默认值（按字节数限制时不限制条目数）
+	if config.MaxSize <= 0 && config.MaxBytes <= 0 {
 		config.MaxSize = 1000
 	}
 	if config.DefaultTTL <= 0 {
@@ -59,6 +80,23 @@ func NewMemoryCache(config MemoryConfig) (*MemoryCache, error) {
 	mc := &MemoryCache{
 		config: config,
 		stats:  Stats{LastUpdated: time.Now()},
+		tags:   newTagIndex(),
+	}
+
+	// 按字节数限制容量时统一使用自实现的存储
+	if config.MaxBytes > 0 {
+		if config.Sizer == nil {
+			config.Sizer = DefaultSizer
+		}
+		mc.config = config
+		mc.store = newPolicyStore(config, mc.onPolicyEvicted)
+		if mc.store == nil {
+			return nil, fmt.Errorf("unsupported eviction policy: %s", config.EvictionPolicy)
+		}
+		if config.SnapshotPath != "" {
+			restoreFromSnapshot(config.SnapshotPath, mc.restoreItem)
+		}
+		return mc, nil
 	}
 
 	// 根据策略初始化相应的缓存库
@@ -72,26 +110,37 @@ func NewMemoryCache(config MemoryConfig) (*MemoryCache, error) {
 
 	case EvictionPolicyTTL:
 		// TTL策略使用goCache，因为它支持每个键的自定义TTL
-		mc.goCache = gocache.New(config.DefaultTTL, config.CleanupInterval)
-		if mc.onEvicted != nil {
-			mc.goCache.OnEvicted(mc.onEvicted)
-		}
+		mc.goCache = newGoCache(config)
+		mc.goCache.OnEvicted(mc.onGoCacheEvicted)
 
 	case EvictionPolicySimple:
-		mc.goCache = gocache.New(config.DefaultTTL, config.CleanupInterval)
-		if mc.onEvicted != nil {
-			mc.goCache.OnEvicted(mc.onEvicted)
-		}
+		mc.goCache = newGoCache(config)
+		mc.goCache.OnEvicted(mc.onGoCacheEvicted)
+
+	case EvictionPolicyLFU, EvictionPolicyFIFO, EvictionPolicyRandom, EvictionPolicyARC, EvictionPolicyTinyLFU:
+		mc.store = newPolicyStore(config, mc.onPolicyEvicted)
 
 	default:
 		return nil, fmt.Errorf("unsupported eviction policy: %s", config.EvictionPolicy)
 	}
 
+	if config.SnapshotPath != "" {
+		restoreFromSnapshot(config.SnapshotPath, mc.restoreItem)
+	}
 	return mc, nil
 }
 
+// newGoCache 创建goCache，按PreAllocSize预分配
+func newGoCache(config MemoryConfig) *gocache.Cache {
+	if config.PreAllocSize > 0 {
+		return gocache.NewFrom(config.DefaultTTL, config.CleanupInterval, make(map[string]gocache.Item, config.PreAllocSize))
+	}
+	return gocache.New(config.DefaultTTL, config.CleanupInterval)
+}
+
 // 回调函数实现
 func (m *MemoryCache) onLRUEvicted(key string, value interface{}) {
+	m.tags.remove(key)
 	m.mutex.Lock()
 	m.stats.Evictions++
 	m.mutex.Unlock()
@@ -100,6 +149,26 @@ func (m *MemoryCache) onLRUEvicted(key string, value interface{}) {
 	}
 }
 
+func (m *MemoryCache) onPolicyEvicted(key string, value interface{}, expired bool) {
+	m.tags.remove(key)
+	if !expired {
+		m.mutex.Lock()
+		m.stats.Evictions++
+		m.mutex.Unlock()
+	}
+	if m.onEvicted != nil {
+		m.onEvicted(key, value)
+	}
+}
+
+// onGoCacheEvicted goCache在删除和过期清理时回调
+func (m *MemoryCache) onGoCacheEvicted(key string, value interface{}) {
+	m.tags.remove(key)
+	if m.onEvicted != nil {
+		m.onEvicted(key, value)
+	}
+}
+
 func (m *MemoryCache) onExpirableEvicted(key string, value interface{}) {
 	m.mutex.Lock()
 	m.stats.Evictions++
@@ -111,6 +180,20 @@ func (m *MemoryCache) onExpirableEvicted(key string, value interface{}) {
 
 // Get 接口实现
 func (m *MemoryCache) Get(ctx context.Context, key string) (interface{}, error) {
+	if m.store != nil {
+		value, found := m.store.get(key)
+		if !found {
+			m.mutex.Lock()
+			m.stats.Misses++
+			m.mutex.Unlock()
+			return nil, ErrKeyNotFound
+		}
+		m.mutex.Lock()
+		m.stats.Hits++
+		m.mutex.Unlock()
+		return value, nil
+	}
+
 	switch m.config.EvictionPolicy {
 	case EvictionPolicyLRU:
 		if m.lruCache == nil {
@@ -165,7 +248,34 @@ func (m *MemoryCache) Get(ctx context.Context, key string) (interface{}, error)
 	}
 }
 
+// Set 设置值，并清除键原有的标签关联
 func (m *MemoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if err := m.setValue(key, value, expiration); err != nil {
+		return err
+	}
+	m.tags.replace(key, nil)
+	return nil
+}
+
+// setValue 按淘汰策略写入值，不处理标签
+func (m *MemoryCache) setValue(key string, value interface{}, expiration time.Duration) error {
+	if m.store != nil {
+		ttl := expiration
+		if ttl <= 0 {
+			ttl = m.config.DefaultTTL
+		}
+		if err := m.store.set(key, value, ttl); err != nil {
+			m.mutex.Lock()
+			m.stats.Errors++
+			m.mutex.Unlock()
+			return err
+		}
+		m.mutex.Lock()
+		m.stats.Sets++
+		m.mutex.Unlock()
+		return nil
+	}
+
 	switch m.config.EvictionPolicy {
 	case EvictionPolicyLRU:
 		if m.lruCache == nil {
@@ -213,6 +323,22 @@ func (m *MemoryCache) Set(ctx context.Context, key string, value interface{}, ex
 }
 
 func (m *MemoryCache) Delete(ctx context.Context, key string) error {
+	if m.store != nil {
+		m.store.delete(key)
+	} else {
+		m.deleteFromLibrary(key)
+	}
+	m.tags.remove(key)
+
+	m.mutex.Lock()
+	m.stats.Deletes++
+	m.mutex.Unlock()
+
+	return nil
+}
+
+// deleteFromLibrary 从第三方库实现的缓存中删除
+func (m *MemoryCache) deleteFromLibrary(key string) {
 	switch m.config.EvictionPolicy {
 	case EvictionPolicyLRU:
 		if m.lruCache != nil {
@@ -227,15 +353,13 @@ func (m *MemoryCache) Delete(ctx context.Context, key string) error {
 			m.goCache.Delete(key)
 		}
 	}
-
-	m.mutex.Lock()
-	m.stats.Deletes++
-	m.mutex.Unlock()
-
-	return nil
 }
 
 func (m *MemoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	if m.store != nil {
+		return m.store.contains(key), nil
+	}
+
 	switch m.config.EvictionPolicy {
 	case EvictionPolicyLRU:
 		if m.lruCache == nil {
@@ -263,6 +387,12 @@ func (m *MemoryCache) Exists(ctx context.Context, key string) (bool, error) {
 }
 
 func (m *MemoryCache) Clear(ctx context.Context) error {
+	m.tags.clear()
+	if m.store != nil {
+		m.store.clear()
+		return nil
+	}
+
 	switch m.config.EvictionPolicy {
 	case EvictionPolicyLRU:
 		if m.lruCache != nil {
@@ -281,14 +411,26 @@ func (m *MemoryCache) Clear(ctx context.Context) error {
 	return nil
 }
 
+// Close 配置了快照路径时导出当前条目
 func (m *MemoryCache) Close() error {
+	if m.config.SnapshotPath == "" {
+		return nil
+	}
+	if _, err := m.SaveSnapshot(m.config.SnapshotPath); err != nil {
+		return fmt.Errorf("failed to save memory cache snapshot: %w", err)
+	}
 	return nil
 }
 
 func (m *MemoryCache) GetStats() Stats {
 	m.mutex.RLock()
-	defer m.mutex.RUnlock()
-	return m.stats
+	stats := m.stats
+	m.mutex.RUnlock()
+
+	if m.store != nil {
+		stats.Bytes = m.store.size()
+	}
+	return stats
 }
 
 func (m *MemoryCache) ResetStats() {
@@ -302,7 +444,33 @@ func (m *MemoryCache) SetEvictionCallback(callback func(string, interface{})) {
 	m.onEvicted = callback
 }
 
-type MemoryBuilder struct{}
+// Len 当前条目数（可能包含尚未清理的过期条目）
+func (m *MemoryCache) Len() int {
+	if m.store != nil {
+		return m.store.len()
+	}
+
+	switch m.config.EvictionPolicy {
+	case EvictionPolicyLRU:
+		if m.lruCache != nil {
+			return m.lruCache.Len()
+		}
+	case EvictionPolicyTTL, EvictionPolicySimple:
+		if m.goCache != nil {
+			return m.goCache.ItemCount()
+		}
+	}
+	return 0
+}
+
+type MemoryBuilder struct {
+	defaults *MemoryConfig // 缓存实例Settings未设置的字段使用的默认配置
+}
+
+// NewMemoryBuilder 创建使用指定默认配置的内存缓存构建器，零值字段使用内置默认值
+func NewMemoryBuilder(defaults MemoryConfig) *MemoryBuilder {
+	return &MemoryBuilder{defaults: &defaults}
+}
 
 func (b *MemoryBuilder) Build(config Config) (Cache, error) {
 	memConfig := MemoryConfig{
@@ -311,14 +479,16 @@ func (b *MemoryBuilder) Build(config Config) (Cache, error) {
 		CleanupInterval: time.Minute * 10,
 		EvictionPolicy:  EvictionPolicyLRU,
 	}
+	if b.defaults != nil {
+		memConfig = *b.defaults
+	}
 
-	if settings := config.Settings; settings != nil {
-		if data, err := json.Marshal(settings); err == nil {
-			if err := json.Unmarshal(data, &memConfig); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal memory config: %w", err)
-			}
-		}
+	if err := decodeSettings(config.Settings, &memConfig); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal memory config: %w", err)
 	}
 
+	if memConfig.ShardCount > 1 {
+		return NewShardedMemoryCache(memConfig)
+	}
 	return NewMemoryCache(memConfig)
 }
@@ -10,11 +10,13 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
-// SimpleRedisCache 简化的Redis缓存实现，只支持外部注入的Redis客户端
+// SimpleRedisCache Redis缓存实现，支持单节点、Sentinel故障转移与Cluster集群客户端
 type SimpleRedisCache struct {
-	client    *redis.Client
-	stats     Stats
-	keyPrefix string
+	client     redis.UniversalClient
+	cluster    *redis.ClusterClient // 集群模式下非空，批量操作按槽位拆分
+	ownsClient bool                 // 客户端由构建器创建时为true，Close时一并关闭
+	stats      Stats
+	keyPrefix  string
 }
 
 // NewSimpleRedisCache 使用外部Redis客户端创建缓存实例
@@ -102,9 +104,16 @@ func (r *SimpleRedisCache) Exists(ctx context.Context, key string) (bool, error)
 	return result > 0, nil
 }
 
-// Clear 清空缓存（注意：这会清空整个数据库）
+// Clear 清空缓存（注意：这会清空整个数据库，集群模式下清空所有主节点）
 func (r *SimpleRedisCache) Clear(ctx context.Context) error {
-	err := r.client.FlushDB(ctx).Err()
+	var err error
+	if r.cluster != nil {
+		err = r.cluster.ForEachMaster(ctx, func(ctx context.Context, master *redis.Client) error {
+			return master.FlushDB(ctx).Err()
+		})
+	} else {
+		err = r.client.FlushDB(ctx).Err()
+	}
 	if err != nil {
 		r.stats.Errors++
 		return err
@@ -114,12 +123,18 @@ func (r *SimpleRedisCache) Clear(ctx context.Context) error {
 
 // Close 关闭连接（不关闭外部注入的客户端）
 func (r *SimpleRedisCache) Close() error {
-	// 不关闭外部注入的Redis客户端
+	if r.ownsClient {
+		return r.client.Close()
+	}
 	return nil
 }
 
 // MGet 批量获取
 func (r *SimpleRedisCache) MGet(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	if r.cluster != nil {
+		return r.clusterMGet(ctx, keys)
+	}
+
 	prefixedKeys := r.addKeyPrefixToSlice(keys)
 	results, err := r.client.MGet(ctx, prefixedKeys...).Result()
 	if err != nil {
@@ -140,7 +155,7 @@ func (r *SimpleRedisCache) MGet(ctx context.Context, keys []string) (map[string]
 	return data, nil
 }
 
-// MSet 批量设置
+// MSet 批量设置（集群模式下流水线按键所在节点自动拆分）
 func (r *SimpleRedisCache) MSet(ctx context.Context, keyValues map[string]interface{}, expiration time.Duration) error {
 	pipe := r.client.Pipeline()
 
@@ -162,7 +177,7 @@ func (r *SimpleRedisCache) MSet(ctx context.Context, keyValues map[string]interf
 // MDelete 批量删除
 func (r *SimpleRedisCache) MDelete(ctx context.Context, keys []string) error {
 	prefixedKeys := r.addKeyPrefixToSlice(keys)
-	err := r.client.Del(ctx, prefixedKeys...).Err()
+	_, err := r.deleteKeys(ctx, prefixedKeys)
 	if err != nil {
 		r.stats.Errors++
 		return err
@@ -0,0 +1,216 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/qiaojinxia/distributed-service/pkg/redis_cluster"
+)
+
+// redisClusterSlots Redis Cluster槽位总数
+const redisClusterSlots = 16384
+
+// RedisClusterCacheConfig Redis Cluster缓存配置，Addrs为空时使用构建器注入的客户端
+type RedisClusterCacheConfig struct {
+	Addrs          []string      `json:"addrs" yaml:"addrs"`                       // 集群节点地址
+	Username       string        `json:"username" yaml:"username"`                 // 用户名（Redis 6 ACL）
+	Password       string        `json:"password" yaml:"password"`                 // 密码
+	PoolSize       int           `json:"pool_size" yaml:"pool_size"`               // 每个节点的连接池大小
+	MaxRetries     int           `json:"max_retries" yaml:"max_retries"`           // 最大重试次数
+	MaxRedirects   int           `json:"max_redirects" yaml:"max_redirects"`       // MOVED/ASK最大重定向次数
+	ReadOnly       bool          `json:"read_only" yaml:"read_only"`               // 允许从副本读取
+	RouteByLatency bool          `json:"route_by_latency" yaml:"route_by_latency"` // 读请求路由到延迟最低的节点
+	RouteRandomly  bool          `json:"route_randomly" yaml:"route_randomly"`     // 读请求随机路由
+	DialTimeout    time.Duration `json:"dial_timeout" yaml:"dial_timeout"`         // 连接超时
+	ReadTimeout    time.Duration `json:"read_timeout" yaml:"read_timeout"`         // 读取超时
+	WriteTimeout   time.Duration `json:"write_timeout" yaml:"write_timeout"`       // 写入超时
+	KeyPrefix      string        `json:"key_prefix" yaml:"key_prefix"`             // 键前缀
+}
+
+// NewRedisClusterCache 使用Redis Cluster客户端创建缓存实例
+//
+// MGet、MDelete按槽位拆分，避免CROSSSLOT错误；Clear、DeletePrefix在每个主节点上执行。
+func NewRedisClusterCache(client *redis.ClusterClient, keyPrefix string) *SimpleRedisCache {
+	return &SimpleRedisCache{
+		client:    client,
+		cluster:   client,
+		stats:     Stats{LastUpdated: time.Now()},
+		keyPrefix: keyPrefix,
+	}
+}
+
+// RedisClusterSlot 计算键所在的槽位，键中包含非空哈希标签 {...} 时只对标签内容计算
+func RedisClusterSlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key)) % redisClusterSlots
+}
+
+// crc16 CRC16-CCITT (XMODEM)，与Redis Cluster的槽位算法一致
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// groupBySlot 按槽位分组，返回各组在原切片中的下标
+func groupBySlot(keys []string) map[int][]int {
+	groups := make(map[int][]int)
+	for i, key := range keys {
+		slot := RedisClusterSlot(key)
+		groups[slot] = append(groups[slot], i)
+	}
+	return groups
+}
+
+// clusterMGet 按槽位拆分MGET，通过一个流水线发送，每个节点一次往返
+func (r *SimpleRedisCache) clusterMGet(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	prefixedKeys := r.addKeyPrefixToSlice(keys)
+	groups := groupBySlot(prefixedKeys)
+
+	pipe := r.cluster.Pipeline()
+	indexes := make([][]int, 0, len(groups))
+	cmds := make([]*redis.SliceCmd, 0, len(groups))
+	for _, group := range groups {
+		slotKeys := make([]string, len(group))
+		for i, idx := range group {
+			slotKeys[i] = prefixedKeys[idx]
+		}
+		indexes = append(indexes, group)
+		cmds = append(cmds, pipe.MGet(ctx, slotKeys...))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.stats.Errors++
+		return nil, err
+	}
+
+	data := make(map[string]interface{})
+	for i, cmd := range cmds {
+		for j, result := range cmd.Val() {
+			if result != nil {
+				data[keys[indexes[i][j]]] = result // 返回原始键名，不包含前缀
+				r.stats.Hits++
+			} else {
+				r.stats.Misses++
+			}
+		}
+	}
+	return data, nil
+}
+
+// deleteKeys 删除带前缀的键，集群模式下按槽位拆分DEL
+func (r *SimpleRedisCache) deleteKeys(ctx context.Context, prefixedKeys []string) (int64, error) {
+	if len(prefixedKeys) == 0 {
+		return 0, nil
+	}
+	if r.cluster == nil {
+		return r.client.Del(ctx, prefixedKeys...).Result()
+	}
+
+	pipe := r.cluster.Pipeline()
+	cmds := make([]*redis.IntCmd, 0)
+	for _, group := range groupBySlot(prefixedKeys) {
+		slotKeys := make([]string, len(group))
+		for i, idx := range group {
+			slotKeys[i] = prefixedKeys[idx]
+		}
+		cmds = append(cmds, pipe.Del(ctx, slotKeys...))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	for _, cmd := range cmds {
+		deleted += cmd.Val()
+	}
+	return deleted, nil
+}
+
+// RedisClusterBuilder Redis Cluster缓存构建器
+type RedisClusterBuilder struct {
+	client *redis.ClusterClient
+}
+
+// NewRedisClusterBuilder 创建Redis Cluster缓存构建器，client为nil时根据Settings中的addrs创建客户端
+func NewRedisClusterBuilder(client *redis.ClusterClient) *RedisClusterBuilder {
+	return &RedisClusterBuilder{
+		client: client,
+	}
+}
+
+// Build 构建缓存实例
+func (b *RedisClusterBuilder) Build(config Config) (Cache, error) {
+	var clusterConfig RedisClusterCacheConfig
+	if err := decodeSettings(config.Settings, &clusterConfig); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal redis cluster config: %w", err)
+	}
+
+	if len(clusterConfig.Addrs) == 0 {
+		if b.client == nil {
+			return nil, fmt.Errorf("redis cluster client not provided and no addrs configured")
+		}
+		return NewRedisClusterCache(b.client, clusterConfig.KeyPrefix), nil
+	}
+
+	client := redis.NewClusterClient(&redis.ClusterOptions{
+		Addrs:          clusterConfig.Addrs,
+		Username:       clusterConfig.Username,
+		Password:       clusterConfig.Password,
+		PoolSize:       clusterConfig.PoolSize,
+		MaxRetries:     clusterConfig.MaxRetries,
+		MaxRedirects:   clusterConfig.MaxRedirects,
+		ReadOnly:       clusterConfig.ReadOnly,
+		RouteByLatency: clusterConfig.RouteByLatency,
+		RouteRandomly:  clusterConfig.RouteRandomly,
+		DialTimeout:    clusterConfig.DialTimeout,
+		ReadTimeout:    clusterConfig.ReadTimeout,
+		WriteTimeout:   clusterConfig.WriteTimeout,
+	})
+	cache := NewRedisClusterCache(client, clusterConfig.KeyPrefix)
+	cache.ownsClient = true
+	return cache, nil
+}
+
+// registerRedisTopologyBuilders 注册Cluster与Sentinel构建器，Cluster优先使用框架的集群客户端
+func registerRedisTopologyBuilders(manager *Manager) {
+	var clusterClient *redis.ClusterClient
+	if client := redis_cluster.GetClient(); client != nil {
+		clusterClient = client.GetClient()
+	}
+	manager.RegisterBuilder(TypeRedisCluster, NewRedisClusterBuilder(clusterClient))
+	manager.RegisterBuilder(TypeRedisSentinel, NewRedisSentinelBuilder(nil))
+}
+
+// decodeSettings 将Settings按json标签转换为配置结构，时长字段同时支持 "5s" 形式的字符串和纳秒数
+func decodeSettings(settings map[string]interface{}, out interface{}) error {
+	if settings == nil {
+		return nil
+	}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		TagName:          "json",
+		WeaklyTypedInput: true,
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		Result:           out,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(settings)
+}
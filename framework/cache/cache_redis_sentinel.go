@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisSentinelCacheConfig Redis Sentinel缓存配置
+type RedisSentinelCacheConfig struct {
+	MasterName       string        `json:"master_name" yaml:"master_name"`             // Sentinel监控的主节点名称
+	SentinelAddrs    []string      `json:"sentinel_addrs" yaml:"sentinel_addrs"`       // Sentinel节点地址
+	SentinelPassword string        `json:"sentinel_password" yaml:"sentinel_password"` // Sentinel密码
+	Username         string        `json:"username" yaml:"username"`                   // 用户名（Redis 6 ACL）
+	Password         string        `json:"password" yaml:"password"`                   // 数据节点密码
+	DB               int           `json:"db" yaml:"db"`                               // 数据库编号
+	PoolSize         int           `json:"pool_size" yaml:"pool_size"`                 // 连接池大小
+	MaxRetries       int           `json:"max_retries" yaml:"max_retries"`             // 最大重试次数
+	DialTimeout      time.Duration `json:"dial_timeout" yaml:"dial_timeout"`           // 连接超时
+	ReadTimeout      time.Duration `json:"read_timeout" yaml:"read_timeout"`           // 读取超时
+	WriteTimeout     time.Duration `json:"write_timeout" yaml:"write_timeout"`         // 写入超时
+	KeyPrefix        string        `json:"key_prefix" yaml:"key_prefix"`               // 键前缀
+}
+
+// RedisSentinelBuilder Redis Sentinel缓存构建器，主节点故障转移后客户端自动连接新的主节点
+type RedisSentinelBuilder struct {
+	client *redis.Client
+}
+
+// NewRedisSentinelBuilder 创建Redis Sentinel缓存构建器
+//
+// client 为 redis.NewFailoverClient 创建的客户端，为nil时根据Settings中的master_name和sentinel_addrs创建。
+func NewRedisSentinelBuilder(client *redis.Client) *RedisSentinelBuilder {
+	return &RedisSentinelBuilder{
+		client: client,
+	}
+}
+
+// Build 构建缓存实例
+func (b *RedisSentinelBuilder) Build(config Config) (Cache, error) {
+	var sentinelConfig RedisSentinelCacheConfig
+	if err := decodeSettings(config.Settings, &sentinelConfig); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal redis sentinel config: %w", err)
+	}
+
+	if sentinelConfig.MasterName == "" || len(sentinelConfig.SentinelAddrs) == 0 {
+		if b.client == nil {
+			return nil, fmt.Errorf("redis sentinel client not provided and master_name/sentinel_addrs not configured")
+		}
+		return NewSimpleRedisCache(b.client, sentinelConfig.KeyPrefix), nil
+	}
+
+	client := redis.NewFailoverClient(&redis.FailoverOptions{
+		MasterName:       sentinelConfig.MasterName,
+		SentinelAddrs:    sentinelConfig.SentinelAddrs,
+		SentinelPassword: sentinelConfig.SentinelPassword,
+		Username:         sentinelConfig.Username,
+		Password:         sentinelConfig.Password,
+		DB:               sentinelConfig.DB,
+		PoolSize:         sentinelConfig.PoolSize,
+		MaxRetries:       sentinelConfig.MaxRetries,
+		DialTimeout:      sentinelConfig.DialTimeout,
+		ReadTimeout:      sentinelConfig.ReadTimeout,
+		WriteTimeout:     sentinelConfig.WriteTimeout,
+	})
+	cache := NewSimpleRedisCache(client, sentinelConfig.KeyPrefix)
+	cache.ownsClient = true
+	return cache, nil
+}
@@ -0,0 +1,207 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// redisTagKeyPrefix 标签集合键前缀，集合成员为带前缀的完整键
+	redisTagKeyPrefix = "__tag__:"
+	// redisScanCount 每次SCAN/SSCAN的数量提示，也是批量删除的大小
+	redisScanCount = 500
+)
+
+// redisTagScript 将键加入标签集合，集合过期时间取所有成员中最长的
+var redisTagScript = redis.NewScript(`
+local existed = redis.call('EXISTS', KEYS[1])
+redis.call('SADD', KEYS[1], ARGV[1])
+local ttl = tonumber(ARGV[2])
+if ttl <= 0 then
+	redis.call('PERSIST', KEYS[1])
+elseif existed == 0 then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+else
+	local current = redis.call('PTTL', KEYS[1])
+	if current >= 0 and current < ttl then
+		redis.call('PEXPIRE', KEYS[1], ttl)
+	end
+end
+return 1
+`)
+
+// redisRenameTagScript 标签集合存在时重命名为临时键，返回是否重命名
+var redisRenameTagScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('RENAME', KEYS[1], KEYS[2])
+return 1
+`)
+
+// tagKey 标签集合的键
+//
+// 标签名放在哈希标签 {} 中，保证集群模式下失效时RENAME出的临时键与原集合在同一槽位。
+func (r *SimpleRedisCache) tagKey(tag string) string {
+	return r.addKeyPrefix(redisTagKeyPrefix + "{" + tag + "}")
+}
+
+// SetWithTags 设置值并将键加入各标签集合
+func (r *SimpleRedisCache) SetWithTags(ctx context.Context, key string, value interface{}, expiration time.Duration, tags ...string) error {
+	prefixedKey := r.addKeyPrefix(key)
+
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, prefixedKey, value, expiration)
+	for _, tag := range tags {
+		redisTagScript.Eval(ctx, pipe, []string{r.tagKey(tag)}, prefixedKey, expiration.Milliseconds())
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.stats.Errors++
+		return fmt.Errorf("failed to set key %s with tags: %w", key, err)
+	}
+
+	r.stats.Sets++
+	return nil
+}
+
+// InvalidateTag 删除标签集合中的所有键
+//
+// 先将标签集合重命名为临时键，失效过程中新写入的键会进入新的集合，不会被误删或丢失标签。
+func (r *SimpleRedisCache) InvalidateTag(ctx context.Context, tag string) error {
+	suffix := make([]byte, 8)
+	_, _ = rand.Read(suffix)
+	pending := r.tagKey(tag) + ":invalidating:" + hex.EncodeToString(suffix)
+
+	renamed, err := redisRenameTagScript.Run(ctx, r.client, []string{r.tagKey(tag), pending}).Int()
+	if err != nil {
+		r.stats.Errors++
+		return fmt.Errorf("failed to invalidate tag %s: %w", tag, err)
+	}
+	if renamed == 0 {
+		return nil
+	}
+
+	deleted, err := r.deleteMembers(ctx, pending)
+	r.stats.Deletes += deleted
+	if err != nil {
+		r.stats.Errors++
+		return fmt.Errorf("failed to invalidate tag %s: %w", tag, err)
+	}
+	return r.client.Del(ctx, pending).Err()
+}
+
+// deleteMembers 分批删除集合中的键
+func (r *SimpleRedisCache) deleteMembers(ctx context.Context, setKey string) (int64, error) {
+	var deleted int64
+	var cursor uint64
+	for {
+		members, next, err := r.client.SScan(ctx, setKey, cursor, "", redisScanCount).Result()
+		if err != nil {
+			return deleted, err
+		}
+		if len(members) > 0 {
+			n, err := r.deleteKeys(ctx, members)
+			if err != nil {
+				return deleted, err
+			}
+			deleted += n
+		}
+		if next == 0 {
+			return deleted, nil
+		}
+		cursor = next
+	}
+}
+
+// TagKeys 标签集合中的键（不含键前缀）
+func (r *SimpleRedisCache) TagKeys(ctx context.Context, tag string) ([]string, error) {
+	var keys []string
+	var cursor uint64
+	for {
+		members, next, err := r.client.SScan(ctx, r.tagKey(tag), cursor, "", redisScanCount).Result()
+		if err != nil {
+			r.stats.Errors++
+			return nil, fmt.Errorf("failed to scan tag %s: %w", tag, err)
+		}
+		for _, member := range members {
+			keys = append(keys, r.stripKeyPrefix(member))
+		}
+		if next == 0 {
+			return keys, nil
+		}
+		cursor = next
+	}
+}
+
+// DeletePrefix 通过SCAN删除以prefix开头的键，不会像KEYS一样阻塞Redis
+//
+// 集群模式下SCAN只遍历单个节点，因此在每个主节点上分别执行。
+func (r *SimpleRedisCache) DeletePrefix(ctx context.Context, prefix string) error {
+	pattern := escapeRedisPattern(r.addKeyPrefix(prefix)) + "*"
+
+	var deleted int64
+	var err error
+	if r.cluster != nil {
+		err = r.cluster.ForEachMaster(ctx, func(ctx context.Context, master *redis.Client) error {
+			return r.deleteMatching(ctx, master, pattern, &deleted)
+		})
+	} else {
+		err = r.deleteMatching(ctx, r.client, pattern, &deleted)
+	}
+	r.stats.Deletes += deleted
+	if err != nil {
+		r.stats.Errors++
+		return fmt.Errorf("failed to delete prefix %s: %w", prefix, err)
+	}
+	return nil
+}
+
+// deleteMatching 在一个节点上SCAN并删除匹配的键，各主节点并发执行时通过deleted累计删除数
+func (r *SimpleRedisCache) deleteMatching(ctx context.Context, node redis.Cmdable, pattern string, deleted *int64) error {
+	var cursor uint64
+	for {
+		keys, next, err := node.Scan(ctx, cursor, pattern, redisScanCount).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			n, err := r.deleteKeys(ctx, keys)
+			if err != nil {
+				return err
+			}
+			atomic.AddInt64(deleted, n)
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// stripKeyPrefix 去掉键前缀
+func (r *SimpleRedisCache) stripKeyPrefix(key string) string {
+	if r.keyPrefix == "" {
+		return key
+	}
+	return strings.TrimPrefix(key, r.keyPrefix+":")
+}
+
+// escapeRedisPattern 转义SCAN MATCH中的通配符
+func escapeRedisPattern(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
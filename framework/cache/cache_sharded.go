@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ShardedMemoryCache 分片内存缓存
+//
+// 按键哈希分布到 ShardCount 个相互独立加锁的 MemoryCache 分片，
+// 每个分片容量为 MaxSize/ShardCount，降低高并发下的锁竞争。
+type ShardedMemoryCache struct {
+	shards []*MemoryCache
+	config MemoryConfig
+}
+
+// NewShardedMemoryCache 创建分片内存缓存
+func NewShardedMemoryCache(config MemoryConfig) (*ShardedMemoryCache, error) {
+	if config.ShardCount <= 0 {
+		return nil, fmt.Errorf("shard count must be positive, got %d", config.ShardCount)
+	}
+	if config.MaxSize <= 0 && config.MaxBytes <= 0 {
+		config.MaxSize = 1000
+	}
+
+	shardConfig := config
+	shardConfig.ShardCount = 0
+	shardConfig.SnapshotPath = "" // 快照由分片缓存统一导出和恢复
+	shardConfig.MaxSize = (config.MaxSize + config.ShardCount - 1) / config.ShardCount
+	shardConfig.PreAllocSize = (config.PreAllocSize + config.ShardCount - 1) / config.ShardCount
+	if config.MaxBytes > 0 {
+		shardConfig.MaxBytes = (config.MaxBytes + int64(config.ShardCount) - 1) / int64(config.ShardCount)
+	}
+
+	shards := make([]*MemoryCache, config.ShardCount)
+	for i := range shards {
+		shard, err := NewMemoryCache(shardConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create shard %d: %w", i, err)
+		}
+		shards[i] = shard
+	}
+
+	s := &ShardedMemoryCache{
+		shards: shards,
+		config: config,
+	}
+	if config.SnapshotPath != "" {
+		restoreFromSnapshot(config.SnapshotPath, s.restoreItem)
+	}
+	return s, nil
+}
+
+// shard 根据键选择分片（FNV-1a）
+func (s *ShardedMemoryCache) shard(key string) *MemoryCache {
+	hash := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		hash ^= uint32(key[i])
+		hash *= 16777619
+	}
+	return s.shards[hash%uint32(len(s.shards))]
+}
+
+func (s *ShardedMemoryCache) Get(ctx context.Context, key string) (interface{}, error) {
+	return s.shard(key).Get(ctx, key)
+}
+
+func (s *ShardedMemoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return s.shard(key).Set(ctx, key, value, expiration)
+}
+
+func (s *ShardedMemoryCache) Delete(ctx context.Context, key string) error {
+	return s.shard(key).Delete(ctx, key)
+}
+
+func (s *ShardedMemoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	return s.shard(key).Exists(ctx, key)
+}
+
+func (s *ShardedMemoryCache) Clear(ctx context.Context) error {
+	for _, shard := range s.shards {
+		if err := shard.Clear(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ShardedMemoryCache) Close() error {
+	var lastErr error
+	if s.config.SnapshotPath != "" {
+		if _, err := s.SaveSnapshot(s.config.SnapshotPath); err != nil {
+			lastErr = fmt.Errorf("failed to save memory cache snapshot: %w", err)
+		}
+	}
+	for _, shard := range s.shards {
+		if err := shard.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// GetStats 汇总所有分片的统计信息
+func (s *ShardedMemoryCache) GetStats() Stats {
+	var total Stats
+	for _, shard := range s.shards {
+		stats := shard.GetStats()
+		total.Hits += stats.Hits
+		total.Misses += stats.Misses
+		total.Sets += stats.Sets
+		total.Deletes += stats.Deletes
+		total.Errors += stats.Errors
+		total.Evictions += stats.Evictions
+		total.Bytes += stats.Bytes
+		if stats.LastUpdated.After(total.LastUpdated) {
+			total.LastUpdated = stats.LastUpdated
+		}
+	}
+	return total
+}
+
+func (s *ShardedMemoryCache) ResetStats() {
+	for _, shard := range s.shards {
+		shard.ResetStats()
+	}
+}
+
+// SetEvictionCallback 设置淘汰回调函数
+func (s *ShardedMemoryCache) SetEvictionCallback(callback func(string, interface{})) {
+	for _, shard := range s.shards {
+		shard.SetEvictionCallback(callback)
+	}
+}
+
+// ShardCount 分片数量
+func (s *ShardedMemoryCache) ShardCount() int {
+	return len(s.shards)
+}
+
+// Len 所有分片的条目数之和
+func (s *ShardedMemoryCache) Len() int {
+	total := 0
+	for _, shard := range s.shards {
+		total += shard.Len()
+	}
+	return total
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec 缓存值编解码器
+type Codec interface {
+	// Name 编解码器名称
+	Name() string
+	// Marshal 编码
+	Marshal(v interface{}) ([]byte, error)
+	// Unmarshal 解码到v，v为指针
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONCodec JSON编解码器
+type JSONCodec struct{}
+
+func (JSONCodec) Name() string { return "json" }
+
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// GobCodec gob编解码器，接口类型字段需要预先 gob.Register
+type GobCodec struct{}
+
+func (GobCodec) Name() string { return "gob" }
+
+func (GobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// MsgpackCodec MessagePack编解码器
+type MsgpackCodec struct {
+	handle *codec.MsgpackHandle
+}
+
+// NewMsgpackCodec 创建MessagePack编解码器
+func NewMsgpackCodec() *MsgpackCodec {
+	handle := &codec.MsgpackHandle{}
+	handle.WriteExt = true
+	handle.RawToString = true
+	handle.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	return &MsgpackCodec{handle: handle}
+}
+
+func (c *MsgpackCodec) Name() string { return "msgpack" }
+
+func (c *MsgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var data []byte
+	if err := codec.NewEncoderBytes(&data, c.handle).Encode(v); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *MsgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	return codec.NewDecoderBytes(data, c.handle).Decode(v)
+}
+
+// ProtobufCodec Protocol Buffers编解码器，值类型必须实现 proto.Message
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) Name() string { return "protobuf" }
+
+func (ProtobufCodec) Marshal(v interface{}) ([]byte, error) {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("protobuf codec: %T does not implement proto.Message", v)
+	}
+	return proto.Marshal(message)
+}
+
+// Unmarshal 支持 proto.Message 以及指向 nil 消息指针的指针（如 TypedCache[*pb.User] 的解码目标）
+func (ProtobufCodec) Unmarshal(data []byte, v interface{}) error {
+	if message, ok := v.(proto.Message); ok {
+		return proto.Unmarshal(data, message)
+	}
+
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return fmt.Errorf("protobuf codec: cannot decode into %T", v)
+	}
+	elem := target.Elem()
+	if elem.Kind() == reflect.Ptr && elem.IsNil() {
+		elem.Set(reflect.New(elem.Type().Elem()))
+	}
+	message, ok := elem.Interface().(proto.Message)
+	if !ok {
+		return fmt.Errorf("protobuf codec: %T does not implement proto.Message", elem.Interface())
+	}
+	return proto.Unmarshal(data, message)
+}
+
+// NewCodec 按名称创建编解码器：json（默认）、gob、msgpack、protobuf
+func NewCodec(name string) (Codec, error) {
+	switch name {
+	case "", "json":
+		return JSONCodec{}, nil
+	case "gob":
+		return GobCodec{}, nil
+	case "msgpack":
+		return NewMsgpackCodec(), nil
+	case "protobuf", "proto":
+		return ProtobufCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported codec: %s", name)
+	}
+}
@@ -1,8 +1,11 @@
 package cache
 
 import (
-	"github.com/qiaojinxia/distributed-service/framework/config"
+	"fmt"
+	"strconv"
 	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/config"
 )
 
 // 预设配置模板
@@ -139,6 +142,26 @@ func (c *CustomHybridConfig) WithWriteBack(enabled bool, interval time.Duration,
 	return c
 }
 
+// WithWriteBackOverflow 设置写回队列容量及队列满时的处理方式
+func (c *CustomHybridConfig) WithWriteBackOverflow(maxPending int, policy WriteBackOverflowPolicy) *CustomHybridConfig {
+	c.config.WriteBackMaxPending = maxPending
+	c.config.WriteBackOverflow = policy
+	return c
+}
+
+// WithWriteBackJournal 设置写回日志 (file, redis) 及其路径
+func (c *CustomHybridConfig) WithWriteBackJournal(backend, path string) *CustomHybridConfig {
+	c.config.WriteBackJournalBackend = backend
+	c.config.WriteBackJournalPath = path
+	return c
+}
+
+// WithWriteBackFlushTimeout 设置关闭时写回的超时时间
+func (c *CustomHybridConfig) WithWriteBackFlushTimeout(timeout time.Duration) *CustomHybridConfig {
+	c.config.WriteBackFlushTimeout = timeout
+	return c
+}
+
 // Build 构建配置
 func (c *CustomHybridConfig) Build() HybridConfig {
 	return c.config
@@ -183,6 +206,42 @@ func NewRedisConfigFromFramework(frameworkConfig *config.Config, keyPrefix strin
 	}
 }
 
+// NewMemoryConfigFromFramework 从框架配置创建内存缓存配置
+func NewMemoryConfigFromFramework(memoryConfig config.CacheMemoryConfig) (MemoryConfig, error) {
+	memConfig := MemoryConfig{
+		MaxSize:        memoryConfig.MaxSize,
+		MaxBytes:       memoryConfig.MaxBytes,
+		EvictionPolicy: EvictionPolicy(memoryConfig.EvictionPolicy),
+		ShardCount:     memoryConfig.ShardCount,
+		PreAllocSize:   memoryConfig.PreAllocSize,
+		LFUMinFreq:     memoryConfig.LFUMinFreq,
+	}
+
+	if memoryConfig.CleanupInterval != "" {
+		interval, err := time.ParseDuration(memoryConfig.CleanupInterval)
+		if err != nil {
+			return memConfig, fmt.Errorf("invalid cleanup interval: %w", err)
+		}
+		memConfig.CleanupInterval = interval
+	}
+	if memoryConfig.LFUDecayRate != "" {
+		rate, err := strconv.ParseFloat(memoryConfig.LFUDecayRate, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return memConfig, fmt.Errorf("invalid lfu decay rate %q: must be between 0 and 1", memoryConfig.LFUDecayRate)
+		}
+		memConfig.LFUDecayRate = rate
+	}
+	if memoryConfig.LFUDecayInterval != "" {
+		interval, err := time.ParseDuration(memoryConfig.LFUDecayInterval)
+		if err != nil {
+			return memConfig, fmt.Errorf("invalid lfu decay interval: %w", err)
+		}
+		memConfig.LFUDecayInterval = interval
+	}
+
+	return memConfig, nil
+}
+
 // ConfigBuilder 缓存配置构建器
 type ConfigBuilder struct {
 	config Config
@@ -230,6 +289,27 @@ func (b *ConfigBuilder) WithMemoryMaxSize(size int) *ConfigBuilder {
 	return b.WithSetting("max_size", size)
 }
 
+// WithEvictionPolicy 设置内存缓存淘汰策略
+func (b *ConfigBuilder) WithEvictionPolicy(policy EvictionPolicy) *ConfigBuilder {
+	return b.WithSetting("eviction_policy", policy)
+}
+
+// WithShardCount 设置内存缓存分片数量
+func (b *ConfigBuilder) WithShardCount(count int) *ConfigBuilder {
+	return b.WithSetting("shard_count", count)
+}
+
+// WithLFUDecay 设置LFU频率衰减比例和周期
+func (b *ConfigBuilder) WithLFUDecay(rate float64, interval time.Duration) *ConfigBuilder {
+	b.WithSetting("lfu_decay_rate", rate)
+	return b.WithSetting("lfu_decay_interval", interval)
+}
+
+// WithMemoryMaxBytes 设置内存缓存最大字节数
+func (b *ConfigBuilder) WithMemoryMaxBytes(maxBytes int64) *ConfigBuilder {
+	return b.WithSetting("max_bytes", maxBytes)
+}
+
 // WithTTL 设置TTL
 func (b *ConfigBuilder) WithTTL(ttl time.Duration) *ConfigBuilder {
 	return b.WithSetting("default_ttl", ttl.String())
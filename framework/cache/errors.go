@@ -10,3 +10,15 @@ var ErrCacheNotFound = fmt.Errorf("cache not found")
 
 // ErrStatsNotSupported 统计功能不支持错误
 var ErrStatsNotSupported = fmt.Errorf("stats not supported")
+
+// ErrEntryTooLarge 条目大小超过缓存字节数上限
+var ErrEntryTooLarge = fmt.Errorf("cache entry too large")
+
+// ErrTagsNotSupported 缓存不支持标签或前缀失效
+var ErrTagsNotSupported = fmt.Errorf("cache does not support tag or prefix invalidation")
+
+// ErrNotSupported 底层缓存不支持该操作
+var ErrNotSupported = fmt.Errorf("operation not supported by cache")
+
+// ErrInvalidKey 键不符合后端的格式要求
+var ErrInvalidKey = fmt.Errorf("invalid cache key")
@@ -0,0 +1,368 @@
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// cacheEntry 自实现淘汰策略的缓存条目
+type cacheEntry struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+	cost      int64 // 条目大小（按字节数限制容量时）
+
+	// 由淘汰算法维护
+	element *list.Element // 所在链表节点（lru, fifo, arc, tinylfu）
+	index   int           // 所在切片/堆下标（random, lfu）
+	freq    float64       // 访问频率（lfu）
+	tick    uint64        // 最近访问序号（lfu同频时按LRU淘汰）
+	segment int           // 所在分段（arc, tinylfu）
+}
+
+// expired 判断条目是否过期
+func (e *cacheEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// evictionAlgorithm 淘汰算法，所有方法都在 policyStore 持锁时调用
+type evictionAlgorithm interface {
+	// add 记录新写入的条目
+	add(e *cacheEntry)
+	// access 记录一次命中或更新
+	access(e *cacheEntry)
+	// remove 移除被删除或过期的条目
+	remove(e *cacheEntry)
+	// evict 选择一个淘汰对象并从算法中移除
+	evict() *cacheEntry
+	// clear 清空算法状态
+	clear()
+}
+
+// defaultAlgorithmCapacity 只按字节数限制容量时，ARC/TinyLFU 按此条目数规划内部分段
+const defaultAlgorithmCapacity = 1000
+
+// policyStore 基于自实现淘汰算法的存储（lfu, fifo, random, arc, tinylfu），
+// 按字节数限制容量时所有策略都使用该存储
+type policyStore struct {
+	mu          sync.Mutex
+	items       map[string]*cacheEntry
+	algorithm   evictionAlgorithm
+	maxSize     int
+	maxBytes    int64
+	bytes       int64
+	sizer       Sizer
+	preAlloc    int
+	cleanup     time.Duration
+	lastCleanup time.Time
+	onEvicted   func(key string, value interface{}, expired bool)
+}
+
+// newPolicyStore 创建存储
+func newPolicyStore(config MemoryConfig, onEvicted func(string, interface{}, bool)) *policyStore {
+	capacity := config.MaxSize
+	if capacity <= 0 {
+		capacity = defaultAlgorithmCapacity
+	}
+
+	var algorithm evictionAlgorithm
+	switch config.EvictionPolicy {
+	case EvictionPolicyLRU, EvictionPolicyTTL, EvictionPolicySimple:
+		algorithm = newLRUAlgorithm()
+	case EvictionPolicyLFU:
+		algorithm = newLFUAlgorithm(config.LFUDecayRate, config.LFUDecayInterval, config.LFUMinFreq)
+	case EvictionPolicyFIFO:
+		algorithm = newFIFOAlgorithm()
+	case EvictionPolicyRandom:
+		algorithm = newRandomAlgorithm()
+	case EvictionPolicyARC:
+		algorithm = newARCAlgorithm(capacity)
+	case EvictionPolicyTinyLFU:
+		algorithm = newTinyLFUAlgorithm(capacity)
+	default:
+		return nil
+	}
+
+	return &policyStore{
+		items:       make(map[string]*cacheEntry, config.PreAllocSize),
+		algorithm:   algorithm,
+		maxSize:     config.MaxSize,
+		maxBytes:    config.MaxBytes,
+		sizer:       config.Sizer,
+		preAlloc:    config.PreAllocSize,
+		cleanup:     config.CleanupInterval,
+		lastCleanup: time.Now(),
+		onEvicted:   onEvicted,
+	}
+}
+
+// get 获取值并记录访问
+func (s *policyStore) get(key string) (interface{}, bool) {
+	now := time.Now()
+
+	s.mu.Lock()
+	e, ok := s.items[key]
+	if !ok {
+		s.mu.Unlock()
+		return nil, false
+	}
+	if e.expired(now) {
+		s.removeLocked(e)
+		s.mu.Unlock()
+		s.notify([]*cacheEntry{e}, true)
+		return nil, false
+	}
+	s.algorithm.access(e)
+	value := e.value
+	s.mu.Unlock()
+
+	return value, true
+}
+
+// contains 判断键是否存在，不影响淘汰顺序
+func (s *policyStore) contains(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.items[key]
+	return ok && !e.expired(time.Now())
+}
+
+// set 写入值，必要时淘汰旧条目
+func (s *policyStore) set(key string, value interface{}, ttl time.Duration) error {
+	now := time.Now()
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = now.Add(ttl)
+	}
+
+	var cost int64
+	if s.sizer != nil {
+		cost = s.sizer(key, value)
+		if s.maxBytes > 0 && cost > s.maxBytes {
+			return fmt.Errorf("%w: %d bytes exceeds limit of %d bytes", ErrEntryTooLarge, cost, s.maxBytes)
+		}
+	}
+
+	s.mu.Lock()
+	expired := s.cleanupLocked(now)
+
+	if e, ok := s.items[key]; ok {
+		s.bytes += cost - e.cost
+		e.value = value
+		e.cost = cost
+		e.expiresAt = expiresAt
+		s.algorithm.access(e)
+	} else {
+		e := &cacheEntry{key: key, value: value, expiresAt: expiresAt, cost: cost}
+		s.items[key] = e
+		s.bytes += cost
+		s.algorithm.add(e)
+	}
+
+	var evicted []*cacheEntry
+	for s.overLimitLocked() {
+		victim := s.algorithm.evict()
+		if victim == nil {
+			break
+		}
+		delete(s.items, victim.key)
+		s.bytes -= victim.cost
+		evicted = append(evicted, victim)
+	}
+	s.mu.Unlock()
+
+	s.notify(expired, true)
+	s.notify(evicted, false)
+	return nil
+}
+
+// keys 当前所有键的快照
+func (s *policyStore) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.items))
+	for key := range s.items {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// len 当前条目数
+func (s *policyStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// size 当前条目总大小
+func (s *policyStore) size() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bytes
+}
+
+// overLimitLocked 判断是否超出条目数或字节数限制（调用方需持有s.mu）
+func (s *policyStore) overLimitLocked() bool {
+	return (s.maxSize > 0 && len(s.items) > s.maxSize) ||
+		(s.maxBytes > 0 && s.bytes > s.maxBytes)
+}
+
+// delete 删除键
+func (s *policyStore) delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.items[key]; ok {
+		s.removeLocked(e)
+	}
+}
+
+// clear 清空存储
+func (s *policyStore) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items = make(map[string]*cacheEntry, s.preAlloc)
+	s.bytes = 0
+	s.algorithm.clear()
+}
+
+// removeLocked 移除条目（调用方需持有s.mu）
+func (s *policyStore) removeLocked(e *cacheEntry) {
+	delete(s.items, e.key)
+	s.bytes -= e.cost
+	s.algorithm.remove(e)
+}
+
+// cleanupLocked 按清理间隔移除过期条目（调用方需持有s.mu）
+func (s *policyStore) cleanupLocked(now time.Time) []*cacheEntry {
+	if s.cleanup <= 0 || now.Sub(s.lastCleanup) < s.cleanup {
+		return nil
+	}
+	s.lastCleanup = now
+
+	var expired []*cacheEntry
+	for _, e := range s.items {
+		if e.expired(now) {
+			s.removeLocked(e)
+			expired = append(expired, e)
+		}
+	}
+	return expired
+}
+
+// notify 在锁外触发淘汰回调
+func (s *policyStore) notify(entries []*cacheEntry, expired bool) {
+	if s.onEvicted == nil {
+		return
+	}
+	for _, e := range entries {
+		s.onEvicted(e.key, e.value, expired)
+	}
+}
+
+// lruAlgorithm 最近最少使用（按字节数限制容量时的 lru/ttl/simple 策略）
+type lruAlgorithm struct {
+	order *list.List
+}
+
+func newLRUAlgorithm() *lruAlgorithm {
+	return &lruAlgorithm{order: list.New()}
+}
+
+func (a *lruAlgorithm) add(e *cacheEntry) {
+	e.element = a.order.PushFront(e)
+}
+
+func (a *lruAlgorithm) access(e *cacheEntry) {
+	a.order.MoveToFront(e.element)
+}
+
+func (a *lruAlgorithm) remove(e *cacheEntry) {
+	a.order.Remove(e.element)
+}
+
+func (a *lruAlgorithm) evict() *cacheEntry {
+	back := a.order.Back()
+	if back == nil {
+		return nil
+	}
+	return a.order.Remove(back).(*cacheEntry)
+}
+
+func (a *lruAlgorithm) clear() {
+	a.order.Init()
+}
+
+// fifoAlgorithm 先进先出
+type fifoAlgorithm struct {
+	queue *list.List
+}
+
+func newFIFOAlgorithm() *fifoAlgorithm {
+	return &fifoAlgorithm{queue: list.New()}
+}
+
+func (a *fifoAlgorithm) add(e *cacheEntry) {
+	e.element = a.queue.PushBack(e)
+}
+
+func (a *fifoAlgorithm) access(e *cacheEntry) {}
+
+func (a *fifoAlgorithm) remove(e *cacheEntry) {
+	a.queue.Remove(e.element)
+}
+
+func (a *fifoAlgorithm) evict() *cacheEntry {
+	front := a.queue.Front()
+	if front == nil {
+		return nil
+	}
+	return a.queue.Remove(front).(*cacheEntry)
+}
+
+func (a *fifoAlgorithm) clear() {
+	a.queue.Init()
+}
+
+// randomAlgorithm 随机淘汰
+type randomAlgorithm struct {
+	entries []*cacheEntry
+}
+
+func newRandomAlgorithm() *randomAlgorithm {
+	return &randomAlgorithm{}
+}
+
+func (a *randomAlgorithm) add(e *cacheEntry) {
+	e.index = len(a.entries)
+	a.entries = append(a.entries, e)
+}
+
+func (a *randomAlgorithm) access(e *cacheEntry) {}
+
+func (a *randomAlgorithm) remove(e *cacheEntry) {
+	last := len(a.entries) - 1
+	a.entries[e.index] = a.entries[last]
+	a.entries[e.index].index = e.index
+	a.entries[last] = nil
+	a.entries = a.entries[:last]
+}
+
+func (a *randomAlgorithm) evict() *cacheEntry {
+	if len(a.entries) == 0 {
+		return nil
+	}
+	e := a.entries[rand.Intn(len(a.entries))]
+	a.remove(e)
+	return e
+}
+
+func (a *randomAlgorithm) clear() {
+	a.entries = nil
+}
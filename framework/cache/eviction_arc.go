@@ -0,0 +1,159 @@
+package cache
+
+import "container/list"
+
+// ARC分段
+const (
+	arcRecent   = iota + 1 // T1: 只访问过一次
+	arcFrequent            // T2: 访问过多次
+)
+
+// arcAlgorithm 自适应替换缓存（Adaptive Replacement Cache）
+//
+// T1/T2 分别保存最近和频繁访问的条目，B1/B2 记录从中淘汰的键（幽灵条目）。
+// 幽灵条目命中时调整 T1 的目标大小 p，使缓存在偏重最近访问和偏重访问频率之间自适应，
+// 一次性扫描只会冲刷 T1，不会挤掉 T2 中的热点数据。
+type arcAlgorithm struct {
+	capacity int
+	p        int // T1 的目标大小
+
+	t1, t2 *list.List
+	b1, b2 *ghostList
+
+	// insertedRecent 最近一次写入进入T1，淘汰时不计入T1大小
+	insertedRecent bool
+}
+
+func newARCAlgorithm(capacity int) *arcAlgorithm {
+	return &arcAlgorithm{
+		capacity: capacity,
+		t1:       list.New(),
+		t2:       list.New(),
+		b1:       newGhostList(capacity),
+		b2:       newGhostList(capacity),
+	}
+}
+
+func (a *arcAlgorithm) add(e *cacheEntry) {
+	a.insertedRecent = false
+
+	switch {
+	case a.b1.remove(e.key):
+		// 最近访问的条目淘汰过早，扩大T1
+		delta := 1
+		if a.b1.len() > 0 && a.b2.len() > a.b1.len() {
+			delta = a.b2.len() / a.b1.len()
+		}
+		a.p = min(a.capacity, a.p+delta)
+		a.pushFrequent(e)
+
+	case a.b2.remove(e.key):
+		// 频繁访问的条目淘汰过早，缩小T1
+		delta := 1
+		if a.b2.len() > 0 && a.b1.len() > a.b2.len() {
+			delta = a.b1.len() / a.b2.len()
+		}
+		a.p = max(0, a.p-delta)
+		a.pushFrequent(e)
+
+	default:
+		e.segment = arcRecent
+		e.element = a.t1.PushFront(e)
+		a.insertedRecent = true
+	}
+}
+
+func (a *arcAlgorithm) access(e *cacheEntry) {
+	if e.segment == arcRecent {
+		a.t1.Remove(e.element)
+		a.pushFrequent(e)
+		return
+	}
+	a.t2.MoveToFront(e.element)
+}
+
+func (a *arcAlgorithm) remove(e *cacheEntry) {
+	a.listOf(e).Remove(e.element)
+}
+
+func (a *arcAlgorithm) evict() *cacheEntry {
+	recent := a.t1.Len()
+	if a.insertedRecent {
+		recent--
+	}
+	a.insertedRecent = false
+
+	if a.t1.Len() > 0 && (recent > a.p || a.t2.Len() == 0) {
+		e := a.t1.Remove(a.t1.Back()).(*cacheEntry)
+		a.b1.add(e.key)
+		return e
+	}
+	if a.t2.Len() > 0 {
+		e := a.t2.Remove(a.t2.Back()).(*cacheEntry)
+		a.b2.add(e.key)
+		return e
+	}
+	return nil
+}
+
+func (a *arcAlgorithm) clear() {
+	a.p = 0
+	a.t1.Init()
+	a.t2.Init()
+	a.b1 = newGhostList(a.capacity)
+	a.b2 = newGhostList(a.capacity)
+	a.insertedRecent = false
+}
+
+// pushFrequent 将条目放入T2头部
+func (a *arcAlgorithm) pushFrequent(e *cacheEntry) {
+	e.segment = arcFrequent
+	e.element = a.t2.PushFront(e)
+}
+
+// listOf 条目所在链表
+func (a *arcAlgorithm) listOf(e *cacheEntry) *list.List {
+	if e.segment == arcRecent {
+		return a.t1
+	}
+	return a.t2
+}
+
+// ghostList 只记录键的有界LRU链表
+type ghostList struct {
+	capacity int
+	order    *list.List
+	keys     map[string]*list.Element
+}
+
+func newGhostList(capacity int) *ghostList {
+	return &ghostList{
+		capacity: capacity,
+		order:    list.New(),
+		keys:     make(map[string]*list.Element),
+	}
+}
+
+func (g *ghostList) len() int {
+	return g.order.Len()
+}
+
+// add 记录键，超出容量时丢弃最旧的键
+func (g *ghostList) add(key string) {
+	g.keys[key] = g.order.PushFront(key)
+	if g.order.Len() > g.capacity {
+		oldest := g.order.Remove(g.order.Back()).(string)
+		delete(g.keys, oldest)
+	}
+}
+
+// remove 移除键，返回键是否存在
+func (g *ghostList) remove(key string) bool {
+	element, ok := g.keys[key]
+	if !ok {
+		return false
+	}
+	g.order.Remove(element)
+	delete(g.keys, key)
+	return true
+}
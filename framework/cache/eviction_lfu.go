@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"container/heap"
+	"time"
+)
+
+const (
+	// defaultLFUDecayInterval 默认LFU衰减周期
+	defaultLFUDecayInterval = time.Minute
+	// defaultLFUMinFreq 默认新条目初始频率
+	defaultLFUMinFreq = 1
+)
+
+// lfuAlgorithm 最不经常使用，按周期衰减访问频率，避免历史热点长期占用缓存
+//
+// 条目按 (频率, 最近访问序号) 组成小顶堆，同频时淘汰最久未访问的条目。
+type lfuAlgorithm struct {
+	entries       lfuHeap
+	tick          uint64
+	decayRate     float64
+	decayInterval time.Duration
+	minFreq       float64
+	lastDecay     time.Time
+}
+
+func newLFUAlgorithm(decayRate float64, decayInterval time.Duration, minFreq int64) *lfuAlgorithm {
+	if decayInterval <= 0 {
+		decayInterval = defaultLFUDecayInterval
+	}
+	if minFreq <= 0 {
+		minFreq = defaultLFUMinFreq
+	}
+	if decayRate > 1 {
+		decayRate = 1
+	}
+	return &lfuAlgorithm{
+		decayRate:     decayRate,
+		decayInterval: decayInterval,
+		minFreq:       float64(minFreq),
+		lastDecay:     time.Now(),
+	}
+}
+
+func (a *lfuAlgorithm) add(e *cacheEntry) {
+	a.decay()
+	a.tick++
+	e.freq = a.minFreq
+	e.tick = a.tick
+	heap.Push(&a.entries, e)
+}
+
+func (a *lfuAlgorithm) access(e *cacheEntry) {
+	a.decay()
+	a.tick++
+	e.freq++
+	e.tick = a.tick
+	heap.Fix(&a.entries, e.index)
+}
+
+func (a *lfuAlgorithm) remove(e *cacheEntry) {
+	heap.Remove(&a.entries, e.index)
+}
+
+func (a *lfuAlgorithm) evict() *cacheEntry {
+	if len(a.entries) == 0 {
+		return nil
+	}
+	return heap.Pop(&a.entries).(*cacheEntry)
+}
+
+func (a *lfuAlgorithm) clear() {
+	a.entries = nil
+	a.lastDecay = time.Now()
+}
+
+// decay 按经过的衰减周期数衰减所有条目的频率，不低于最小频率
+func (a *lfuAlgorithm) decay() {
+	if a.decayRate <= 0 {
+		return
+	}
+
+	periods := int(time.Since(a.lastDecay) / a.decayInterval)
+	if periods == 0 {
+		return
+	}
+	a.lastDecay = a.lastDecay.Add(time.Duration(periods) * a.decayInterval)
+
+	factor := 1.0
+	for i := 0; i < periods && factor > 0; i++ {
+		factor *= 1 - a.decayRate
+	}
+	for _, e := range a.entries {
+		e.freq *= factor
+		if e.freq < a.minFreq {
+			e.freq = a.minFreq
+		}
+	}
+	heap.Init(&a.entries)
+}
+
+// lfuHeap 按频率排序的小顶堆
+type lfuHeap []*cacheEntry
+
+func (h lfuHeap) Len() int { return len(h) }
+
+func (h lfuHeap) Less(i, j int) bool {
+	if h[i].freq == h[j].freq {
+		return h[i].tick < h[j].tick
+	}
+	return h[i].freq < h[j].freq
+}
+
+func (h lfuHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *lfuHeap) Push(x interface{}) {
+	e := x.(*cacheEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *lfuHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
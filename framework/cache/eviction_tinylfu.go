@@ -0,0 +1,238 @@
+package cache
+
+import (
+	"container/list"
+	"hash/fnv"
+)
+
+// W-TinyLFU分段
+const (
+	tinyLFUWindow    = iota + 1 // 窗口LRU，吸收突发的新条目
+	tinyLFUProbation            // 主缓存试用区
+	tinyLFUProtected            // 主缓存保护区
+)
+
+const (
+	// tinyLFUWindowPercent 窗口区占总容量的百分比
+	tinyLFUWindowPercent = 1
+	// tinyLFUProtectedPercent 保护区占主缓存的百分比
+	tinyLFUProtectedPercent = 80
+	// tinyLFUSampleFactor 频率草图每累计 容量×该系数 次访问后减半
+	tinyLFUSampleFactor = 10
+	// tinyLFUWidthFactor 频率草图每行计数器数与容量的比例，降低哈希冲突
+	tinyLFUWidthFactor = 8
+)
+
+// tinyLFUAlgorithm W-TinyLFU
+//
+// 新条目先进入窗口LRU，被挤出窗口时与主缓存试用区的淘汰候选比较访问频率，
+// 只有频率更高才能进入主缓存。访问频率由带周期减半的Count-Min草图估算，
+// 因此一次性扫描的冷数据无法挤掉长期热点。
+type tinyLFUAlgorithm struct {
+	windowCap    int
+	mainCap      int
+	protectedCap int
+
+	window, probation, protected *list.List
+	sketch                       *countMinSketch
+}
+
+func newTinyLFUAlgorithm(capacity int) *tinyLFUAlgorithm {
+	windowCap := capacity * tinyLFUWindowPercent / 100
+	if windowCap < 1 {
+		windowCap = 1
+	}
+	mainCap := capacity - windowCap
+	if mainCap < 0 {
+		mainCap = 0
+	}
+
+	return &tinyLFUAlgorithm{
+		windowCap:    windowCap,
+		mainCap:      mainCap,
+		protectedCap: mainCap * tinyLFUProtectedPercent / 100,
+		window:       list.New(),
+		probation:    list.New(),
+		protected:    list.New(),
+		sketch:       newCountMinSketch(capacity),
+	}
+}
+
+func (a *tinyLFUAlgorithm) add(e *cacheEntry) {
+	a.sketch.increment(e.key)
+	e.segment = tinyLFUWindow
+	e.element = a.window.PushFront(e)
+}
+
+func (a *tinyLFUAlgorithm) access(e *cacheEntry) {
+	a.sketch.increment(e.key)
+
+	switch e.segment {
+	case tinyLFUWindow:
+		a.window.MoveToFront(e.element)
+	case tinyLFUProbation:
+		// 试用区再次命中，晋升到保护区
+		a.probation.Remove(e.element)
+		e.segment = tinyLFUProtected
+		e.element = a.protected.PushFront(e)
+		if a.protected.Len() > a.protectedCap {
+			demoted := a.protected.Remove(a.protected.Back()).(*cacheEntry)
+			demoted.segment = tinyLFUProbation
+			demoted.element = a.probation.PushFront(demoted)
+		}
+	case tinyLFUProtected:
+		a.protected.MoveToFront(e.element)
+	}
+}
+
+func (a *tinyLFUAlgorithm) remove(e *cacheEntry) {
+	a.listOf(e).Remove(e.element)
+}
+
+func (a *tinyLFUAlgorithm) evict() *cacheEntry {
+	for a.window.Len() > a.windowCap {
+		candidate := a.window.Back().Value.(*cacheEntry)
+
+		// 主缓存未满，直接进入试用区
+		if a.probation.Len()+a.protected.Len() < a.mainCap {
+			a.moveToProbation(candidate)
+			continue
+		}
+
+		victim := a.mainVictim()
+		if victim == nil {
+			break
+		}
+		// 准入判断：候选频率高于主缓存淘汰对象才替换
+		if a.sketch.estimate(candidate.key) > a.sketch.estimate(victim.key) {
+			a.remove(victim)
+			a.moveToProbation(candidate)
+			return victim
+		}
+		a.remove(candidate)
+		return candidate
+	}
+
+	if victim := a.mainVictim(); victim != nil {
+		a.remove(victim)
+		return victim
+	}
+	if back := a.window.Back(); back != nil {
+		return a.window.Remove(back).(*cacheEntry)
+	}
+	return nil
+}
+
+func (a *tinyLFUAlgorithm) clear() {
+	a.window.Init()
+	a.probation.Init()
+	a.protected.Init()
+	a.sketch.reset()
+}
+
+// mainVictim 主缓存中的淘汰候选，优先选择试用区
+func (a *tinyLFUAlgorithm) mainVictim() *cacheEntry {
+	if back := a.probation.Back(); back != nil {
+		return back.Value.(*cacheEntry)
+	}
+	if back := a.protected.Back(); back != nil {
+		return back.Value.(*cacheEntry)
+	}
+	return nil
+}
+
+// moveToProbation 将条目从窗口移入试用区
+func (a *tinyLFUAlgorithm) moveToProbation(e *cacheEntry) {
+	a.window.Remove(e.element)
+	e.segment = tinyLFUProbation
+	e.element = a.probation.PushFront(e)
+}
+
+// listOf 条目所在链表
+func (a *tinyLFUAlgorithm) listOf(e *cacheEntry) *list.List {
+	switch e.segment {
+	case tinyLFUProbation:
+		return a.probation
+	case tinyLFUProtected:
+		return a.protected
+	default:
+		return a.window
+	}
+}
+
+// countMinSketch 4位计数器的Count-Min草图，用于估算访问频率
+type countMinSketch struct {
+	rows       [4][]uint8
+	mask       uint32
+	additions  int
+	sampleSize int
+}
+
+func newCountMinSketch(capacity int) *countMinSketch {
+	width := 16
+	for width < capacity*tinyLFUWidthFactor {
+		width <<= 1
+	}
+
+	s := &countMinSketch{
+		mask:       uint32(width - 1),
+		sampleSize: capacity * tinyLFUSampleFactor,
+	}
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// increment 增加键的计数，达到采样数时所有计数减半
+func (s *countMinSketch) increment(key string) {
+	h1, h2 := sketchHash(key)
+	for i := range s.rows {
+		index := (h1 + uint32(i)*h2) & s.mask
+		if s.rows[i][index] < 15 {
+			s.rows[i][index]++
+		}
+	}
+
+	s.additions++
+	if s.sampleSize > 0 && s.additions >= s.sampleSize {
+		s.halve()
+	}
+}
+
+// estimate 估算键的访问频率
+func (s *countMinSketch) estimate(key string) uint8 {
+	h1, h2 := sketchHash(key)
+	estimate := uint8(15)
+	for i := range s.rows {
+		if count := s.rows[i][(h1+uint32(i)*h2)&s.mask]; count < estimate {
+			estimate = count
+		}
+	}
+	return estimate
+}
+
+// halve 计数减半，使频率估算随时间老化
+func (s *countMinSketch) halve() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+	s.additions /= 2
+}
+
+func (s *countMinSketch) reset() {
+	for i := range s.rows {
+		clear(s.rows[i])
+	}
+	s.additions = 0
+}
+
+// sketchHash 计算双重哈希所需的两个哈希值
+func sketchHash(key string) (uint32, uint32) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	sum := h.Sum64()
+	return uint32(sum), uint32(sum>>32) | 1
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/qiaojinxia/distributed-service/framework/config"
 	"github.com/qiaojinxia/distributed-service/framework/database"
 	"github.com/qiaojinxia/distributed-service/framework/logger"
 )
@@ -45,6 +46,12 @@ func (fcs *FrameworkCacheService) Initialize(ctx context.Context) error {
 	} else {
 		logger.Warn(ctx, "⚠️ Redis client not available, using memory-only caching")
 	}
+
+	// 注册Redis Cluster / Sentinel构建器
+	registerRedisTopologyBuilders(fcs.Manager)
+
+	// 注册Memcached构建器，节点地址来自缓存实例的Settings
+	fcs.Manager.RegisterBuilder(TypeMemcached, &MemcachedBuilder{})
 	
 	// 注册混合缓存构建器
 	fcs.Manager.RegisterBuilder(TypeHybrid, &HybridBuilder{})
@@ -53,6 +60,17 @@ func (fcs *FrameworkCacheService) Initialize(ctx context.Context) error {
 	return nil
 }
 
+// SetMemoryDefaults 设置内存缓存的默认配置，之后创建的内存缓存（含混合缓存的L1）中
+// 缓存实例Settings未设置的字段使用该配置
+func (fcs *FrameworkCacheService) SetMemoryDefaults(memoryConfig config.CacheMemoryConfig) error {
+	defaults, err := NewMemoryConfigFromFramework(memoryConfig)
+	if err != nil {
+		return err
+	}
+	fcs.Manager.RegisterBuilder(TypeMemory, NewMemoryBuilder(defaults))
+	return nil
+}
+
 // CreateDefaultCaches 创建默认缓存实例（内存缓存）
 func (fcs *FrameworkCacheService) CreateDefaultCaches(ctx context.Context) error {
 	logger.Info(ctx, "🔧 Creating default memory-based caches...")
@@ -70,6 +88,7 @@ func (fcs *FrameworkCacheService) CreateDefaultCaches(ctx context.Context) error
 			"eviction_policy":  "ttl", 
 			"default_ttl":      time.Minute * 30,    // 30分钟
 			"cleanup_interval": time.Minute * 5,     // 5分钟 - 恢复合理值
+			"shard_count":      16,                  // 分片降低高并发下的锁竞争
 		},
 		"products": {
 			"max_size":         2000,
@@ -5,21 +5,45 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/database"
+	"github.com/qiaojinxia/distributed-service/pkg/etcd"
 )
 
+
 type HybridCache struct {
-	l1Cache        Cache
-	l2Cache        Cache
-	config         HybridConfig
-	writeBackQueue chan *writeBackItem
-	stats          HybridStats
-	mutex          sync.RWMutex
-	stopChan       chan struct{}
-	wg             sync.WaitGroup
+	l1Cache  Cache
+	l2Cache  Cache
+	config   HybridConfig
+	stats    HybridStats
+	mutex    sync.RWMutex
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+
+	// 写回队列，同一个键的多次写入合并为一条
+	wbMutex     sync.Mutex
+	flushMutex  sync.Mutex // 串行化写回，保证按序号顺序确认日志
+	pending     map[string]*writeBackItem
+	wbSeq       uint64
+	wbAcked     uint64 // 日志中已确认的序号
+	wbSpace     chan struct{} // 队列腾出空间时关闭
+	wbNotify    chan struct{} // 达到批量大小时通知写回协程
+	journal     WriteBackJournal
+	ownsJournal bool
+	flushErr    error // Close时写回的结果
+
+	// L1跨实例失效
+	bus                InvalidationBus
+	ownsBus            bool
+	instanceID         string
+	invalidationCancel context.CancelFunc
 }
 
+
 type HybridConfig struct {
 	L1Config           Config        `json:"l1_config" yaml:"l1_config"`
 	L2Config           Config        `json:"l2_config" yaml:"l2_config"`
@@ -29,26 +53,51 @@ type HybridConfig struct {
 	WriteBackBatchSize int           `json:"write_back_batch_size" yaml:"write_back_batch_size"`
 	L1TTL              time.Duration `json:"l1_ttl" yaml:"l1_ttl"`
 	L2TTL              time.Duration `json:"l2_ttl" yaml:"l2_ttl"`
+
+	// L1跨实例失效配置
+	Name                string          `json:"name" yaml:"name"`                                 // 缓存名称，同名缓存之间互相广播失效，默认使用L2名称
+	InstanceID          string          `json:"instance_id" yaml:"instance_id"`                   // 实例ID，默认自动生成
+	InvalidationBackend string          `json:"invalidation_backend" yaml:"invalidation_backend"` // 失效总线类型 (redis, etcd)，为空不广播
+	InvalidationChannel string          `json:"invalidation_channel" yaml:"invalidation_channel"` // 失效频道（Redis频道或etcd键）
+	InvalidationBus     InvalidationBus `json:"-" yaml:"-"`                                       // 自定义失效总线，优先于 InvalidationBackend
+
+	// 写回队列与持久化配置
+	WriteBackMaxPending     int                     `json:"write_back_max_pending" yaml:"write_back_max_pending"`         // 最大待写回键数，默认1000
+	WriteBackOverflow       WriteBackOverflowPolicy `json:"write_back_overflow" yaml:"write_back_overflow"`               // 队列满时的处理方式 (block, drop, write_through)，默认write_through
+	WriteBackFlushTimeout   time.Duration           `json:"write_back_flush_timeout" yaml:"write_back_flush_timeout"`     // Close时写回的超时时间，默认30秒
+	WriteBackJournalBackend string                  `json:"write_back_journal_backend" yaml:"write_back_journal_backend"` // 写回日志类型 (file, redis)，为空不持久化
+	WriteBackJournalPath    string                  `json:"write_back_journal_path" yaml:"write_back_journal_path"`       // 日志文件路径或Redis Stream键
+	WriteBackJournalSync    bool                    `json:"write_back_journal_sync" yaml:"write_back_journal_sync"`       // 文件日志每次追加都fsync
+	WriteBackJournal        WriteBackJournal        `json:"-" yaml:"-"`                                                   // 自定义写回日志，优先于 WriteBackJournalBackend
 }
 
 
 type writeBackItem struct {
+	seq        uint64
 	key        string
 	value      interface{}
 	expiration time.Duration
+	tags       []string
 	timestamp  time.Time
 }
 
+
 type HybridStats struct {
-	L1Hits      int64
-	L1Misses    int64
-	L2Hits      int64
-	L2Misses    int64
-	L1Sets      int64
-	L2Sets      int64
-	Writebacks  int64
-	Errors      int64
-	LastUpdated time.Time
+	L1Hits        int64
+	L1Misses      int64
+	L2Hits        int64
+	L2Misses      int64
+	L1Sets        int64
+	L2Sets        int64
+	Writebacks    int64 // 已写回L2的条目数
+	Errors        int64
+	Invalidations int64 // 收到并应用的其他实例失效消息数
+
+	WriteBackQueued    int64 // 进入写回队列的写入数
+	WriteBackCoalesced int64 // 与队列中同一个键合并的写入数
+	WriteBackDropped   int64 // 队列满时丢弃的写入数
+	WriteBackPending   int64 // 当前待写回的键数
+	LastUpdated        time.Time
 }
 
 func NewHybridCache(config HybridConfig, manager *Manager) (*HybridCache, error) {
@@ -57,7 +106,7 @@ func NewHybridCache(config HybridConfig, manager *Manager) (*HybridCache, error)
 	var err error
 	
 	if config.L1Config.Type == TypeMemory {
-		l1Builder := &MemoryBuilder{}
+		l1Builder := manager.memoryBuilder()
 		l1Cache, err = l1Builder.Build(config.L1Config)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create L1 cache: %w", err)
@@ -66,18 +115,22 @@ func NewHybridCache(config HybridConfig, manager *Manager) (*HybridCache, error)
 		return nil, fmt.Errorf("unsupported L1 cache type: %s", config.L1Config.Type)
 	}
 
-	// 创建L2缓存（通常是Redis缓存）
+	// 指标按混合缓存名称区分L1/L2层级
+	metricName := config.Name
+	if metricName == "" {
+		metricName = config.L2Config.Name
+	}
+	l1Cache = manager.instrument(l1Cache, metricName, TierL1, string(config.L1Config.Type))
+
+	// 创建L2缓存（Redis、Redis Cluster/Sentinel、Memcached等任意已注册的远程缓存）
 	var l2Cache Cache
 	
-	if config.L2Config.Type == TypeRedis {
-		// 从管理器中获取Redis构建器
-		if err := manager.CreateCache(config.L2Config); err != nil {
-			return nil, fmt.Errorf("failed to create L2 cache config: %w", err)
-		}
-		
-		l2Cache, err = manager.GetCache(config.L2Config.Name)
+	if config.L2Config.Type != TypeHybrid && config.L2Config.Type != "" {
+		// 从管理器中获取对应的构建器
+		l2Cache, err = manager.createCache(config.L2Config, metricName, TierL2)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get L2 cache: %w", err)
+			_ = l1Cache.Close()
+			return nil, fmt.Errorf("failed to create L2 cache config: %w", err)
 		}
 	} else {
 		return nil, fmt.Errorf("unsupported L2 cache type: %s", config.L2Config.Type)
@@ -90,24 +143,54 @@ func NewHybridCache(config HybridConfig, manager *Manager) (*HybridCache, error)
 	if config.WriteBackBatchSize == 0 {
 		config.WriteBackBatchSize = 100
 	}
+	if config.WriteBackMaxPending <= 0 {
+		config.WriteBackMaxPending = 1000
+	}
+	if config.WriteBackOverflow == "" {
+		config.WriteBackOverflow = WriteBackOverflowWriteThrough
+	}
+	if config.WriteBackFlushTimeout <= 0 {
+		config.WriteBackFlushTimeout = 30 * time.Second
+	}
 	if config.L1TTL == 0 {
 		config.L1TTL = time.Hour
 	}
 	if config.L2TTL == 0 {
 		config.L2TTL = time.Hour * 24
 	}
+	if config.Name == "" {
+		config.Name = config.L2Config.Name
+	}
+	if config.InstanceID == "" {
+		config.InstanceID = newInstanceID()
+	}
 
 	hc := &HybridCache{
-		l1Cache:        l1Cache,
-		l2Cache:        l2Cache,
-		config:         config,
-		writeBackQueue: make(chan *writeBackItem, 1000),
-		stats:          HybridStats{LastUpdated: time.Now()},
-		stopChan:       make(chan struct{}),
+		l1Cache:    l1Cache,
+		l2Cache:    l2Cache,
+		config:     config,
+		stats:      HybridStats{LastUpdated: time.Now()},
+		stopChan:   make(chan struct{}),
+		pending:    make(map[string]*writeBackItem),
+		wbSpace:    make(chan struct{}),
+		wbNotify:   make(chan struct{}, 1),
+		instanceID: config.InstanceID,
+	}
+
+	// 订阅其他实例的L1失效消息
+	if err := hc.startInvalidation(); err != nil {
+		return nil, err
 	}
 
-	// 启动写回工作协程
-	if config.WriteBackEnabled && config.SyncStrategy == SyncStrategyWriteBack {
+	// 重放写回日志并启动写回工作协程
+	if hc.writeBackActive() {
+		if err := hc.startWriteBack(); err != nil {
+			hc.stopInvalidation()
+			if hc.ownsBus {
+				_ = hc.bus.Close()
+			}
+			return nil, err
+		}
 		hc.wg.Add(1)
 		go hc.writeBackWorker()
 	}
@@ -151,22 +234,60 @@ func (h *HybridCache) Get(ctx context.Context, key string) (interface{}, error)
 }
 
 func (h *HybridCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	h.mutex.Lock()
-	defer h.mutex.Unlock()
+	if err := h.set(ctx, key, value, expiration, nil); err != nil {
+		return err
+	}
 
+	h.publishInvalidation(ctx, []string{key}, false)
+	return nil
+}
+
+func (h *HybridCache) set(ctx context.Context, key string, value interface{}, expiration time.Duration, tags []string) error {
+	h.mutex.Lock()
+	var err error
 	switch h.config.SyncStrategy {
 	case SyncStrategyWriteThrough:
-		return h.writeThrough(ctx, key, value, expiration)
+		err = h.writeThrough(ctx, key, value, expiration, tags)
 	case SyncStrategyWriteBack:
-		return h.writeBack(ctx, key, value, expiration)
+		err = h.writeBack(ctx, key, value, expiration, tags)
 	case SyncStrategyWriteAround:
-		return h.writeAround(ctx, key, value, expiration)
+		err = h.writeAround(ctx, key, value, expiration, tags)
 	default:
-		return h.writeThrough(ctx, key, value, expiration)
+		err = h.writeThrough(ctx, key, value, expiration, tags)
+	}
+	h.mutex.Unlock()
+	if err != nil {
+		return err
 	}
+
+	// 在锁外入队，阻塞等待队列空间时不影响读取和写回协程
+	if h.config.SyncStrategy == SyncStrategyWriteBack && h.config.WriteBackEnabled {
+		l2TTL := h.config.L2TTL
+		if expiration > 0 && expiration < l2TTL {
+			l2TTL = expiration
+		}
+		return h.enqueueWriteBack(ctx, key, value, l2TTL, tags)
+	}
+	return nil
 }
 
-func (h *HybridCache) writeThrough(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+// setL1 写入L1，L1支持标签时一并关联标签
+func (h *HybridCache) setL1(ctx context.Context, key string, value interface{}, expiration time.Duration, tags []string) error {
+	if taggable, ok := asTaggable(h.l1Cache); ok && len(tags) > 0 {
+		return taggable.SetWithTags(ctx, key, value, expiration, tags...)
+	}
+	return h.l1Cache.Set(ctx, key, value, expiration)
+}
+
+// setL2 写入L2，有标签时L2必须支持标签（由 SetWithTags 预先检查）
+func (h *HybridCache) setL2(ctx context.Context, key string, value interface{}, expiration time.Duration, tags []string) error {
+	if taggable, ok := asTaggable(h.l2Cache); ok && len(tags) > 0 {
+		return taggable.SetWithTags(ctx, key, value, expiration, tags...)
+	}
+	return h.l2Cache.Set(ctx, key, value, expiration)
+}
+
+func (h *HybridCache) writeThrough(ctx context.Context, key string, value interface{}, expiration time.Duration, tags []string) error {
 	// 同时写入L1和L2
 	l1TTL := h.config.L1TTL
 	if expiration > 0 && expiration < l1TTL {
@@ -179,14 +300,14 @@ func (h *HybridCache) writeThrough(ctx context.Context, key string, value interf
 	}
 
 	// 写入L1
-	if err := h.l1Cache.Set(ctx, key, value, l1TTL); err != nil {
+	if err := h.setL1(ctx, key, value, l1TTL, tags); err != nil {
 		h.stats.Errors++
 		return fmt.Errorf("failed to set L1 cache: %w", err)
 	}
 	h.stats.L1Sets++
 
 	// 写入L2
-	if err := h.l2Cache.Set(ctx, key, value, l2TTL); err != nil {
+	if err := h.setL2(ctx, key, value, l2TTL, tags); err != nil {
 		h.stats.Errors++
 		return fmt.Errorf("failed to set L2 cache: %w", err)
 	}
@@ -195,63 +316,56 @@ func (h *HybridCache) writeThrough(ctx context.Context, key string, value interf
 	return nil
 }
 
-func (h *HybridCache) writeBack(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+func (h *HybridCache) writeBack(ctx context.Context, key string, value interface{}, expiration time.Duration, tags []string) error {
 	// 先写入L1
 	l1TTL := h.config.L1TTL
 	if expiration > 0 && expiration < l1TTL {
 		l1TTL = expiration
 	}
 
-	if err := h.l1Cache.Set(ctx, key, value, l1TTL); err != nil {
+	if err := h.setL1(ctx, key, value, l1TTL, tags); err != nil {
 		h.stats.Errors++
 		return fmt.Errorf("failed to set L1 cache: %w", err)
 	}
 	h.stats.L1Sets++
 
-	// 加入写回队列
-	if h.config.WriteBackEnabled {
-		l2TTL := h.config.L2TTL
-		if expiration > 0 && expiration < l2TTL {
-			l2TTL = expiration
-		}
-
-		select {
-		case h.writeBackQueue <- &writeBackItem{
-			key:        key,
-			value:      value,
-			expiration: l2TTL,
-			timestamp:  time.Now(),
-		}:
-		default:
-			// 队列满了，直接写入L2
-			if err := h.l2Cache.Set(ctx, key, value, l2TTL); err != nil {
-				h.stats.Errors++
-			} else {
-				h.stats.L2Sets++
-			}
-		}
-	}
-
+	// 写回队列由 set 在释放锁后加入
 	return nil
 }
 
-func (h *HybridCache) writeAround(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+func (h *HybridCache) writeAround(ctx context.Context, key string, value interface{}, expiration time.Duration, tags []string) error {
 	// 只写入L2，不写L1
 	l2TTL := h.config.L2TTL
 	if expiration > 0 && expiration < l2TTL {
 		l2TTL = expiration
 	}
 
-	if err := h.l2Cache.Set(ctx, key, value, l2TTL); err != nil {
+	if err := h.setL2(ctx, key, value, l2TTL, tags); err != nil {
 		h.stats.Errors++
 		return fmt.Errorf("failed to set L2 cache: %w", err)
 	}
 	h.stats.L2Sets++
 
+	// 本地L1中的旧值同样失效
+	_ = h.l1Cache.Delete(ctx, key)
+
 	return nil
 }
 
 func (h *HybridCache) Delete(ctx context.Context, key string) error {
+	err := h.delete(ctx, key)
+	h.publishInvalidation(ctx, []string{key}, false)
+	return err
+}
+
+func (h *HybridCache) delete(ctx context.Context, key string) error {
+	// 等待进行中的写回批次完成，再取消待写回的旧值，避免写回后复活
+	if h.writeBackActive() {
+		h.flushMutex.Lock()
+		defer h.flushMutex.Unlock()
+	}
+	h.cancelWriteBack(ctx, key)
+
 	h.mutex.Lock()
 	defer h.mutex.Unlock()
 
@@ -286,6 +400,18 @@ func (h *HybridCache) Exists(ctx context.Context, key string) (bool, error) {
 }
 
 func (h *HybridCache) Clear(ctx context.Context) error {
+	err := h.clear(ctx)
+	h.publishInvalidation(ctx, nil, true)
+	return err
+}
+
+func (h *HybridCache) clear(ctx context.Context) error {
+	if h.writeBackActive() {
+		h.flushMutex.Lock()
+		defer h.flushMutex.Unlock()
+	}
+	h.cancelWriteBack(ctx, "")
+
 	h.mutex.Lock()
 	defer h.mutex.Unlock()
 
@@ -304,11 +430,25 @@ func (h *HybridCache) Clear(ctx context.Context) error {
 	return lastErr
 }
 
+// Close 关闭缓存，写回策略下会在 WriteBackFlushTimeout 内将待写回数据写入L2，
+// 超时未写完的数据保留在写回日志中，下次启动时重放
 func (h *HybridCache) Close() error {
 	close(h.stopChan)
 	h.wg.Wait()
 
-	var lastErr error
+	lastErr := h.flushErr
+
+	h.stopInvalidation()
+	if h.ownsBus {
+		if err := h.bus.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	if h.ownsJournal {
+		if err := h.journal.Close(); err != nil {
+			lastErr = err
+		}
+	}
 
 	if err := h.l1Cache.Close(); err != nil {
 		lastErr = err
@@ -323,8 +463,13 @@ func (h *HybridCache) Close() error {
 
 func (h *HybridCache) GetStats() HybridStats {
 	h.mutex.RLock()
-	defer h.mutex.RUnlock()
-	return h.stats
+	stats := h.stats
+	h.mutex.RUnlock()
+
+	h.wbMutex.Lock()
+	stats.WriteBackPending = int64(len(h.pending))
+	h.wbMutex.Unlock()
+	return stats
 }
 
 func (h *HybridCache) ResetStats() {
@@ -333,52 +478,214 @@ func (h *HybridCache) ResetStats() {
 	h.stats = HybridStats{LastUpdated: time.Now()}
 }
 
-func (h *HybridCache) writeBackWorker() {
-	defer h.wg.Done()
+// startInvalidation 创建失效总线并订阅其他实例的失效消息
+func (h *HybridCache) startInvalidation() error {
+	bus := h.config.InvalidationBus
+	if bus == nil && h.config.InvalidationBackend != "" {
+		var err error
+		bus, err = newInvalidationBus(h.config.InvalidationBackend, h.config.InvalidationChannel)
+		if err != nil {
+			return err
+		}
+		h.ownsBus = true
+	}
+	if bus == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := bus.Subscribe(ctx, h.handleInvalidation); err != nil {
+		cancel()
+		if h.ownsBus {
+			_ = bus.Close()
+		}
+		return fmt.Errorf("failed to subscribe L1 invalidation: %w", err)
+	}
+
+	h.bus = bus
+	h.invalidationCancel = cancel
+	return nil
+}
+
+// stopInvalidation 取消失效消息订阅
+func (h *HybridCache) stopInvalidation() {
+	if h.invalidationCancel != nil {
+		h.invalidationCancel()
+	}
+}
 
-	ticker := time.NewTicker(h.config.WriteBackInterval)
-	defer ticker.Stop()
+// publishInvalidation 广播L1失效消息
+func (h *HybridCache) publishInvalidation(ctx context.Context, keys []string, clear bool) {
+	h.publish(ctx, &InvalidationMessage{Keys: keys, Clear: clear})
+}
 
-	batch := make([]*writeBackItem, 0, h.config.WriteBackBatchSize)
+// publish 补全来源信息后广播失效消息，失败只计入错误统计
+func (h *HybridCache) publish(ctx context.Context, msg *InvalidationMessage) {
+	if h.bus == nil {
+		return
+	}
 
-	for {
-		select {
-		case <-h.stopChan:
-			// 处理剩余的写回任务
-			h.flushWriteBackBatch(batch)
-			return
+	msg.Source = h.instanceID
+	msg.Cache = h.config.Name
+	msg.Timestamp = time.Now()
+	if err := h.bus.Publish(ctx, msg); err != nil {
+		h.mutex.Lock()
+		h.stats.Errors++
+		h.mutex.Unlock()
+	}
+}
 
-		case item := <-h.writeBackQueue:
-			batch = append(batch, item)
-			if len(batch) >= h.config.WriteBackBatchSize {
-				h.flushWriteBackBatch(batch)
-				batch = batch[:0]
-			}
+// handleInvalidation 处理其他实例的失效消息，淘汰本地L1副本
+func (h *HybridCache) handleInvalidation(msg *InvalidationMessage) {
+	if msg.Source == h.instanceID || msg.Cache != h.config.Name {
+		return
+	}
 
-		case <-ticker.C:
-			if len(batch) > 0 {
-				h.flushWriteBackBatch(batch)
-				batch = batch[:0]
-			}
+	ctx := context.Background()
+	switch {
+	case msg.Clear:
+		_ = h.l1Cache.Clear(ctx)
+	case msg.Prefix != "":
+		_ = h.deleteL1Prefix(ctx, msg.Prefix)
+	default:
+		for _, key := range msg.Keys {
+			_ = h.l1Cache.Delete(ctx, key)
 		}
 	}
+
+	h.mutex.Lock()
+	h.stats.Invalidations++
+	h.mutex.Unlock()
 }
 
-func (h *HybridCache) flushWriteBackBatch(batch []*writeBackItem) {
-	ctx := context.Background()
+// SetWithTags 设置值并关联标签，L2必须支持标签
+func (h *HybridCache) SetWithTags(ctx context.Context, key string, value interface{}, expiration time.Duration, tags ...string) error {
+	if _, ok := asTaggable(h.l2Cache); !ok {
+		return fmt.Errorf("L2 cache: %w", ErrTagsNotSupported)
+	}
+	if err := h.set(ctx, key, value, expiration, tags); err != nil {
+		return err
+	}
 
-	for _, item := range batch {
-		if err := h.l2Cache.Set(ctx, item.key, item.value, item.expiration); err != nil {
-			h.mutex.Lock()
-			h.stats.Errors++
-			h.mutex.Unlock()
-		} else {
-			h.mutex.Lock()
-			h.stats.L2Sets++
-			h.stats.Writebacks++
-			h.mutex.Unlock()
+	h.publishInvalidation(ctx, []string{key}, false)
+	return nil
+}
+
+// InvalidateTag 删除L2中关联了该标签的键，并淘汰本实例和其他实例L1中的对应副本
+//
+// L1中的副本可能是从L2读取回填的，不带标签，因此按L2记录的键逐个淘汰。
+// 写回队列中关联了该标签或属于这些键的记录一并取消，避免写回后复活。
+func (h *HybridCache) InvalidateTag(ctx context.Context, tag string) error {
+	taggable, ok := asTaggable(h.l2Cache)
+	if !ok {
+		return fmt.Errorf("L2 cache: %w", ErrTagsNotSupported)
+	}
+
+	if h.writeBackActive() {
+		h.flushMutex.Lock()
+		defer h.flushMutex.Unlock()
+	}
+
+	keys, err := taggable.TagKeys(ctx, tag)
+	if err != nil {
+		h.recordError()
+		return fmt.Errorf("failed to invalidate tag %s in L2 cache: %w", tag, err)
+	}
+
+	tagged := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		tagged[key] = true
+	}
+	canceled := h.cancelWriteBackWhere(ctx, func(item *writeBackItem) bool {
+		return tagged[item.key] || slices.Contains(item.tags, tag)
+	})
+	for _, key := range canceled {
+		if !tagged[key] {
+			keys = append(keys, key)
 		}
 	}
+
+	if err := taggable.InvalidateTag(ctx, tag); err != nil {
+		h.recordError()
+		return fmt.Errorf("failed to invalidate tag %s in L2 cache: %w", tag, err)
+	}
+
+	if l1, ok := asTaggable(h.l1Cache); ok {
+		_ = l1.InvalidateTag(ctx, tag)
+	}
+	for _, key := range keys {
+		_ = h.l1Cache.Delete(ctx, key)
+	}
+
+	if len(keys) > 0 {
+		h.publishInvalidation(ctx, keys, false)
+	}
+	return nil
+}
+
+// TagKeys L2中关联了该标签的键
+func (h *HybridCache) TagKeys(ctx context.Context, tag string) ([]string, error) {
+	taggable, ok := asTaggable(h.l2Cache)
+	if !ok {
+		return nil, fmt.Errorf("L2 cache: %w", ErrTagsNotSupported)
+	}
+	return taggable.TagKeys(ctx, tag)
+}
+
+// DeletePrefix 删除L1和L2中以prefix开头的键，并广播给其他实例
+func (h *HybridCache) DeletePrefix(ctx context.Context, prefix string) error {
+	l2, ok := asPrefixCache(h.l2Cache)
+	if !ok {
+		return fmt.Errorf("L2 cache: %w", ErrTagsNotSupported)
+	}
+
+	if h.writeBackActive() {
+		h.flushMutex.Lock()
+		defer h.flushMutex.Unlock()
+	}
+	h.cancelWriteBackWhere(ctx, func(item *writeBackItem) bool {
+		return strings.HasPrefix(item.key, prefix)
+	})
+
+	var lastErr error
+	if err := h.deleteL1Prefix(ctx, prefix); err != nil {
+		h.recordError()
+		lastErr = err
+	}
+	if err := l2.DeletePrefix(ctx, prefix); err != nil {
+		h.recordError()
+		lastErr = fmt.Errorf("failed to delete prefix %s in L2 cache: %w", prefix, err)
+	}
+
+	h.publish(ctx, &InvalidationMessage{Prefix: prefix})
+	return lastErr
+}
+
+// deleteL1Prefix 删除L1中以prefix开头的键，L1不支持时清空L1
+func (h *HybridCache) deleteL1Prefix(ctx context.Context, prefix string) error {
+	if l1, ok := asPrefixCache(h.l1Cache); ok {
+		return l1.DeletePrefix(ctx, prefix)
+	}
+	return h.l1Cache.Clear(ctx)
+}
+
+// newInvalidationBus 根据类型创建失效总线
+func newInvalidationBus(backend, channel string) (InvalidationBus, error) {
+	switch backend {
+	case "redis":
+		if database.RedisClient == nil {
+			return nil, fmt.Errorf("framework redis not initialized")
+		}
+		return NewRedisInvalidationBus(database.RedisClient, channel), nil
+	case "etcd":
+		client := etcd.GetClient()
+		if client == nil {
+			return nil, fmt.Errorf("etcd client not initialized")
+		}
+		return NewEtcdInvalidationBus(client, channel), nil
+	default:
+		return nil, fmt.Errorf("unsupported invalidation backend: %s", backend)
+	}
 }
 
 // MGet 支持批量操作的混合缓存
@@ -489,6 +796,9 @@ func (b *HybridBuilder) Build(config Config) (Cache, error) {
 			_ = json.Unmarshal(data, &hybridConfig)
 		}
 	}
+	if hybridConfig.Name == "" {
+		hybridConfig.Name = config.Name
+	}
 
 	return NewHybridCache(hybridConfig, b.manager)
 }
@@ -0,0 +1,397 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/database"
+)
+
+// WriteBackOverflowPolicy 写回队列满时的处理方式
+type WriteBackOverflowPolicy string
+
+const (
+	// WriteBackOverflowBlock 阻塞等待队列腾出空间，直到ctx取消
+	WriteBackOverflowBlock WriteBackOverflowPolicy = "block"
+	// WriteBackOverflowDrop 丢弃本次L2写入（L1已写入），计入 WriteBackDropped
+	WriteBackOverflowDrop WriteBackOverflowPolicy = "drop"
+	// WriteBackOverflowWriteThrough 退化为写透，直接写入L2
+	WriteBackOverflowWriteThrough WriteBackOverflowPolicy = "write_through"
+)
+
+// writeBackRetryInterval Close时写回失败的重试间隔
+const writeBackRetryInterval = 100 * time.Millisecond
+
+// writeBackActive 是否启用写回队列
+func (h *HybridCache) writeBackActive() bool {
+	return h.config.WriteBackEnabled && h.config.SyncStrategy == SyncStrategyWriteBack
+}
+
+// startWriteBack 创建写回日志并重放上次未写回的记录
+func (h *HybridCache) startWriteBack() error {
+	journal := h.config.WriteBackJournal
+	if journal == nil && h.config.WriteBackJournalBackend != "" {
+		var err error
+		journal, err = newWriteBackJournal(h.config)
+		if err != nil {
+			return err
+		}
+		h.ownsJournal = true
+	}
+	if journal == nil {
+		return nil
+	}
+	h.journal = journal
+
+	entries, err := journal.Replay(context.Background())
+	if err != nil {
+		if h.ownsJournal {
+			_ = journal.Close()
+		}
+		return fmt.Errorf("failed to replay write-back journal: %w", err)
+	}
+
+	for _, entry := range entries {
+		h.wbSeq = max(h.wbSeq, entry.Seq)
+		switch entry.Op {
+		case journalOpSet:
+			value, err := decodeJournalValue(entry.Value, entry.Encoding)
+			if err != nil {
+				continue
+			}
+			h.pending[entry.Key] = &writeBackItem{
+				seq:        entry.Seq,
+				key:        entry.Key,
+				value:      value,
+				expiration: entry.Expiration,
+				tags:       entry.Tags,
+				timestamp:  entry.Timestamp,
+			}
+		case journalOpDelete:
+			delete(h.pending, entry.Key)
+		case journalOpClear:
+			h.pending = make(map[string]*writeBackItem)
+		}
+	}
+	return nil
+}
+
+// newWriteBackJournal 根据类型创建写回日志
+func newWriteBackJournal(config HybridConfig) (WriteBackJournal, error) {
+	path := config.WriteBackJournalPath
+	switch config.WriteBackJournalBackend {
+	case "file":
+		if path == "" {
+			path = filepath.Join("data", "cache", config.Name+".journal")
+		}
+		return NewFileJournal(path, config.WriteBackJournalSync)
+	case "redis":
+		if database.RedisClient == nil {
+			return nil, fmt.Errorf("framework redis not initialized")
+		}
+		if path == "" {
+			path = "cache:writeback:" + config.Name
+		}
+		return NewRedisStreamJournal(database.RedisClient, path), nil
+	default:
+		return nil, fmt.Errorf("unsupported write-back journal backend: %s", config.WriteBackJournalBackend)
+	}
+}
+
+// enqueueWriteBack 加入写回队列，队列中已有同一个键时直接合并
+func (h *HybridCache) enqueueWriteBack(ctx context.Context, key string, value interface{}, expiration time.Duration, tags []string) error {
+	for {
+		h.wbMutex.Lock()
+		_, coalesced := h.pending[key]
+		if coalesced || len(h.pending) < h.config.WriteBackMaxPending {
+			item := &writeBackItem{
+				seq:        h.wbSeq + 1,
+				key:        key,
+				value:      value,
+				expiration: expiration,
+				tags:       tags,
+				timestamp:  time.Now(),
+			}
+			if err := h.appendJournal(ctx, item); err != nil {
+				h.wbMutex.Unlock()
+				// 无法持久化时退化为写透，不丢失写入
+				h.recordError()
+				return h.writeBackDirect(ctx, item)
+			}
+			h.wbSeq = item.seq
+			h.pending[key] = item
+			full := len(h.pending) >= h.config.WriteBackBatchSize
+			h.wbMutex.Unlock()
+
+			h.mutex.Lock()
+			h.stats.WriteBackQueued++
+			if coalesced {
+				h.stats.WriteBackCoalesced++
+			}
+			h.mutex.Unlock()
+
+			if full {
+				h.notifyWriteBack()
+			}
+			return nil
+		}
+		space := h.wbSpace
+		h.wbMutex.Unlock()
+
+		switch h.config.WriteBackOverflow {
+		case WriteBackOverflowDrop:
+			h.mutex.Lock()
+			h.stats.WriteBackDropped++
+			h.mutex.Unlock()
+			return nil
+
+		case WriteBackOverflowBlock:
+			h.notifyWriteBack()
+			select {
+			case <-space:
+				continue
+			case <-ctx.Done():
+				return fmt.Errorf("write-back queue full: %w", ctx.Err())
+			case <-h.stopChan:
+				// 已关闭，写回协程不再消费队列
+			}
+		}
+
+		return h.writeBackDirect(ctx, &writeBackItem{key: key, value: value, expiration: expiration, tags: tags})
+	}
+}
+
+// writeBackDirect 绕过队列直接写入L2
+func (h *HybridCache) writeBackDirect(ctx context.Context, item *writeBackItem) error {
+	if err := h.setL2(ctx, item.key, item.value, item.expiration, item.tags); err != nil {
+		h.recordError()
+		return fmt.Errorf("failed to set L2 cache: %w", err)
+	}
+	h.mutex.Lock()
+	h.stats.L2Sets++
+	h.mutex.Unlock()
+	return nil
+}
+
+// appendJournal 将写回记录写入日志（调用方需持有h.wbMutex）
+func (h *HybridCache) appendJournal(ctx context.Context, item *writeBackItem) error {
+	if h.journal == nil {
+		return nil
+	}
+	data, enc, err := encodeJournalValue(item.value)
+	if err != nil {
+		return fmt.Errorf("failed to encode write-back value: %w", err)
+	}
+	return h.journal.Append(ctx, &JournalEntry{
+		Seq:        item.seq,
+		Op:         journalOpSet,
+		Key:        item.key,
+		Value:      data,
+		Encoding:   enc,
+		Expiration: item.expiration,
+		Tags:       item.tags,
+		Timestamp:  item.timestamp,
+	})
+}
+
+// cancelWriteBack 取消键的待写回记录，key为空时取消全部
+func (h *HybridCache) cancelWriteBack(ctx context.Context, key string) {
+	h.wbMutex.Lock()
+	defer h.wbMutex.Unlock()
+
+	op := journalOpDelete
+	if key == "" {
+		op = journalOpClear
+		if len(h.pending) == 0 {
+			return
+		}
+		h.pending = make(map[string]*writeBackItem)
+	} else {
+		if _, ok := h.pending[key]; !ok {
+			return
+		}
+		delete(h.pending, key)
+	}
+
+	if h.journal != nil {
+		h.wbSeq++
+		entry := &JournalEntry{Seq: h.wbSeq, Op: op, Key: key, Timestamp: time.Now()}
+		if err := h.journal.Append(ctx, entry); err != nil {
+			h.recordError()
+		}
+	}
+}
+
+// cancelWriteBackWhere 取消满足条件的待写回记录，返回被取消的键
+func (h *HybridCache) cancelWriteBackWhere(ctx context.Context, match func(item *writeBackItem) bool) []string {
+	h.wbMutex.Lock()
+	defer h.wbMutex.Unlock()
+
+	var canceled []string
+	for key, item := range h.pending {
+		if !match(item) {
+			continue
+		}
+		delete(h.pending, key)
+		canceled = append(canceled, key)
+
+		if h.journal != nil {
+			h.wbSeq++
+			entry := &JournalEntry{Seq: h.wbSeq, Op: journalOpDelete, Key: key, Timestamp: time.Now()}
+			if err := h.journal.Append(ctx, entry); err != nil {
+				h.recordError()
+			}
+		}
+	}
+	return canceled
+}
+
+// notifyWriteBack 通知写回协程立即写回
+func (h *HybridCache) notifyWriteBack() {
+	select {
+	case h.wbNotify <- struct{}{}:
+	default:
+	}
+}
+
+// recordError 计入错误统计
+func (h *HybridCache) recordError() {
+	h.mutex.Lock()
+	h.stats.Errors++
+	h.mutex.Unlock()
+}
+
+func (h *HybridCache) writeBackWorker() {
+	defer h.wg.Done()
+
+	ticker := time.NewTicker(h.config.WriteBackInterval)
+	defer ticker.Stop()
+
+	// 立即写回上次重放的记录
+	_ = h.Flush(context.Background())
+
+	for {
+		select {
+		case <-h.stopChan:
+			ctx, cancel := context.WithTimeout(context.Background(), h.config.WriteBackFlushTimeout)
+			h.flushErr = h.drainWriteBack(ctx)
+			cancel()
+			return
+
+		case <-h.wbNotify:
+			_ = h.Flush(context.Background())
+
+		case <-ticker.C:
+			_ = h.Flush(context.Background())
+		}
+	}
+}
+
+// Flush 立即将队列中的数据写回L2，写入失败的键重新入队
+//
+// 写回期间持有flushMutex，Delete 和 Clear 会等待本批次写完后再删除L2，
+// 因此批次中的旧值不会在删除之后写入L2。
+func (h *HybridCache) Flush(ctx context.Context) error {
+	h.flushMutex.Lock()
+	defer h.flushMutex.Unlock()
+
+	h.wbMutex.Lock()
+	if len(h.pending) == 0 {
+		// 只剩删除/清空记录时同样确认，使日志可以截断
+		if h.journal != nil && h.wbSeq > h.wbAcked {
+			if err := h.journal.Ack(ctx, h.wbSeq); err != nil {
+				h.recordError()
+			} else {
+				h.wbAcked = h.wbSeq
+			}
+		}
+		h.wbMutex.Unlock()
+		return nil
+	}
+	batch := h.pending
+	ackSeq := h.wbSeq
+	h.pending = make(map[string]*writeBackItem)
+	close(h.wbSpace)
+	h.wbSpace = make(chan struct{})
+	h.wbMutex.Unlock()
+
+	flushed := make([]string, 0, len(batch))
+	var failed []*writeBackItem
+	var lastErr error
+	for _, item := range batch {
+		if err := ctx.Err(); err != nil {
+			failed = append(failed, item)
+			lastErr = err
+			continue
+		}
+		if err := h.setL2(ctx, item.key, item.value, item.expiration, item.tags); err != nil {
+			h.recordError()
+			failed = append(failed, item)
+			lastErr = err
+			continue
+		}
+		flushed = append(flushed, item.key)
+	}
+
+	h.mutex.Lock()
+	h.stats.L2Sets += int64(len(flushed))
+	h.stats.Writebacks += int64(len(flushed))
+	h.mutex.Unlock()
+
+	h.wbMutex.Lock()
+	for _, item := range failed {
+		// 写回期间有新的写入或删除时以新的为准
+		if _, newer := h.pending[item.key]; newer {
+			continue
+		}
+		h.wbSeq++
+		item.seq = h.wbSeq
+		if err := h.appendJournal(ctx, item); err != nil {
+			h.recordError()
+		}
+		h.pending[item.key] = item
+	}
+	if h.journal != nil {
+		if err := h.journal.Ack(ctx, ackSeq); err != nil {
+			h.recordError()
+		} else {
+			h.wbAcked = ackSeq
+		}
+	}
+	h.wbMutex.Unlock()
+
+	// 写回L2后再次广播，避免其他实例在写回前从L2读到旧值并放入L1
+	if len(flushed) > 0 {
+		h.publishInvalidation(ctx, flushed, false)
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("failed to write back %d of %d keys: %w", len(failed), len(batch), lastErr)
+	}
+	return nil
+}
+
+// drainWriteBack 关闭时写回全部数据，失败时重试直到ctx超时
+func (h *HybridCache) drainWriteBack(ctx context.Context) error {
+	for {
+		err := h.Flush(ctx)
+
+		h.wbMutex.Lock()
+		remaining := len(h.pending)
+		h.wbMutex.Unlock()
+		if remaining == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err == nil {
+				err = ctx.Err()
+			}
+			return fmt.Errorf("write-back flush timed out with %d keys remaining: %w", remaining, err)
+		case <-time.After(writeBackRetryInterval):
+		}
+	}
+}
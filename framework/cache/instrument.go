@@ -0,0 +1,515 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/qiaojinxia/distributed-service/framework/metrics"
+	"github.com/qiaojinxia/distributed-service/framework/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 指标中的缓存层级标签
+const (
+	TierDefault = "default" // 独立缓存
+	TierHybrid  = "hybrid"  // 混合缓存整体
+	TierL1      = "l1"      // 混合缓存的本地层
+	TierL2      = "l2"      // 混合缓存的远程层
+)
+
+// InstrumentationOptions 缓存指标与链路追踪选项
+type InstrumentationOptions struct {
+	Metrics         bool    // 记录Prometheus指标
+	TraceSampleRate float64 // 上游span被采样时，为缓存操作创建子span的比例，0表示不创建
+	TraceKeys       bool    // span中记录键名，键中含用户数据时应关闭
+}
+
+// DefaultInstrumentationOptions 默认选项：记录指标，1%的缓存操作创建span
+func DefaultInstrumentationOptions() InstrumentationOptions {
+	return InstrumentationOptions{
+		Metrics:         true,
+		TraceSampleRate: 0.01,
+	}
+}
+
+// cacheOp 被记录的操作
+type cacheOp int
+
+const (
+	opGet cacheOp = iota
+	opSet
+	opDelete
+	opExists
+	opClear
+	opMGet
+	opMSet
+	opMDelete
+	opSetWithTags
+	opInvalidateTag
+	opDeletePrefix
+	opCount
+)
+
+var cacheOpNames = [opCount]string{
+	"get", "set", "delete", "exists", "clear", "mget", "mset", "mdelete", "set_with_tags", "invalidate_tag", "delete_prefix",
+}
+
+// InstrumentedCache 记录指标与span的缓存装饰器，由 Manager.CreateCache 自动应用
+//
+// 装饰器实现了所有可选接口，底层缓存不支持的能力返回 ErrNotSupported
+// （标签和前缀删除返回 ErrTagsNotSupported），判断能力或访问具体类型时使用 Unwrap。
+type InstrumentedCache struct {
+	cache   Cache
+	name    string
+	tier    string
+	system  string
+	options InstrumentationOptions
+
+	hits        prometheus.Counter
+	misses      prometheus.Counter
+	cacheHits   prometheus.Counter // 按缓存名称统计，混合缓存的L1/L2层不计入
+	cacheMisses prometheus.Counter
+	durations   [opCount]prometheus.Observer
+	errors      [opCount]prometheus.Counter
+
+	// 底层缓存不支持统计时由装饰器自行统计
+	stats struct {
+		hits, misses, sets, deletes, errors atomic.Int64
+		lastReset                           atomic.Int64
+	}
+}
+
+// NewInstrumentedCache 创建装饰器，system为底层缓存类型，用于span属性
+func NewInstrumentedCache(cache Cache, name, tier, system string, options InstrumentationOptions) *InstrumentedCache {
+	c := &InstrumentedCache{
+		cache:   cache,
+		name:    name,
+		tier:    tier,
+		system:  system,
+		options: options,
+	}
+	c.stats.lastReset.Store(time.Now().UnixNano())
+	if options.Metrics {
+		c.hits = metrics.CacheTierHits.WithLabelValues(name, tier)
+		c.misses = metrics.CacheTierMisses.WithLabelValues(name, tier)
+		if tier != TierL1 && tier != TierL2 {
+			c.cacheHits = metrics.CacheHits.WithLabelValues(name)
+			c.cacheMisses = metrics.CacheMisses.WithLabelValues(name)
+		}
+		for op := cacheOp(0); op < opCount; op++ {
+			c.durations[op] = metrics.CacheOperationDuration.WithLabelValues(name, tier, cacheOpNames[op])
+			c.errors[op] = metrics.CacheErrors.WithLabelValues(name, tier, cacheOpNames[op])
+		}
+		registerStatsCollector(c)
+	}
+	return c
+}
+
+// Unwrap 返回被装饰的缓存
+func (c *InstrumentedCache) Unwrap() Cache {
+	return c.cache
+}
+
+// Unwrap 逐层去掉装饰器，返回最底层的缓存
+func Unwrap(cache Cache) Cache {
+	for {
+		wrapped, ok := cache.(interface{ Unwrap() Cache })
+		if !ok {
+			return cache
+		}
+		cache = wrapped.Unwrap()
+	}
+}
+
+// asTaggable 底层缓存支持标签时返回可调用的TaggableCache（保留装饰器）
+func asTaggable(cache Cache) (TaggableCache, bool) {
+	if _, ok := Unwrap(cache).(TaggableCache); !ok {
+		return nil, false
+	}
+	taggable, ok := cache.(TaggableCache)
+	return taggable, ok
+}
+
+// asPrefixCache 底层缓存支持前缀删除时返回可调用的PrefixCache（保留装饰器）
+func asPrefixCache(cache Cache) (PrefixCache, bool) {
+	if _, ok := Unwrap(cache).(PrefixCache); !ok {
+		return nil, false
+	}
+	prefixCache, ok := cache.(PrefixCache)
+	return prefixCache, ok
+}
+
+// start 开始一次操作，按采样率创建span
+func (c *InstrumentedCache) start(ctx context.Context, op cacheOp) (context.Context, trace.Span, time.Time) {
+	var span trace.Span
+	if c.sampled(ctx) {
+		ctx, span = tracing.StartSpan(ctx, "cache."+cacheOpNames[op],
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("cache.name", c.name),
+				attribute.String("cache.tier", c.tier),
+			))
+	}
+	return ctx, span, time.Now()
+}
+
+// sampled 只在上游span被采样时按比例采样，避免热点路径产生大量span
+func (c *InstrumentedCache) sampled(ctx context.Context) bool {
+	rate := c.options.TraceSampleRate
+	if rate <= 0 || !trace.SpanFromContext(ctx).IsRecording() {
+		return false
+	}
+	return rate >= 1 || rand.Float64() < rate
+}
+
+// finish 记录耗时与错误并结束span，hit仅对读操作有意义
+func (c *InstrumentedCache) finish(ctx context.Context, span trace.Span, start time.Time, op cacheOp, key string, hit bool, err error) {
+	if err != nil {
+		c.stats.errors.Add(1)
+	}
+	if c.options.Metrics {
+		c.durations[op].Observe(time.Since(start).Seconds())
+		if err != nil {
+			c.errors[op].Inc()
+		}
+	}
+
+	if span == nil {
+		return
+	}
+	if !c.options.TraceKeys {
+		key = ""
+	}
+	tracing.TraceCacheSystem(ctx, c.system, cacheOpNames[op], key, hit)
+	if err != nil {
+		tracing.RecordError(ctx, err)
+	}
+	span.End()
+}
+
+// recordLookups 记录命中与未命中次数
+func (c *InstrumentedCache) recordLookups(hits, misses int) {
+	c.stats.hits.Add(int64(hits))
+	c.stats.misses.Add(int64(misses))
+	if !c.options.Metrics {
+		return
+	}
+	if hits > 0 {
+		c.hits.Add(float64(hits))
+		if c.cacheHits != nil {
+			c.cacheHits.Add(float64(hits))
+		}
+	}
+	if misses > 0 {
+		c.misses.Add(float64(misses))
+		if c.cacheMisses != nil {
+			c.cacheMisses.Add(float64(misses))
+		}
+	}
+}
+
+// Get 获取值
+func (c *InstrumentedCache) Get(ctx context.Context, key string) (interface{}, error) {
+	ctx, span, start := c.start(ctx, opGet)
+	value, err := c.cache.Get(ctx, key)
+
+	switch {
+	case err == nil:
+		c.recordLookups(1, 0)
+		c.finish(ctx, span, start, opGet, key, true, nil)
+	case errors.Is(err, ErrKeyNotFound):
+		c.recordLookups(0, 1)
+		c.finish(ctx, span, start, opGet, key, false, nil)
+	default:
+		c.finish(ctx, span, start, opGet, key, false, err)
+	}
+	return value, err
+}
+
+// Set 设置值
+func (c *InstrumentedCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	ctx, span, start := c.start(ctx, opSet)
+	err := c.cache.Set(ctx, key, value, expiration)
+	if err == nil {
+		c.stats.sets.Add(1)
+	}
+	c.finish(ctx, span, start, opSet, key, false, err)
+	return err
+}
+
+// Delete 删除键
+func (c *InstrumentedCache) Delete(ctx context.Context, key string) error {
+	ctx, span, start := c.start(ctx, opDelete)
+	err := c.cache.Delete(ctx, key)
+	if err == nil {
+		c.stats.deletes.Add(1)
+	}
+	c.finish(ctx, span, start, opDelete, key, false, err)
+	return err
+}
+
+// Exists 检查键是否存在
+func (c *InstrumentedCache) Exists(ctx context.Context, key string) (bool, error) {
+	ctx, span, start := c.start(ctx, opExists)
+	exists, err := c.cache.Exists(ctx, key)
+	c.finish(ctx, span, start, opExists, key, exists, err)
+	return exists, err
+}
+
+// Clear 清空缓存
+func (c *InstrumentedCache) Clear(ctx context.Context) error {
+	ctx, span, start := c.start(ctx, opClear)
+	err := c.cache.Clear(ctx)
+	c.finish(ctx, span, start, opClear, "", false, err)
+	return err
+}
+
+// Close 关闭缓存并停止上报统计
+func (c *InstrumentedCache) Close() error {
+	unregisterStatsCollector(c)
+	return c.cache.Close()
+}
+
+// MGet 批量获取，底层缓存不支持批量时逐个获取
+func (c *InstrumentedCache) MGet(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	ctx, span, start := c.start(ctx, opMGet)
+
+	var result map[string]interface{}
+	var err error
+	if batch, ok := c.cache.(BatchCache); ok {
+		result, err = batch.MGet(ctx, keys)
+	} else {
+		result = make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			value, getErr := c.cache.Get(ctx, key)
+			if getErr == nil {
+				result[key] = value
+			} else if !errors.Is(getErr, ErrKeyNotFound) {
+				err = getErr
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		c.finish(ctx, span, start, opMGet, "", false, err)
+		return nil, err
+	}
+	c.recordLookups(len(result), len(keys)-len(result))
+	c.finish(ctx, span, start, opMGet, "", len(result) > 0, nil)
+	return result, nil
+}
+
+// MSet 批量设置，底层缓存不支持批量时逐个设置
+func (c *InstrumentedCache) MSet(ctx context.Context, keyValues map[string]interface{}, expiration time.Duration) error {
+	ctx, span, start := c.start(ctx, opMSet)
+
+	var err error
+	if batch, ok := c.cache.(BatchCache); ok {
+		err = batch.MSet(ctx, keyValues, expiration)
+	} else {
+		for key, value := range keyValues {
+			if err = c.cache.Set(ctx, key, value, expiration); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil {
+		c.stats.sets.Add(int64(len(keyValues)))
+	}
+	c.finish(ctx, span, start, opMSet, "", false, err)
+	return err
+}
+
+// MDelete 批量删除，底层缓存不支持批量时逐个删除
+func (c *InstrumentedCache) MDelete(ctx context.Context, keys []string) error {
+	ctx, span, start := c.start(ctx, opMDelete)
+
+	var err error
+	if batch, ok := c.cache.(BatchCache); ok {
+		err = batch.MDelete(ctx, keys)
+	} else {
+		for _, key := range keys {
+			if err = c.cache.Delete(ctx, key); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil {
+		c.stats.deletes.Add(int64(len(keys)))
+	}
+	c.finish(ctx, span, start, opMDelete, "", false, err)
+	return err
+}
+
+// SetWithTags 设置值并关联标签
+func (c *InstrumentedCache) SetWithTags(ctx context.Context, key string, value interface{}, expiration time.Duration, tags ...string) error {
+	taggable, ok := c.cache.(TaggableCache)
+	if !ok {
+		return ErrTagsNotSupported
+	}
+	ctx, span, start := c.start(ctx, opSetWithTags)
+	err := taggable.SetWithTags(ctx, key, value, expiration, tags...)
+	if err == nil {
+		c.stats.sets.Add(1)
+	}
+	c.finish(ctx, span, start, opSetWithTags, key, false, err)
+	return err
+}
+
+// InvalidateTag 删除关联了该标签的所有键
+func (c *InstrumentedCache) InvalidateTag(ctx context.Context, tag string) error {
+	taggable, ok := c.cache.(TaggableCache)
+	if !ok {
+		return ErrTagsNotSupported
+	}
+	ctx, span, start := c.start(ctx, opInvalidateTag)
+	err := taggable.InvalidateTag(ctx, tag)
+	c.finish(ctx, span, start, opInvalidateTag, "", false, err)
+	return err
+}
+
+// TagKeys 关联了该标签的键
+func (c *InstrumentedCache) TagKeys(ctx context.Context, tag string) ([]string, error) {
+	taggable, ok := c.cache.(TaggableCache)
+	if !ok {
+		return nil, ErrTagsNotSupported
+	}
+	return taggable.TagKeys(ctx, tag)
+}
+
+// DeletePrefix 删除所有以prefix开头的键
+func (c *InstrumentedCache) DeletePrefix(ctx context.Context, prefix string) error {
+	prefixCache, ok := c.cache.(PrefixCache)
+	if !ok {
+		return ErrTagsNotSupported
+	}
+	ctx, span, start := c.start(ctx, opDeletePrefix)
+	err := prefixCache.DeletePrefix(ctx, prefix)
+	c.finish(ctx, span, start, opDeletePrefix, "", false, err)
+	return err
+}
+
+// GetObject 获取对象，底层缓存不支持序列化时返回 ErrNotSupported
+func (c *InstrumentedCache) GetObject(ctx context.Context, key string, obj interface{}) error {
+	serializable, ok := c.cache.(SerializableCache)
+	if !ok {
+		return fmt.Errorf("GetObject: %w", ErrNotSupported)
+	}
+	return serializable.GetObject(ctx, key, obj)
+}
+
+// SetObject 设置对象，底层缓存不支持序列化时返回 ErrNotSupported
+func (c *InstrumentedCache) SetObject(ctx context.Context, key string, obj interface{}, expiration time.Duration) error {
+	serializable, ok := c.cache.(SerializableCache)
+	if !ok {
+		return fmt.Errorf("SetObject: %w", ErrNotSupported)
+	}
+	return serializable.SetObject(ctx, key, obj, expiration)
+}
+
+// GetStats 统计信息，底层缓存支持统计时以其为准，否则返回装饰器记录的读写次数
+func (c *InstrumentedCache) GetStats() Stats {
+	if statsCache, ok := c.cache.(StatsCache); ok {
+		return statsCache.GetStats()
+	}
+	return Stats{
+		Hits:        c.stats.hits.Load(),
+		Misses:      c.stats.misses.Load(),
+		Sets:        c.stats.sets.Load(),
+		Deletes:     c.stats.deletes.Load(),
+		Errors:      c.stats.errors.Load(),
+		LastUpdated: time.Unix(0, c.stats.lastReset.Load()),
+	}
+}
+
+// ResetStats 重置统计信息
+func (c *InstrumentedCache) ResetStats() {
+	if statsCache, ok := c.cache.(StatsCache); ok {
+		statsCache.ResetStats()
+	}
+	c.stats.hits.Store(0)
+	c.stats.misses.Store(0)
+	c.stats.sets.Store(0)
+	c.stats.deletes.Store(0)
+	c.stats.errors.Store(0)
+	c.stats.lastReset.Store(time.Now().UnixNano())
+}
+
+// Flush 写回底层混合缓存的待写回数据，底层缓存不支持时返回 ErrNotSupported
+func (c *InstrumentedCache) Flush(ctx context.Context) error {
+	flusher, ok := c.cache.(interface{ Flush(context.Context) error })
+	if !ok {
+		return fmt.Errorf("Flush: %w", ErrNotSupported)
+	}
+	return flusher.Flush(ctx)
+}
+
+// 由底层缓存统计信息在采集时生成的指标
+var (
+	cacheEvictionsDesc = prometheus.NewDesc("cache_evictions_total", "Total number of cache evictions", []string{"cache", "tier"}, nil)
+	cacheSizeDesc      = prometheus.NewDesc("cache_size_bytes", "Current size of cache entries in bytes", []string{"cache", "tier"}, nil)
+	cacheEntriesDesc   = prometheus.NewDesc("cache_entries", "Current number of cache entries", []string{"cache", "tier"}, nil)
+)
+
+// statsCollector 采集所有装饰器底层缓存的淘汰数与容量
+type statsCollector struct {
+	mu     sync.RWMutex
+	caches map[[2]string]*InstrumentedCache
+}
+
+var (
+	cacheStats            = &statsCollector{caches: make(map[[2]string]*InstrumentedCache)}
+	registerCollectorOnce sync.Once
+)
+
+// registerStatsCollector 注册缓存，同名同层级的缓存以后注册的为准
+func registerStatsCollector(c *InstrumentedCache) {
+	registerCollectorOnce.Do(func() {
+		_ = prometheus.Register(cacheStats)
+	})
+	cacheStats.mu.Lock()
+	cacheStats.caches[[2]string{c.name, c.tier}] = c
+	cacheStats.mu.Unlock()
+}
+
+// unregisterStatsCollector 缓存关闭后不再上报
+func unregisterStatsCollector(c *InstrumentedCache) {
+	cacheStats.mu.Lock()
+	if cacheStats.caches[[2]string{c.name, c.tier}] == c {
+		delete(cacheStats.caches, [2]string{c.name, c.tier})
+	}
+	cacheStats.mu.Unlock()
+}
+
+// Describe 实现 prometheus.Collector
+func (s *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheEvictionsDesc
+	ch <- cacheSizeDesc
+	ch <- cacheEntriesDesc
+}
+
+// Collect 实现 prometheus.Collector
+func (s *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, c := range s.caches {
+		underlying := Unwrap(c.cache)
+		if statsCache, ok := underlying.(StatsCache); ok {
+			stats := statsCache.GetStats()
+			ch <- prometheus.MustNewConstMetric(cacheEvictionsDesc, prometheus.CounterValue, float64(stats.Evictions), c.name, c.tier)
+			ch <- prometheus.MustNewConstMetric(cacheSizeDesc, prometheus.GaugeValue, float64(stats.Bytes), c.name, c.tier)
+		}
+		if sized, ok := underlying.(interface{ Len() int }); ok {
+			ch <- prometheus.MustNewConstMetric(cacheEntriesDesc, prometheus.GaugeValue, float64(sized.Len()), c.name, c.tier)
+		}
+	}
+}
@@ -29,6 +29,10 @@ func NewIntegrationManager(frameworkConfig *config.Config) *IntegrationManager {
 		manager.RegisterBuilder("framework-redis", NewSimpleRedisBuilder(database.RedisClient))
 	}
 	
+	// 注册Redis Cluster / Sentinel构建器
+	registerRedisTopologyBuilders(manager)
+	manager.RegisterBuilder(TypeMemcached, &MemcachedBuilder{})
+
 	// 注册混合缓存构建器
 	manager.RegisterBuilder(TypeHybrid, NewHybridBuilder(manager))
 
@@ -74,6 +78,13 @@ func (im *IntegrationManager) CreateHybridCacheWithFrameworkRedis(name string, h
 			"write_back_batch_size": hybridConfig.WriteBackBatchSize,
 			"l1_ttl":                hybridConfig.L1TTL,
 			"l2_ttl":                hybridConfig.L2TTL,
+
+			"write_back_max_pending":     hybridConfig.WriteBackMaxPending,
+			"write_back_overflow":        hybridConfig.WriteBackOverflow,
+			"write_back_flush_timeout":   hybridConfig.WriteBackFlushTimeout,
+			"write_back_journal_backend": hybridConfig.WriteBackJournalBackend,
+			"write_back_journal_path":    hybridConfig.WriteBackJournalPath,
+			"write_back_journal_sync":    hybridConfig.WriteBackJournalSync,
 		},
 	}
 
@@ -27,6 +27,24 @@ type SerializableCache interface {
 	SetObject(ctx context.Context, key string, obj interface{}, expiration time.Duration) error
 }
 
+// TaggableCache 支持按标签批量失效的缓存
+type TaggableCache interface {
+	Cache
+	// SetWithTags 设置值并关联标签，标签只增不减，直到键被删除或淘汰
+	SetWithTags(ctx context.Context, key string, value interface{}, expiration time.Duration, tags ...string) error
+	// InvalidateTag 删除关联了该标签的所有键
+	InvalidateTag(ctx context.Context, tag string) error
+	// TagKeys 关联了该标签的键，可能包含已过期的键
+	TagKeys(ctx context.Context, tag string) ([]string, error)
+}
+
+// PrefixCache 支持按前缀批量删除的缓存
+type PrefixCache interface {
+	Cache
+	// DeletePrefix 删除所有以prefix开头的键
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
 type Stats struct {
 	Hits        int64
 	Misses      int64
@@ -34,6 +52,7 @@ type Stats struct {
 	Deletes     int64
 	Errors      int64
 	Evictions   int64
+	Bytes       int64 // 当前条目总字节数（仅按字节数限制容量时统计）
 	LastUpdated time.Time
 }
 
@@ -46,8 +65,10 @@ type StatsCache interface {
 type Type string
 
 const (
-	TypeMemory    Type = "memory"
-	TypeRedis     Type = "redis"
-	TypeMemcached Type = "memcached"
-	TypeHybrid    Type = "hybrid"
+	TypeMemory        Type = "memory"
+	TypeRedis         Type = "redis"
+	TypeRedisCluster  Type = "redis_cluster"
+	TypeRedisSentinel Type = "redis_sentinel"
+	TypeMemcached     Type = "memcached"
+	TypeHybrid        Type = "hybrid"
 )
@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/qiaojinxia/distributed-service/pkg/etcd"
+)
+
+// DefaultInvalidationChannel 默认失效广播频道
+const DefaultInvalidationChannel = "cache:invalidation"
+
+// InvalidationMessage L1失效消息
+type InvalidationMessage struct {
+	Source    string    `json:"source"`           // 发送实例ID，实例会忽略自己发出的消息
+	Cache     string    `json:"cache"`            // 缓存名称
+	Keys      []string  `json:"keys,omitempty"`   // 失效的键
+	Clear     bool      `json:"clear,omitempty"`  // 清空整个L1
+	Prefix    string    `json:"prefix,omitempty"` // 失效以该前缀开头的键
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// InvalidationHandler 失效消息处理函数
+type InvalidationHandler func(msg *InvalidationMessage)
+
+// InvalidationBus 跨实例的L1失效广播总线
+//
+// 默认实现基于Redis Pub/Sub，也可以替换为etcd watch、Kafka等实现。
+type InvalidationBus interface {
+	// Publish 广播失效消息
+	Publish(ctx context.Context, msg *InvalidationMessage) error
+
+	// Subscribe 订阅失效消息，订阅建立后立即返回，ctx取消或总线关闭时停止
+	Subscribe(ctx context.Context, handler InvalidationHandler) error
+
+	// Close 关闭总线并停止所有订阅
+	Close() error
+}
+
+// RedisInvalidationBus 基于Redis Pub/Sub的失效总线
+type RedisInvalidationBus struct {
+	client  *redis.Client
+	channel string
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewRedisInvalidationBus 创建Redis失效总线
+func NewRedisInvalidationBus(client *redis.Client, channel string) *RedisInvalidationBus {
+	if channel == "" {
+		channel = DefaultInvalidationChannel
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &RedisInvalidationBus{
+		client:  client,
+		channel: channel,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Publish 广播失效消息
+func (b *RedisInvalidationBus) Publish(ctx context.Context, msg *InvalidationMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal invalidation message: %w", err)
+	}
+	if err := b.client.Publish(ctx, b.channel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish invalidation message: %w", err)
+	}
+	return nil
+}
+
+// Subscribe 订阅失效消息
+func (b *RedisInvalidationBus) Subscribe(ctx context.Context, handler InvalidationHandler) error {
+	pubsub := b.client.Subscribe(ctx, b.channel)
+	// 等待订阅确认，确保返回后发布的消息都能收到
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return fmt.Errorf("failed to subscribe invalidation channel %s: %w", b.channel, err)
+	}
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-b.ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				msg := &InvalidationMessage{}
+				if err := json.Unmarshal([]byte(message.Payload), msg); err != nil {
+					continue
+				}
+				handler(msg)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Close 关闭总线，Redis客户端由调用方管理
+func (b *RedisInvalidationBus) Close() error {
+	b.cancel()
+	b.wg.Wait()
+	return nil
+}
+
+// EtcdInvalidationBus 基于etcd watch的失效总线
+//
+// 所有消息写入同一个键，watch 会按revision依次收到每次写入。
+type EtcdInvalidationBus struct {
+	client *etcd.Client
+	key    string
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewEtcdInvalidationBus 创建etcd失效总线
+func NewEtcdInvalidationBus(client *etcd.Client, key string) *EtcdInvalidationBus {
+	if key == "" {
+		key = "/" + DefaultInvalidationChannel
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &EtcdInvalidationBus{
+		client: client,
+		key:    key,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Publish 广播失效消息
+func (b *EtcdInvalidationBus) Publish(ctx context.Context, msg *InvalidationMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal invalidation message: %w", err)
+	}
+	if err := b.client.Put(ctx, b.key, string(data)); err != nil {
+		return fmt.Errorf("failed to publish invalidation message: %w", err)
+	}
+	return nil
+}
+
+// Subscribe 订阅失效消息
+func (b *EtcdInvalidationBus) Subscribe(ctx context.Context, handler InvalidationHandler) error {
+	watchCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-watchCtx.Done():
+		case <-b.ctx.Done():
+			cancel()
+		}
+	}()
+
+	err := b.client.Watch(watchCtx, b.key, func(event *etcd.WatchEvent) error {
+		if event.Type != "PUT" {
+			return nil
+		}
+		msg := &InvalidationMessage{}
+		if err := json.Unmarshal(event.Value, msg); err != nil {
+			return fmt.Errorf("failed to unmarshal invalidation message: %w", err)
+		}
+		handler(msg)
+		return nil
+	})
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to watch invalidation key %s: %w", b.key, err)
+	}
+	return nil
+}
+
+// Close 关闭总线，etcd客户端由调用方管理
+func (b *EtcdInvalidationBus) Close() error {
+	b.cancel()
+	return nil
+}
+
+// newInstanceID 生成实例ID：主机名-进程号-随机后缀
+func newInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(suffix))
+}
@@ -0,0 +1,238 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/logger"
+	"golang.org/x/sync/singleflight"
+)
+
+// LoaderFunc 缓存未命中时的数据加载函数，数据不存在时返回 ErrKeyNotFound
+type LoaderFunc func(ctx context.Context, key string) (interface{}, error)
+
+// LoaderOptions 缓存旁路加载配置
+type LoaderOptions struct {
+	// NegativeTTL 数据不存在时的缓存时长，0表示不缓存未找到结果
+	NegativeTTL time.Duration
+	// StaleTTL 数据过期后仍可返回旧值并在后台刷新的时长，0表示不启用
+	StaleTTL time.Duration
+	// EarlyExpirationBeta 概率提前过期系数（XFetch），0表示不启用，通常取1，越大越早刷新
+	EarlyExpirationBeta float64
+	// RefreshTimeout 单次加载的超时时间，合并后的加载和后台刷新都不受调用方ctx取消的影响
+	RefreshTimeout time.Duration
+}
+
+// DefaultLoaderOptions 默认加载配置
+func DefaultLoaderOptions() LoaderOptions {
+	return LoaderOptions{
+		RefreshTimeout: 10 * time.Second,
+	}
+}
+
+// loadEntryVersion 加载条目的序列化版本，用于区分普通缓存值
+const loadEntryVersion = 1
+
+// loadEntry 加载器写入缓存的条目
+//
+// 内存缓存直接保存指针；Redis等远程缓存通过 MarshalBinary 保存为JSON，
+// 读取后 Value 为JSON解码得到的通用类型（map、float64等），需要具体类型时配合 TypedCache 使用。
+type loadEntry struct {
+	Version   int           `json:"v"`
+	Value     interface{}   `json:"value,omitempty"`
+	NotFound  bool          `json:"not_found,omitempty"`
+	ExpiresAt time.Time     `json:"expires_at"`      // 逻辑过期时间，零值表示永不过期
+	Delta     time.Duration `json:"delta,omitempty"` // 加载耗时，用于计算提前过期概率
+}
+
+// MarshalBinary 序列化为JSON，供Redis客户端写入
+func (e *loadEntry) MarshalBinary() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// fresh 判断条目是否未过期
+func (e *loadEntry) fresh(now time.Time) bool {
+	return e.ExpiresAt.IsZero() || now.Before(e.ExpiresAt)
+}
+
+// result 条目对应的返回值
+func (e *loadEntry) result() (interface{}, error) {
+	if e.NotFound {
+		return nil, ErrKeyNotFound
+	}
+	return e.Value, nil
+}
+
+// decodeLoadEntry 解析缓存中的值，非加载器写入的值返回false
+func decodeLoadEntry(value interface{}) (*loadEntry, bool) {
+	var data []byte
+	switch v := value.(type) {
+	case *loadEntry:
+		return v, true
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return nil, false
+	}
+
+	entry := &loadEntry{}
+	if err := json.Unmarshal(data, entry); err != nil || entry.Version != loadEntryVersion {
+		return nil, false
+	}
+	return entry, true
+}
+
+// Loader 缓存旁路加载器
+//
+// 同一个键的并发加载通过 singleflight 合并为一次；数据不存在时可缓存负结果；
+// 过期后的 StaleTTL 内先返回旧值再后台刷新；临近过期时按 XFetch 算法
+// 以随剩余时间递增的概率提前刷新，避免热点键同时过期引发的惊群。
+type Loader struct {
+	cache      Cache
+	options    LoaderOptions
+	group      singleflight.Group
+	refreshing sync.Map // 正在后台刷新的键
+}
+
+// NewLoader 创建缓存旁路加载器
+func NewLoader(cache Cache, options ...LoaderOptions) *Loader {
+	opts := DefaultLoaderOptions()
+	if len(options) > 0 {
+		opts = options[0]
+		if opts.RefreshTimeout <= 0 {
+			opts.RefreshTimeout = DefaultLoaderOptions().RefreshTimeout
+		}
+	}
+	return &Loader{
+		cache:   cache,
+		options: opts,
+	}
+}
+
+// GetOrLoad 获取缓存值，未命中时调用loader加载并写入缓存
+//
+// loader 返回 ErrKeyNotFound 时，若配置了 NegativeTTL 则缓存该结果；
+// 其他加载错误不会写入缓存。缓存写入失败不影响已加载的值返回。
+func (l *Loader) GetOrLoad(ctx context.Context, key string, loader LoaderFunc, ttl time.Duration) (interface{}, error) {
+	value, err := l.cache.Get(ctx, key)
+	if err == nil {
+		entry, ok := decodeLoadEntry(value)
+		if !ok {
+			// 由其他途径直接写入的值，按普通命中处理
+			return value, nil
+		}
+
+		now := time.Now()
+		if entry.fresh(now) {
+			if l.shouldRefreshEarly(entry, now) {
+				l.refresh(key, loader, ttl)
+			}
+			return entry.result()
+		}
+		if !entry.NotFound && l.options.StaleTTL > 0 && now.Before(entry.ExpiresAt.Add(l.options.StaleTTL)) {
+			l.refresh(key, loader, ttl)
+			return entry.result()
+		}
+	} else if !errors.Is(err, ErrKeyNotFound) {
+		return nil, fmt.Errorf("failed to get key %s: %w", key, err)
+	}
+
+	// 加载结果由所有等待者共享，不使用首个调用方的ctx，避免其取消导致其他调用方一起失败
+	results := l.group.DoChan(key, func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), l.options.RefreshTimeout)
+		defer cancel()
+
+		// 等待期间其他请求可能已完成加载
+		if value, err := l.cache.Get(loadCtx, key); err == nil {
+			if entry, ok := decodeLoadEntry(value); ok && entry.fresh(time.Now()) {
+				return entry, nil
+			}
+		}
+		return l.load(loadCtx, key, loader, ttl)
+	})
+
+	select {
+	case result := <-results:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*loadEntry).result()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// load 调用loader加载并写入缓存
+func (l *Loader) load(ctx context.Context, key string, loader LoaderFunc, ttl time.Duration) (*loadEntry, error) {
+	start := time.Now()
+	value, err := loader(ctx, key)
+	now := time.Now()
+
+	entry := &loadEntry{Version: loadEntryVersion, Delta: now.Sub(start)}
+	var expiration time.Duration
+	switch {
+	case err == nil:
+		entry.Value = value
+		if ttl > 0 {
+			entry.ExpiresAt = now.Add(ttl)
+			expiration = ttl + l.options.StaleTTL
+		}
+	case errors.Is(err, ErrKeyNotFound):
+		if l.options.NegativeTTL <= 0 {
+			return nil, err
+		}
+		entry.NotFound = true
+		entry.ExpiresAt = now.Add(l.options.NegativeTTL)
+		expiration = l.options.NegativeTTL
+	default:
+		return nil, fmt.Errorf("failed to load key %s: %w", key, err)
+	}
+
+	if err := l.cache.Set(ctx, key, entry, expiration); err != nil {
+		logger.Warn(ctx, "Failed to cache loaded value",
+			logger.String("key", key),
+			logger.Err(err))
+	}
+	return entry, nil
+}
+
+// shouldRefreshEarly XFetch：剩余时间越短、加载越慢，提前刷新的概率越高
+func (l *Loader) shouldRefreshEarly(entry *loadEntry, now time.Time) bool {
+	if l.options.EarlyExpirationBeta <= 0 || entry.NotFound || entry.ExpiresAt.IsZero() || entry.Delta <= 0 {
+		return false
+	}
+	gap := -float64(entry.Delta) * l.options.EarlyExpirationBeta * math.Log(1-rand.Float64())
+	return !now.Add(time.Duration(gap)).Before(entry.ExpiresAt)
+}
+
+// refresh 在后台刷新键，同一个键同时只有一个刷新任务
+func (l *Loader) refresh(key string, loader LoaderFunc, ttl time.Duration) {
+	if _, loaded := l.refreshing.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+
+	go func() {
+		defer l.refreshing.Delete(key)
+
+		ctx, cancel := context.WithTimeout(context.Background(), l.options.RefreshTimeout)
+		defer cancel()
+
+		_, err, _ := l.group.Do(key, func() (interface{}, error) {
+			return l.load(ctx, key, loader, ttl)
+		})
+		if err != nil && !errors.Is(err, ErrKeyNotFound) {
+			// 刷新失败时保留旧值，直到其物理过期
+			logger.Warn(ctx, "Failed to refresh cached value",
+				logger.String("key", key),
+				logger.Err(err))
+		}
+	}()
+}
@@ -3,14 +3,18 @@ package cache
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
 
 type Manager struct {
-	caches   map[string]Cache
-	builders map[Type]Builder
-	mutex    sync.RWMutex
+	caches          map[string]Cache
+	configs         map[string]Config
+	loaders         map[string]*Loader // 按缓存名称复用的默认加载器，随缓存移除
+	builders        map[Type]Builder
+	instrumentation *InstrumentationOptions // 为nil时不装饰新建的缓存
+	mutex           sync.RWMutex
 }
 
 type Builder interface {
@@ -24,38 +28,92 @@ type Config struct {
 }
 
 func NewManager() *Manager {
+	instrumentation := DefaultInstrumentationOptions()
 	return &Manager{
-		caches:   make(map[string]Cache),
-		builders: make(map[Type]Builder),
+		caches:          make(map[string]Cache),
+		configs:         make(map[string]Config),
+		loaders:         make(map[string]*Loader),
+		builders:        make(map[Type]Builder),
+		instrumentation: &instrumentation,
 	}
 }
 
-func (m *Manager) RegisterBuilder(cacheType Type, builder Builder) {
+// SetInstrumentation 设置之后创建的缓存的指标与追踪选项，nil表示不装饰
+func (m *Manager) SetInstrumentation(options *InstrumentationOptions) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
-	m.builders[cacheType] = builder
+	m.instrumentation = options
 }
 
-func (m *Manager) CreateCache(config Config) error {
+func (m *Manager) RegisterBuilder(cacheType Type, builder Builder) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
+	m.builders[cacheType] = builder
+}
 
-	if _, exists := m.caches[config.Name]; exists {
-		return fmt.Errorf("cache %s already exists", config.Name)
+// memoryBuilder 返回已注册的内存缓存构建器，使 SetMemoryDefaults 的默认配置同样作用于混合缓存的L1
+func (m *Manager) memoryBuilder() Builder {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if builder, ok := m.builders[TypeMemory].(*MemoryBuilder); ok {
+		return builder
 	}
+	return &MemoryBuilder{}
+}
 
-	builder, exists := m.builders[config.Type]
-	if !exists {
-		return fmt.Errorf("no builder registered for cache type %s", config.Type)
+// CreateCache 创建缓存，启用指标时返回的缓存由 InstrumentedCache 装饰
+func (m *Manager) CreateCache(config Config) error {
+	tier := TierDefault
+	if config.Type == TypeHybrid {
+		tier = TierHybrid
+	}
+	_, err := m.createCache(config, config.Name, tier)
+	return err
+}
+
+// createCache 创建并注册缓存，metricName和tier为指标标签
+//
+// 构建时不持有锁，混合缓存的构建器会通过同一个管理器创建L2。
+func (m *Manager) createCache(config Config, metricName, tier string) (Cache, error) {
+	m.mutex.RLock()
+	_, exists := m.caches[config.Name]
+	builder, registered := m.builders[config.Type]
+	m.mutex.RUnlock()
+
+	if exists {
+		return nil, fmt.Errorf("cache %s already exists", config.Name)
+	}
+	if !registered {
+		return nil, fmt.Errorf("no builder registered for cache type %s", config.Type)
 	}
 
 	cache, err := builder.Build(config)
 	if err != nil {
-		return fmt.Errorf("failed to build cache %s: %w", config.Name, err)
+		return nil, fmt.Errorf("failed to build cache %s: %w", config.Name, err)
 	}
+	cache = m.instrument(cache, metricName, tier, string(config.Type))
 
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, exists := m.caches[config.Name]; exists {
+		_ = cache.Close()
+		return nil, fmt.Errorf("cache %s already exists", config.Name)
+	}
 	m.caches[config.Name] = cache
-	return nil
+	m.configs[config.Name] = config
+	return cache, nil
+}
+
+// instrument 按管理器的选项装饰缓存
+func (m *Manager) instrument(cache Cache, name, tier, system string) Cache {
+	m.mutex.RLock()
+	options := m.instrumentation
+	m.mutex.RUnlock()
+
+	if options == nil {
+		return cache
+	}
+	return NewInstrumentedCache(cache, name, tier, system, *options)
 }
 
 func (m *Manager) GetCache(name string) (Cache, error) {
@@ -70,6 +128,18 @@ func (m *Manager) GetCache(name string) (Cache, error) {
 	return cache, nil
 }
 
+// GetConfig 返回创建缓存时使用的配置
+func (m *Manager) GetConfig(name string) (Config, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	config, exists := m.configs[name]
+	if !exists {
+		return Config{}, fmt.Errorf("cache %s not found", name)
+	}
+	return config, nil
+}
+
 func (m *Manager) RemoveCache(name string) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -84,6 +154,8 @@ func (m *Manager) RemoveCache(name string) error {
 	}
 
 	delete(m.caches, name)
+	delete(m.configs, name)
+	delete(m.loaders, name)
 	return nil
 }
 
@@ -110,9 +182,37 @@ func (m *Manager) Close() error {
 	}
 
 	m.caches = make(map[string]Cache)
+	m.configs = make(map[string]Config)
+	m.loaders = make(map[string]*Loader)
 	return lastErr
 }
 
+// Loader 获取缓存的默认配置加载器，同一缓存上的并发加载会被合并，缓存移除时一并释放
+func (m *Manager) Loader(name string) (*Loader, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if loader, ok := m.loaders[name]; ok {
+		return loader, nil
+	}
+	cache, exists := m.caches[name]
+	if !exists {
+		return nil, fmt.Errorf("cache %s not found", name)
+	}
+	loader := NewLoader(cache)
+	m.loaders[name] = loader
+	return loader, nil
+}
+
+// GetOrLoad 使用缓存的默认加载器获取值，未命中时调用loader加载并写入缓存
+func (m *Manager) GetOrLoad(ctx context.Context, name, key string, loader LoaderFunc, ttl time.Duration) (interface{}, error) {
+	l, err := m.Loader(name)
+	if err != nil {
+		return nil, err
+	}
+	return l.GetOrLoad(ctx, key, loader, ttl)
+}
+
 type Wrapper struct {
 	cache Cache
 	name  string
@@ -134,8 +234,60 @@ func (w *Wrapper) Exists(ctx context.Context, key string) (bool, error) {
 	return w.cache.Exists(ctx, w.prefixKey(key))
 }
 
+// Clear 只清空本命名空间下的键，底层缓存不支持前缀删除时返回 ErrTagsNotSupported
 func (w *Wrapper) Clear(ctx context.Context) error {
-	return w.cache.Clear(ctx)
+	return w.DeletePrefix(ctx, "")
+}
+
+// SetWithTags 设置值并关联标签，标签同样带命名空间前缀，与其他命名空间的同名标签互不影响
+func (w *Wrapper) SetWithTags(ctx context.Context, key string, value interface{}, expiration time.Duration, tags ...string) error {
+	taggable, ok := asTaggable(w.cache)
+	if !ok {
+		return ErrTagsNotSupported
+	}
+	namespaced := make([]string, len(tags))
+	for i, tag := range tags {
+		namespaced[i] = w.prefixKey(tag)
+	}
+	return taggable.SetWithTags(ctx, w.prefixKey(key), value, expiration, namespaced...)
+}
+
+// InvalidateTag 删除本命名空间下关联了该标签的所有键
+func (w *Wrapper) InvalidateTag(ctx context.Context, tag string) error {
+	taggable, ok := asTaggable(w.cache)
+	if !ok {
+		return ErrTagsNotSupported
+	}
+	return taggable.InvalidateTag(ctx, w.prefixKey(tag))
+}
+
+// TagKeys 本命名空间下关联了该标签的键（不含命名空间前缀）
+func (w *Wrapper) TagKeys(ctx context.Context, tag string) ([]string, error) {
+	taggable, ok := asTaggable(w.cache)
+	if !ok {
+		return nil, ErrTagsNotSupported
+	}
+	keys, err := taggable.TagKeys(ctx, w.prefixKey(tag))
+	if err != nil {
+		return nil, err
+	}
+
+	namespaced := keys[:0]
+	for _, key := range keys {
+		if strings.HasPrefix(key, w.prefixKey("")) {
+			namespaced = append(namespaced, strings.TrimPrefix(key, w.prefixKey("")))
+		}
+	}
+	return namespaced, nil
+}
+
+// DeletePrefix 删除本命名空间下以prefix开头的键
+func (w *Wrapper) DeletePrefix(ctx context.Context, prefix string) error {
+	prefixCache, ok := asPrefixCache(w.cache)
+	if !ok {
+		return ErrTagsNotSupported
+	}
+	return prefixCache.DeletePrefix(ctx, w.prefixKey(prefix))
 }
 
 func (w *Wrapper) Close() error {
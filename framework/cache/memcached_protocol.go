@@ -0,0 +1,241 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// memcachedProtocolError 服务端返回的错误（ERROR、CLIENT_ERROR、SERVER_ERROR、NOT_STORED）
+type memcachedProtocolError struct {
+	line string
+}
+
+func (e *memcachedProtocolError) Error() string {
+	return "memcached: " + e.line
+}
+
+// errMemcachedNotStored set未被保存，是唯一可以复用连接的错误响应
+var errMemcachedNotStored = &memcachedProtocolError{line: "NOT_STORED"}
+
+// memcachedConn 单个连接
+type memcachedConn struct {
+	nc net.Conn
+	rw *bufio.ReadWriter
+}
+
+// memcachedServer 单个Memcached节点及其空闲连接池
+type memcachedServer struct {
+	addr    string
+	timeout time.Duration
+	idle    chan *memcachedConn
+}
+
+// newMemcachedServer 创建节点，maxIdle为保留的空闲连接数
+func newMemcachedServer(addr string, timeout time.Duration, maxIdle int) *memcachedServer {
+	return &memcachedServer{
+		addr:    addr,
+		timeout: timeout,
+		idle:    make(chan *memcachedConn, maxIdle),
+	}
+}
+
+// do 取出一个连接执行fn，成功或NOT_STORED时放回连接池，其他错误时关闭连接
+func (s *memcachedServer) do(ctx context.Context, fn func(c *memcachedConn) error) error {
+	c, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(s.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.nc.SetDeadline(deadline); err != nil {
+		_ = c.nc.Close()
+		return fmt.Errorf("memcached %s: %w", s.addr, err)
+	}
+
+	err = fn(c)
+	if err != nil && !errors.Is(err, errMemcachedNotStored) {
+		// ERROR、CLIENT_ERROR 后服务端可能把未读完的数据当作命令处理，后续响应会错位
+		_ = c.nc.Close()
+		var protocolErr *memcachedProtocolError
+		if errors.As(err, &protocolErr) {
+			return err
+		}
+		return fmt.Errorf("memcached %s: %w", s.addr, err)
+	}
+
+	select {
+	case s.idle <- c:
+	default:
+		_ = c.nc.Close()
+	}
+	return err
+}
+
+// conn 优先复用空闲连接
+func (s *memcachedServer) conn(ctx context.Context) (*memcachedConn, error) {
+	select {
+	case c := <-s.idle:
+		return c, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: s.timeout}
+	nc, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect memcached %s: %w", s.addr, err)
+	}
+	return &memcachedConn{
+		nc: nc,
+		rw: bufio.NewReadWriter(bufio.NewReader(nc), bufio.NewWriter(nc)),
+	}, nil
+}
+
+// close 关闭所有空闲连接
+func (s *memcachedServer) close() {
+	for {
+		select {
+		case c := <-s.idle:
+			_ = c.nc.Close()
+		default:
+			return
+		}
+	}
+}
+
+// readLine 读取一行响应（不含\r\n）
+func (c *memcachedConn) readLine() (string, error) {
+	line, err := c.rw.ReadSlice('\n')
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSuffix(line, []byte("\r\n"))), nil
+}
+
+// checkMemcachedError 识别服务端错误响应
+func checkMemcachedError(line string) error {
+	if line == "ERROR" || strings.HasPrefix(line, "CLIENT_ERROR ") || strings.HasPrefix(line, "SERVER_ERROR ") {
+		return &memcachedProtocolError{line: line}
+	}
+	return nil
+}
+
+// get 批量读取，每个命中的键回调一次fn
+func (c *memcachedConn) get(keys []string, fn func(key string, data []byte)) error {
+	if _, err := fmt.Fprintf(c.rw, "get %s\r\n", strings.Join(keys, " ")); err != nil {
+		return err
+	}
+	if err := c.rw.Flush(); err != nil {
+		return err
+	}
+
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if line == "END" {
+			return nil
+		}
+		if err := checkMemcachedError(line); err != nil {
+			return err
+		}
+
+		// VALUE <key> <flags> <bytes>
+		fields := strings.Fields(line)
+		if len(fields) != 4 || fields[0] != "VALUE" {
+			return fmt.Errorf("unexpected memcached response: %q", line)
+		}
+		size, err := strconv.Atoi(fields[3])
+		if err != nil || size < 0 {
+			return fmt.Errorf("unexpected memcached response: %q", line)
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(c.rw, data); err != nil {
+			return err
+		}
+		if !bytes.HasSuffix(data, []byte("\r\n")) {
+			return fmt.Errorf("corrupt memcached value for key %s", fields[1])
+		}
+		fn(fields[1], data[:size])
+	}
+}
+
+// writeSet 写入set命令，不读取响应（用于流水线）
+func (c *memcachedConn) writeSet(key string, data []byte, exptime int64) error {
+	if _, err := fmt.Fprintf(c.rw, "set %s 0 %d %d\r\n", key, exptime, len(data)); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(data); err != nil {
+		return err
+	}
+	_, err := c.rw.WriteString("\r\n")
+	return err
+}
+
+// readSet 读取set响应
+func (c *memcachedConn) readSet() error {
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	switch line {
+	case "STORED":
+		return nil
+	case "NOT_STORED":
+		return errMemcachedNotStored
+	}
+	if err := checkMemcachedError(line); err != nil {
+		return err
+	}
+	return fmt.Errorf("unexpected memcached response: %q", line)
+}
+
+// readDelete 读取delete响应，返回键是否存在
+func (c *memcachedConn) readDelete() (bool, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return false, err
+	}
+	switch line {
+	case "DELETED":
+		return true, nil
+	case "NOT_FOUND":
+		return false, nil
+	}
+	if err := checkMemcachedError(line); err != nil {
+		return false, err
+	}
+	return false, fmt.Errorf("unexpected memcached response: %q", line)
+}
+
+// flushAll 清空节点上的所有数据
+func (c *memcachedConn) flushAll() error {
+	if _, err := c.rw.WriteString("flush_all\r\n"); err != nil {
+		return err
+	}
+	if err := c.rw.Flush(); err != nil {
+		return err
+	}
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	if line == "OK" {
+		return nil
+	}
+	if err := checkMemcachedError(line); err != nil {
+		return err
+	}
+	return fmt.Errorf("unexpected memcached response: %q", line)
+}
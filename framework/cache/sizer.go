@@ -0,0 +1,47 @@
+package cache
+
+import "encoding/json"
+
+// Sizer 计算缓存条目占用的字节数
+type Sizer func(key string, value interface{}) int64
+
+// Sized 可自行报告大小的值
+type Sized interface {
+	Size() int64
+}
+
+// DefaultSizer 默认条目大小估算：键长度 + 值大小
+//
+// 字符串和字节切片取长度，基本数值类型取固定大小，实现了 Sized 的值使用其 Size()，
+// 其他类型按JSON序列化后的长度估算。
+func DefaultSizer(key string, value interface{}) int64 {
+	return int64(len(key)) + valueSize(value)
+}
+
+// valueSize 估算值的大小
+func valueSize(value interface{}) int64 {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case Sized:
+		return v.Size()
+	case string:
+		return int64(len(v))
+	case []byte:
+		return int64(len(v))
+	case bool, int8, uint8:
+		return 1
+	case int16, uint16:
+		return 2
+	case int32, uint32, float32:
+		return 4
+	case int, int64, uint, uint64, uintptr, float64:
+		return 8
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return 0
+		}
+		return int64(len(data))
+	}
+}
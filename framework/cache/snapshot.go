@@ -0,0 +1,338 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/logger"
+)
+
+// snapshotMagic 快照文件头，用于识别格式版本
+const snapshotMagic = "DSCSNAP1"
+
+func init() {
+	// 缓存旁路加载写入内存缓存的条目
+	gob.Register(&loadEntry{})
+}
+
+// SnapshotStats 快照导出或恢复的结果
+type SnapshotStats struct {
+	Entries int // 导出或恢复的条目数
+	Skipped int // 无法编码（类型未 gob.Register）或已过期而跳过的条目数
+}
+
+// snapshotEntry 快照中的单个条目
+type snapshotEntry struct {
+	Key       string
+	Value     []byte // gob编码的 snapshotValue，单独编码使一个条目失败不影响其他条目
+	ExpiresAt int64  // 过期时间（UnixNano），0表示不过期
+	Tags      []string
+}
+
+// snapshotValue 包装任意类型的值，使gob记录具体类型
+type snapshotValue struct {
+	V interface{}
+}
+
+// memoryItem 内存缓存中的条目
+type memoryItem struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+	tags      []string
+}
+
+// writeSnapshot 将条目写入快照
+//
+// 格式为文件头加gzip压缩的gob条目流，值的具体类型需要预先 gob.Register（基础类型除外）。
+func writeSnapshot(w io.Writer, items []memoryItem) (SnapshotStats, error) {
+	var stats SnapshotStats
+	if _, err := io.WriteString(w, snapshotMagic); err != nil {
+		return stats, fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+
+	zw := gzip.NewWriter(w)
+	encoder := gob.NewEncoder(zw)
+	now := time.Now()
+	for _, item := range items {
+		if !item.expiresAt.IsZero() && !now.Before(item.expiresAt) {
+			stats.Skipped++
+			continue
+		}
+
+		var value bytes.Buffer
+		if err := gob.NewEncoder(&value).Encode(&snapshotValue{V: item.value}); err != nil {
+			stats.Skipped++
+			continue
+		}
+		entry := snapshotEntry{Key: item.key, Value: value.Bytes(), Tags: item.tags}
+		if !item.expiresAt.IsZero() {
+			entry.ExpiresAt = item.expiresAt.UnixNano()
+		}
+		if err := encoder.Encode(&entry); err != nil {
+			return stats, fmt.Errorf("failed to write snapshot entry %s: %w", item.key, err)
+		}
+		stats.Entries++
+	}
+
+	if err := zw.Close(); err != nil {
+		return stats, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return stats, nil
+}
+
+// readSnapshot 读取快照，按导出顺序对每个未过期的条目回调restore
+func readSnapshot(r io.Reader, restore func(item memoryItem) error) (SnapshotStats, error) {
+	var stats SnapshotStats
+	header := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(r, header); err != nil {
+		return stats, fmt.Errorf("failed to read snapshot header: %w", err)
+	}
+	if string(header) != snapshotMagic {
+		return stats, fmt.Errorf("invalid snapshot header %q", header)
+	}
+
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return stats, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	defer zr.Close()
+
+	decoder := gob.NewDecoder(zr)
+	for {
+		var entry snapshotEntry
+		if err := decoder.Decode(&entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				return stats, nil
+			}
+			return stats, fmt.Errorf("failed to read snapshot entry: %w", err)
+		}
+
+		item := memoryItem{key: entry.Key, tags: entry.Tags}
+		if entry.ExpiresAt != 0 {
+			item.expiresAt = time.Unix(0, entry.ExpiresAt)
+			if !time.Now().Before(item.expiresAt) {
+				stats.Skipped++
+				continue
+			}
+		}
+
+		var value snapshotValue
+		if err := gob.NewDecoder(bytes.NewReader(entry.Value)).Decode(&value); err != nil {
+			stats.Skipped++
+			continue
+		}
+		item.value = value.V
+
+		if err := restore(item); err != nil {
+			return stats, fmt.Errorf("failed to restore snapshot entry %s: %w", entry.Key, err)
+		}
+		stats.Entries++
+	}
+}
+
+// saveSnapshotFile 先写临时文件再重命名，避免进程中途退出留下不完整的快照
+func saveSnapshotFile(path string, items []memoryItem) (SnapshotStats, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return SnapshotStats{}, fmt.Errorf("failed to create snapshot directory: %w", err)
+		}
+	}
+
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return SnapshotStats{}, fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+
+	w := bufio.NewWriter(file)
+	stats, err := writeSnapshot(w, items)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return stats, err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return stats, fmt.Errorf("failed to rename snapshot file: %w", err)
+	}
+	return stats, nil
+}
+
+// loadSnapshotFile 读取快照文件，文件不存在时不恢复任何条目
+func loadSnapshotFile(path string, restore func(item memoryItem) error) (SnapshotStats, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return SnapshotStats{}, nil
+	}
+	if err != nil {
+		return SnapshotStats{}, fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer file.Close()
+
+	return readSnapshot(bufio.NewReader(file), restore)
+}
+
+// restoreFromSnapshot 启动时按配置恢复快照，失败只记录日志，缓存从空开始
+func restoreFromSnapshot(path string, restore func(item memoryItem) error) {
+	ctx := context.Background()
+	stats, err := loadSnapshotFile(path, restore)
+	if err != nil {
+		logger.Warn(ctx, "Failed to restore memory cache snapshot",
+			logger.String("path", path),
+			logger.Err(err))
+		return
+	}
+	if stats.Entries > 0 || stats.Skipped > 0 {
+		logger.Info(ctx, "Memory cache snapshot restored",
+			logger.String("path", path),
+			logger.Int("entries", stats.Entries),
+			logger.Int("skipped", stats.Skipped))
+	}
+}
+
+// WriteSnapshot 将当前未过期的条目导出到w
+func (m *MemoryCache) WriteSnapshot(w io.Writer) (SnapshotStats, error) {
+	return writeSnapshot(w, m.items())
+}
+
+// ReadSnapshot 从r恢复条目，保留剩余过期时间和标签，已有的同名键会被覆盖
+func (m *MemoryCache) ReadSnapshot(r io.Reader) (SnapshotStats, error) {
+	return readSnapshot(r, m.restoreItem)
+}
+
+// SaveSnapshot 将当前条目导出到快照文件
+func (m *MemoryCache) SaveSnapshot(path string) (SnapshotStats, error) {
+	return saveSnapshotFile(path, m.items())
+}
+
+// LoadSnapshot 从快照文件恢复条目，文件不存在时返回空结果
+func (m *MemoryCache) LoadSnapshot(path string) (SnapshotStats, error) {
+	return loadSnapshotFile(path, m.restoreItem)
+}
+
+// restoreItem 按剩余过期时间写入条目
+func (m *MemoryCache) restoreItem(item memoryItem) error {
+	var ttl time.Duration
+	if !item.expiresAt.IsZero() {
+		ttl = time.Until(item.expiresAt)
+		if ttl <= 0 {
+			return nil
+		}
+	}
+	return m.SetWithTags(context.Background(), item.key, item.value, ttl, item.tags...)
+}
+
+// items 当前条目的快照，LRU策略按从旧到新的顺序返回以便恢复后保持访问顺序
+func (m *MemoryCache) items() []memoryItem {
+	var items []memoryItem
+	if m.store != nil {
+		items = m.store.snapshot()
+	} else {
+		switch m.config.EvictionPolicy {
+		case EvictionPolicyLRU:
+			if m.lruCache != nil {
+				for _, key := range m.lruCache.Keys() {
+					if value, ok := m.lruCache.Peek(key); ok {
+						items = append(items, memoryItem{key: key, value: value})
+					}
+				}
+			}
+		case EvictionPolicyTTL, EvictionPolicySimple:
+			if m.goCache != nil {
+				for key, item := range m.goCache.Items() {
+					entry := memoryItem{key: key, value: item.Object}
+					if item.Expiration > 0 {
+						entry.expiresAt = time.Unix(0, item.Expiration)
+					}
+					items = append(items, entry)
+				}
+			}
+		}
+	}
+
+	for i := range items {
+		items[i].tags = m.tags.tagsOf(items[i].key)
+	}
+	return items
+}
+
+// snapshot 当前条目的快照
+func (s *policyStore) snapshot() []memoryItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := make([]memoryItem, 0, len(s.items))
+	for _, e := range s.items {
+		items = append(items, memoryItem{key: e.key, value: e.value, expiresAt: e.expiresAt})
+	}
+	return items
+}
+
+// tagsOf 键关联的标签
+func (t *tagIndex) tagsOf(key string) []string {
+	if !t.used.Load() {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.keyTags[key]) == 0 {
+		return nil
+	}
+	tags := make([]string, 0, len(t.keyTags[key]))
+	for tag := range t.keyTags[key] {
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// WriteSnapshot 将所有分片的条目导出到w
+func (s *ShardedMemoryCache) WriteSnapshot(w io.Writer) (SnapshotStats, error) {
+	return writeSnapshot(w, s.items())
+}
+
+// ReadSnapshot 从r恢复条目，条目按键重新分配到分片，分片数可以与导出时不同
+func (s *ShardedMemoryCache) ReadSnapshot(r io.Reader) (SnapshotStats, error) {
+	return readSnapshot(r, s.restoreItem)
+}
+
+// SaveSnapshot 将所有分片的条目导出到快照文件
+func (s *ShardedMemoryCache) SaveSnapshot(path string) (SnapshotStats, error) {
+	return saveSnapshotFile(path, s.items())
+}
+
+// LoadSnapshot 从快照文件恢复条目，文件不存在时返回空结果
+func (s *ShardedMemoryCache) LoadSnapshot(path string) (SnapshotStats, error) {
+	return loadSnapshotFile(path, s.restoreItem)
+}
+
+func (s *ShardedMemoryCache) restoreItem(item memoryItem) error {
+	return s.shard(item.key).restoreItem(item)
+}
+
+func (s *ShardedMemoryCache) items() []memoryItem {
+	var items []memoryItem
+	for _, shard := range s.shards {
+		items = append(items, shard.items()...)
+	}
+	return items
+}
@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// tagIndex 内存缓存的标签索引
+type tagIndex struct {
+	mu      sync.Mutex
+	used    atomic.Bool                    // 是否写入过标签，未使用时淘汰回调跳过加锁
+	keys    map[string]map[string]struct{} // 标签 -> 键
+	keyTags map[string]map[string]struct{} // 键 -> 标签
+}
+
+func newTagIndex() *tagIndex {
+	return &tagIndex{
+		keys:    make(map[string]map[string]struct{}),
+		keyTags: make(map[string]map[string]struct{}),
+	}
+}
+
+// replace 将键的标签替换为tags，tags为空时清除键的所有标签
+func (t *tagIndex) replace(key string, tags []string) {
+	if len(tags) == 0 {
+		t.remove(key)
+		return
+	}
+	t.used.Store(true)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.removeLocked(key)
+	keyTags := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		keys, ok := t.keys[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			t.keys[tag] = keys
+		}
+		keys[key] = struct{}{}
+		keyTags[tag] = struct{}{}
+	}
+	t.keyTags[key] = keyTags
+}
+
+// remove 移除键的所有标签关联
+func (t *tagIndex) remove(key string) {
+	if !t.used.Load() {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.removeLocked(key)
+}
+
+// removeLocked 移除键的所有标签关联（调用方需持有t.mu）
+func (t *tagIndex) removeLocked(key string) {
+	for tag := range t.keyTags[key] {
+		keys := t.keys[tag]
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(t.keys, tag)
+		}
+	}
+	delete(t.keyTags, key)
+}
+
+// members 关联了标签的键
+func (t *tagIndex) members(tag string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	keys := make([]string, 0, len(t.keys[tag]))
+	for key := range t.keys[tag] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// clear 清空索引
+func (t *tagIndex) clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.keys = make(map[string]map[string]struct{})
+	t.keyTags = make(map[string]map[string]struct{})
+}
+
+// SetWithTags 设置值并关联标签，替换键原有的标签
+func (m *MemoryCache) SetWithTags(ctx context.Context, key string, value interface{}, expiration time.Duration, tags ...string) error {
+	if err := m.setValue(key, value, expiration); err != nil {
+		return err
+	}
+	m.tags.replace(key, tags)
+	return nil
+}
+
+// InvalidateTag 删除关联了该标签的所有键
+func (m *MemoryCache) InvalidateTag(ctx context.Context, tag string) error {
+	for _, key := range m.tags.members(tag) {
+		if err := m.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TagKeys 关联了该标签的键
+func (m *MemoryCache) TagKeys(ctx context.Context, tag string) ([]string, error) {
+	return m.tags.members(tag), nil
+}
+
+// DeletePrefix 删除所有以prefix开头的键
+func (m *MemoryCache) DeletePrefix(ctx context.Context, prefix string) error {
+	for _, key := range m.keys() {
+		if strings.HasPrefix(key, prefix) {
+			if err := m.Delete(ctx, key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// keys 当前所有键的快照
+func (m *MemoryCache) keys() []string {
+	if m.store != nil {
+		return m.store.keys()
+	}
+
+	switch m.config.EvictionPolicy {
+	case EvictionPolicyLRU:
+		if m.lruCache != nil {
+			return m.lruCache.Keys()
+		}
+	case EvictionPolicyTTL, EvictionPolicySimple:
+		if m.goCache != nil {
+			items := m.goCache.Items()
+			keys := make([]string, 0, len(items))
+			for key := range items {
+				keys = append(keys, key)
+			}
+			return keys
+		}
+	}
+	return nil
+}
+
+// SetWithTags 设置值并关联标签
+func (s *ShardedMemoryCache) SetWithTags(ctx context.Context, key string, value interface{}, expiration time.Duration, tags ...string) error {
+	return s.shard(key).SetWithTags(ctx, key, value, expiration, tags...)
+}
+
+// InvalidateTag 在所有分片上删除关联了该标签的键
+func (s *ShardedMemoryCache) InvalidateTag(ctx context.Context, tag string) error {
+	for _, shard := range s.shards {
+		if err := shard.InvalidateTag(ctx, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TagKeys 汇总所有分片中关联了该标签的键
+func (s *ShardedMemoryCache) TagKeys(ctx context.Context, tag string) ([]string, error) {
+	var keys []string
+	for _, shard := range s.shards {
+		shardKeys, err := shard.TagKeys(ctx, tag)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, shardKeys...)
+	}
+	return keys, nil
+}
+
+// DeletePrefix 在所有分片上删除以prefix开头的键
+func (s *ShardedMemoryCache) DeletePrefix(ctx context.Context, prefix string) error {
+	for _, shard := range s.shards {
+		if err := shard.DeletePrefix(ctx, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
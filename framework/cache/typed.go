@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// batchGetter 支持批量读取的缓存（SimpleRedisCache、HybridCache）
+type batchGetter interface {
+	MGet(ctx context.Context, keys []string) (map[string]interface{}, error)
+}
+
+// batchSetter 支持批量写入的缓存
+type batchSetter interface {
+	MSet(ctx context.Context, keyValues map[string]interface{}, expiration time.Duration) error
+}
+
+// TypedCache 泛型类型安全缓存
+//
+// 值统一经 Codec 编码为字节后写入底层缓存，读取时解码为新的 T，
+// 因此内存、Redis、混合缓存的行为一致：调用方拿到的永远是副本，不会共享内存缓存中的对象。
+type TypedCache[T any] struct {
+	cache Cache
+	codec Codec
+}
+
+// NewTypedCache 创建泛型缓存，codec为nil时使用JSON
+func NewTypedCache[T any](cache Cache, codec Codec) *TypedCache[T] {
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+	return &TypedCache[T]{
+		cache: cache,
+		codec: codec,
+	}
+}
+
+// Cache 底层缓存
+func (t *TypedCache[T]) Cache() Cache {
+	return t.cache
+}
+
+// Codec 编解码器
+func (t *TypedCache[T]) Codec() Codec {
+	return t.codec
+}
+
+// Get 获取值，键不存在时返回 ErrKeyNotFound
+func (t *TypedCache[T]) Get(ctx context.Context, key string) (T, error) {
+	var zero T
+	raw, err := t.cache.Get(ctx, key)
+	if err != nil {
+		return zero, err
+	}
+	return t.decode(key, raw)
+}
+
+// Set 设置值
+func (t *TypedCache[T]) Set(ctx context.Context, key string, value T, expiration time.Duration) error {
+	data, err := t.encode(key, value)
+	if err != nil {
+		return err
+	}
+	return t.cache.Set(ctx, key, data, expiration)
+}
+
+// Delete 删除键
+func (t *TypedCache[T]) Delete(ctx context.Context, key string) error {
+	return t.cache.Delete(ctx, key)
+}
+
+// Exists 检查键是否存在
+func (t *TypedCache[T]) Exists(ctx context.Context, key string) (bool, error) {
+	return t.cache.Exists(ctx, key)
+}
+
+// MGet 批量获取，结果只包含存在的键；底层缓存不支持批量读取时逐个获取
+func (t *TypedCache[T]) MGet(ctx context.Context, keys []string) (map[string]T, error) {
+	result := make(map[string]T, len(keys))
+
+	if batch, ok := t.cache.(batchGetter); ok {
+		raws, err := batch.MGet(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+		for key, raw := range raws {
+			value, err := t.decode(key, raw)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+		}
+		return result, nil
+	}
+
+	for _, key := range keys {
+		value, err := t.Get(ctx, key)
+		if err != nil {
+			if errors.Is(err, ErrKeyNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// MSet 批量设置；底层缓存不支持批量写入时逐个设置
+func (t *TypedCache[T]) MSet(ctx context.Context, values map[string]T, expiration time.Duration) error {
+	encoded := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		data, err := t.encode(key, value)
+		if err != nil {
+			return err
+		}
+		encoded[key] = data
+	}
+
+	if batch, ok := t.cache.(batchSetter); ok {
+		return batch.MSet(ctx, encoded, expiration)
+	}
+	for key, data := range encoded {
+		if err := t.cache.Set(ctx, key, data, expiration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encode 编码值
+func (t *TypedCache[T]) encode(key string, value T) ([]byte, error) {
+	data, err := t.codec.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key %s with %s codec: %w", key, t.codec.Name(), err)
+	}
+	return data, nil
+}
+
+// decode 解码底层缓存返回的值，内存缓存返回[]byte，Redis返回string
+func (t *TypedCache[T]) decode(key string, raw interface{}) (T, error) {
+	var value T
+	var data []byte
+	switch v := raw.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return value, fmt.Errorf("failed to decode key %s: unexpected cached value type %T", key, raw)
+	}
+
+	if err := t.codec.Unmarshal(data, &value); err != nil {
+		return value, fmt.Errorf("failed to decode key %s with %s codec: %w", key, t.codec.Name(), err)
+	}
+	return value, nil
+}
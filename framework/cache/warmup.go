@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WarmupLoader 预热加载函数，keys为配置中列出的键（未配置时为空，由加载函数自行决定加载哪些数据），
+// 返回要写入缓存的键值
+type WarmupLoader func(ctx context.Context, keys []string) (map[string]interface{}, error)
+
+var (
+	warmupLoaders   = make(map[string]WarmupLoader)
+	warmupLoadersMu sync.RWMutex
+)
+
+// RegisterWarmupLoader 注册预热加载函数，供配置中的 warmup.loader 引用，需要在组件初始化前注册
+func RegisterWarmupLoader(name string, loader WarmupLoader) {
+	warmupLoadersMu.Lock()
+	defer warmupLoadersMu.Unlock()
+	warmupLoaders[name] = loader
+}
+
+// GetWarmupLoader 获取已注册的预热加载函数
+func GetWarmupLoader(name string) (WarmupLoader, bool) {
+	warmupLoadersMu.RLock()
+	defer warmupLoadersMu.RUnlock()
+	loader, ok := warmupLoaders[name]
+	return loader, ok
+}
+
+// WarmupConfig 缓存预热配置，对应缓存实例 Settings 中的 warmup 项
+type WarmupConfig struct {
+	Loader    string        `json:"loader" yaml:"loader"`         // 通过 RegisterWarmupLoader 注册的加载函数名
+	Keys      []string      `json:"keys" yaml:"keys"`             // 要预热的键，为空时调用一次加载函数
+	BatchSize int           `json:"batch_size" yaml:"batch_size"` // 每次调用加载函数的键数，默认100
+	TTL       time.Duration `json:"ttl" yaml:"ttl"`               // 过期时间，为0使用缓存默认值
+	Timeout   time.Duration `json:"timeout" yaml:"timeout"`       // 预热超时时间，默认30s
+	Required  bool          `json:"required" yaml:"required"`     // 预热失败时是否中止启动
+}
+
+// ParseWarmupConfig 从缓存实例的 Settings 中解析预热配置，未配置时返回nil
+func ParseWarmupConfig(settings map[string]interface{}) (*WarmupConfig, error) {
+	raw, ok := settings["warmup"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	var wrapper struct {
+		Warmup WarmupConfig `json:"warmup"`
+	}
+	if err := decodeSettings(map[string]interface{}{"warmup": raw}, &wrapper); err != nil {
+		return nil, fmt.Errorf("invalid warmup config: %w", err)
+	}
+	config := wrapper.Warmup
+	if config.Loader == "" {
+		return nil, fmt.Errorf("invalid warmup config: loader is required")
+	}
+	return &config, nil
+}
+
+// Warmup 调用预热加载函数并写入缓存，返回写入的条目数
+func Warmup(ctx context.Context, c Cache, config WarmupConfig) (int, error) {
+	loader, ok := GetWarmupLoader(config.Loader)
+	if !ok {
+		return 0, fmt.Errorf("warmup loader %s not registered", config.Loader)
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if len(config.Keys) == 0 {
+		return warmupBatch(ctx, c, loader, nil, config.TTL)
+	}
+
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	loaded := 0
+	for start := 0; start < len(config.Keys); start += batchSize {
+		end := min(start+batchSize, len(config.Keys))
+		n, err := warmupBatch(ctx, c, loader, config.Keys[start:end], config.TTL)
+		loaded += n
+		if err != nil {
+			return loaded, err
+		}
+	}
+	return loaded, nil
+}
+
+// warmupBatch 加载一批键并写入缓存
+func warmupBatch(ctx context.Context, c Cache, loader WarmupLoader, keys []string, ttl time.Duration) (int, error) {
+	values, err := loader(ctx, keys)
+	if err != nil {
+		return 0, fmt.Errorf("warmup loader failed: %w", err)
+	}
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	if batch, ok := c.(BatchCache); ok {
+		if err := batch.MSet(ctx, values, ttl); err != nil {
+			return 0, fmt.Errorf("failed to write warmup values: %w", err)
+		}
+		return len(values), nil
+	}
+
+	for key, value := range values {
+		if err := c.Set(ctx, key, value, ttl); err != nil {
+			return 0, fmt.Errorf("failed to write warmup value %s: %w", key, err)
+		}
+	}
+	return len(values), nil
+}
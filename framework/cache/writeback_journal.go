@@ -0,0 +1,394 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// 写回日志操作类型
+const (
+	journalOpSet    = "set"
+	journalOpDelete = "delete"
+	journalOpClear  = "clear"
+	journalOpAck    = "ack" // 仅文件日志使用，记录已确认的序号
+)
+
+// 写回日志中值的编码方式
+const (
+	journalEncodingString = "string"
+	journalEncodingBytes  = "bytes"
+	journalEncodingJSON   = "json"
+)
+
+// JournalEntry 写回日志记录
+type JournalEntry struct {
+	Seq        uint64        `json:"seq"`
+	Op         string        `json:"op"`
+	Key        string        `json:"key,omitempty"`
+	Value      []byte        `json:"value,omitempty"`
+	Encoding   string        `json:"encoding,omitempty"`
+	Expiration time.Duration `json:"expiration,omitempty"`
+	Tags       []string      `json:"tags,omitempty"`
+	Timestamp  time.Time     `json:"timestamp"`
+}
+
+// WriteBackJournal 写回日志，保存尚未写入L2的记录，进程崩溃重启后重放
+//
+// 记录序号单调递增，Ack(seq) 表示 seq 及之前的记录都已写入L2，可以截断。
+type WriteBackJournal interface {
+	// Append 追加记录
+	Append(ctx context.Context, entry *JournalEntry) error
+	// Replay 按序号顺序返回所有未确认的记录
+	Replay(ctx context.Context) ([]*JournalEntry, error)
+	// Ack 确认seq及之前的记录
+	Ack(ctx context.Context, seq uint64) error
+	// Close 关闭日志
+	Close() error
+}
+
+// encodeJournalValue 编码缓存值
+func encodeJournalValue(value interface{}) ([]byte, string, error) {
+	switch v := value.(type) {
+	case string:
+		return []byte(v), journalEncodingString, nil
+	case []byte:
+		return v, journalEncodingBytes, nil
+	case encoding.BinaryMarshaler:
+		data, err := v.MarshalBinary()
+		return data, journalEncodingBytes, err
+	default:
+		data, err := json.Marshal(v)
+		return data, journalEncodingJSON, err
+	}
+}
+
+// decodeJournalValue 解码缓存值，JSON编码的值解码为通用类型
+func decodeJournalValue(data []byte, enc string) (interface{}, error) {
+	switch enc {
+	case journalEncodingString:
+		return string(data), nil
+	case journalEncodingBytes:
+		return data, nil
+	case journalEncodingJSON:
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unknown journal value encoding: %s", enc)
+	}
+}
+
+// fileJournalCompactSize 未能完全确认时，文件超过该大小后重写为只含未确认记录
+const fileJournalCompactSize = 16 << 20
+
+// FileJournal 本地追加写文件日志，每行一条JSON记录
+type FileJournal struct {
+	mu         sync.Mutex
+	path       string
+	file       *os.File
+	syncWrites bool
+	size       int64
+	lastSeq    uint64
+}
+
+// NewFileJournal 创建文件日志，syncWrites为true时每次追加都fsync（可防止机器掉电丢失）
+func NewFileJournal(path string, syncWrites bool) (*FileJournal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal %s: %w", path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to stat journal %s: %w", path, err)
+	}
+
+	return &FileJournal{
+		path:       path,
+		file:       file,
+		syncWrites: syncWrites,
+		size:       info.Size(),
+	}, nil
+}
+
+// Append 追加记录
+func (j *FileJournal) Append(_ context.Context, entry *JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.writeLocked(entry); err != nil {
+		return err
+	}
+	if entry.Seq > j.lastSeq {
+		j.lastSeq = entry.Seq
+	}
+	return nil
+}
+
+// writeLocked 写入一行记录（调用方需持有j.mu）
+func (j *FileJournal) writeLocked(entry *JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	n, err := j.file.Write(data)
+	j.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if j.syncWrites {
+		if err := j.file.Sync(); err != nil {
+			return fmt.Errorf("failed to sync journal: %w", err)
+		}
+	}
+	return nil
+}
+
+// Replay 读取所有未确认的记录，忽略崩溃时写了一半的行
+func (j *FileJournal) Replay(_ context.Context) ([]*JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries, err := j.readLocked()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.Seq > j.lastSeq {
+			j.lastSeq = entry.Seq
+		}
+	}
+	return entries, nil
+}
+
+// readLocked 读取文件中未确认的记录（调用方需持有j.mu）
+func (j *FileJournal) readLocked() ([]*JournalEntry, error) {
+	data, err := os.ReadFile(j.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal %s: %w", j.path, err)
+	}
+
+	var entries []*JournalEntry
+	var acked uint64
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		entry := &JournalEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			continue
+		}
+		if entry.Op == journalOpAck {
+			acked = max(acked, entry.Seq)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	pending := entries[:0]
+	for _, entry := range entries {
+		if entry.Seq > acked {
+			pending = append(pending, entry)
+		}
+	}
+	return pending, nil
+}
+
+// Ack 确认记录，全部确认后清空文件
+func (j *FileJournal) Ack(_ context.Context, seq uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if seq >= j.lastSeq {
+		if err := j.file.Truncate(0); err != nil {
+			return fmt.Errorf("failed to truncate journal: %w", err)
+		}
+		j.size = 0
+		return nil
+	}
+
+	if err := j.writeLocked(&JournalEntry{Seq: seq, Op: journalOpAck, Timestamp: time.Now()}); err != nil {
+		return err
+	}
+	if j.size > fileJournalCompactSize {
+		return j.compactLocked()
+	}
+	return nil
+}
+
+// compactLocked 将未确认的记录写入临时文件后替换原文件（调用方需持有j.mu）
+func (j *FileJournal) compactLocked() error {
+	entries, err := j.readLocked()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal journal entry: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write compacted journal: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return fmt.Errorf("failed to replace journal: %w", err)
+	}
+
+	file, err := os.OpenFile(j.path, os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to reopen journal: %w", err)
+	}
+	_ = j.file.Close()
+	j.file = file
+	j.size = int64(buf.Len())
+	return nil
+}
+
+// Close 关闭文件
+func (j *FileJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
+
+// redisJournalBatch Replay/Ack 每批处理的记录数
+const redisJournalBatch = 1000
+
+// streamRecord 已写入Stream的记录序号与消息ID
+type streamRecord struct {
+	seq uint64
+	id  string
+}
+
+// RedisStreamJournal 基于Redis Stream的写回日志，适合无本地持久盘的部署
+type RedisStreamJournal struct {
+	client  *redis.Client
+	stream  string
+	mu      sync.Mutex
+	records []streamRecord
+}
+
+// NewRedisStreamJournal 创建Redis Stream日志
+func NewRedisStreamJournal(client *redis.Client, stream string) *RedisStreamJournal {
+	return &RedisStreamJournal{
+		client: client,
+		stream: stream,
+	}
+}
+
+// Append 追加记录
+func (j *RedisStreamJournal) Append(ctx context.Context, entry *JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	id, err := j.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: j.stream,
+		Values: map[string]interface{}{"entry": data},
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("failed to append journal stream %s: %w", j.stream, err)
+	}
+	j.records = append(j.records, streamRecord{seq: entry.Seq, id: id})
+	return nil
+}
+
+// Replay 读取Stream中所有记录（已确认的记录已被删除）
+func (j *RedisStreamJournal) Replay(ctx context.Context) ([]*JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var entries []*JournalEntry
+	j.records = j.records[:0]
+	start := "-"
+	for {
+		messages, err := j.client.XRangeN(ctx, j.stream, start, "+", redisJournalBatch).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read journal stream %s: %w", j.stream, err)
+		}
+		for _, message := range messages {
+			raw, _ := message.Values["entry"].(string)
+			entry := &JournalEntry{}
+			if err := json.Unmarshal([]byte(raw), entry); err != nil {
+				continue
+			}
+			entries = append(entries, entry)
+			j.records = append(j.records, streamRecord{seq: entry.Seq, id: message.ID})
+		}
+		if len(messages) < redisJournalBatch {
+			return entries, nil
+		}
+		start = nextStreamID(messages[len(messages)-1].ID)
+	}
+}
+
+// Ack 删除已确认的记录
+func (j *RedisStreamJournal) Ack(ctx context.Context, seq uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	n := 0
+	for n < len(j.records) && j.records[n].seq <= seq {
+		n++
+	}
+	for start := 0; start < n; start += redisJournalBatch {
+		end := min(start+redisJournalBatch, n)
+		ids := make([]string, 0, end-start)
+		for _, record := range j.records[start:end] {
+			ids = append(ids, record.id)
+		}
+		if err := j.client.XDel(ctx, j.stream, ids...).Err(); err != nil {
+			j.records = j.records[start:]
+			return fmt.Errorf("failed to ack journal stream %s: %w", j.stream, err)
+		}
+	}
+	j.records = j.records[n:]
+	return nil
+}
+
+// Close Redis客户端由调用方管理
+func (j *RedisStreamJournal) Close() error {
+	return nil
+}
+
+// nextStreamID 紧随其后的Stream消息ID，用于分页读取（兼容不支持"("排他区间的Redis版本）
+func nextStreamID(id string) string {
+	ms, seq, found := strings.Cut(id, "-")
+	if !found {
+		return id
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return id
+	}
+	return ms + "-" + strconv.FormatUint(n+1, 10)
+}
@@ -0,0 +1,286 @@
+package idgen
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/common/idgen/idgenpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RemoteConfig 远程ID生成器配置
+type RemoteConfig struct {
+	Step             int32         `json:"step"`              // 每次申请的号段长度，0表示使用服务端业务标识的步长
+	BatchSize        int32         `json:"batch_size"`        // 服务端不支持号段时每次批量获取的ID数量
+	PreloadThreshold float64       `json:"preload_threshold"` // 当前号段使用率达到该值时预加载下一个号段
+	Timeout          time.Duration `json:"timeout"`           // 单次请求服务端的超时时间
+}
+
+// DefaultRemoteConfig 默认配置
+func DefaultRemoteConfig() *RemoteConfig {
+	return &RemoteConfig{
+		BatchSize:        1000,
+		PreloadThreshold: 0.5,
+		Timeout:          3 * time.Second,
+	}
+}
+
+// idRange 连续的ID区间 [next, max]
+type idRange struct {
+	next int64
+	max  int64
+}
+
+// remoteBuffer 单个业务标识的双缓冲区
+type remoteBuffer struct {
+	mutex   sync.Mutex
+	current []idRange     // 正在使用的区间
+	next    []idRange     // 预加载的区间
+	size    int64         // 最近一次加载的ID数量，用于计算预加载时机
+	loading chan struct{} // 非nil表示正在加载，加载完成后关闭
+	loadErr error         // 最近一次加载的错误
+}
+
+// remaining 当前区间剩余的ID数量
+func (b *remoteBuffer) remaining() int64 {
+	var n int64
+	for _, r := range b.current {
+		n += r.max - r.next + 1
+	}
+	return n
+}
+
+// take 从当前区间取出一个ID
+func (b *remoteBuffer) take() (int64, bool) {
+	for len(b.current) > 0 {
+		r := &b.current[0]
+		if r.next <= r.max {
+			id := r.next
+			r.next++
+			return id, true
+		}
+		b.current = b.current[1:]
+	}
+	return 0, false
+}
+
+// RemoteIDGenerator 通过gRPC调用ID生成服务的客户端，实现 IDGenerator 接口
+//
+// 客户端按业务标识向服务端申请号段并在本地发号，当前号段使用率达到阈值时
+// 异步预加载下一个号段，与 GormLeafIDGenerator 的双缓冲行为一致。
+// 服务端为Snowflake等不支持号段的模式时，改为批量获取ID后在本地缓冲。
+type RemoteIDGenerator struct {
+	client      idgenpb.IDGenServiceClient
+	config      *RemoteConfig
+	buffers     sync.Map    // 业务标识到 *remoteBuffer 的映射
+	noSegment   atomic.Bool // 服务端不支持号段分配
+	metrics     sync.Map    // 业务标识到 *LeafMetrics 的映射
+	closed      atomic.Bool
+	loadTimeout time.Duration
+}
+
+// NewRemoteIDGenerator 使用已建立的gRPC连接创建远程ID生成器，连接由调用方管理
+func NewRemoteIDGenerator(conn grpc.ClientConnInterface, config *RemoteConfig) *RemoteIDGenerator {
+	if config == nil {
+		config = DefaultRemoteConfig()
+	}
+	loadTimeout := config.Timeout
+	if loadTimeout <= 0 {
+		loadTimeout = 3 * time.Second
+	}
+	return &RemoteIDGenerator{
+		client:      idgenpb.NewIDGenServiceClient(conn),
+		config:      config,
+		loadTimeout: loadTimeout,
+	}
+}
+
+// NextID 获取下一个ID
+func (g *RemoteIDGenerator) NextID(ctx context.Context, bizTag string) (int64, error) {
+	if g.closed.Load() {
+		return 0, fmt.Errorf("remote id generator is closed")
+	}
+
+	metrics := g.getMetrics(bizTag)
+	metrics.IncTotalRequests()
+	id, err := g.nextID(ctx, bizTag, metrics)
+	if err != nil {
+		metrics.IncFailedRequests()
+		return 0, err
+	}
+	metrics.IncSuccessRequests()
+	return id, nil
+}
+
+// BatchNextID 批量获取ID
+func (g *RemoteIDGenerator) BatchNextID(ctx context.Context, bizTag string, count int) ([]int64, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("count must be positive")
+	}
+
+	ids := make([]int64, count)
+	for i := 0; i < count; i++ {
+		id, err := g.NextID(ctx, bizTag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ID at index %d: %w", i, err)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// nextID 从本地缓冲区取ID，缓冲区耗尽时等待加载
+func (g *RemoteIDGenerator) nextID(ctx context.Context, bizTag string, metrics *LeafMetrics) (int64, error) {
+	value, _ := g.buffers.LoadOrStore(bizTag, &remoteBuffer{})
+	buffer := value.(*remoteBuffer)
+
+	for {
+		buffer.mutex.Lock()
+		if id, ok := buffer.take(); ok {
+			// 使用率达到阈值时预加载下一个号段
+			if buffer.next == nil && buffer.loading == nil &&
+				float64(buffer.remaining()) <= float64(buffer.size)*(1-g.config.PreloadThreshold) {
+				g.startLoad(bizTag, buffer, metrics)
+			}
+			buffer.mutex.Unlock()
+			return id, nil
+		}
+
+		if buffer.next != nil {
+			buffer.current, buffer.next = buffer.next, nil
+			metrics.IncBufferSwitches()
+			buffer.mutex.Unlock()
+			continue
+		}
+
+		if buffer.loading == nil {
+			g.startLoad(bizTag, buffer, metrics)
+		}
+		loading := buffer.loading
+		buffer.mutex.Unlock()
+
+		select {
+		case <-loading:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+
+		buffer.mutex.Lock()
+		err := buffer.loadErr
+		buffer.mutex.Unlock()
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// startLoad 异步加载下一个号段（调用方需持有buffer.mutex）
+func (g *RemoteIDGenerator) startLoad(bizTag string, buffer *remoteBuffer, metrics *LeafMetrics) {
+	loading := make(chan struct{})
+	buffer.loading = loading
+	buffer.loadErr = nil
+
+	go func() {
+		// 预加载与具体请求无关，使用独立的超时上下文
+		ctx, cancel := context.WithTimeout(context.Background(), g.loadTimeout)
+		ranges, size, err := g.fetch(ctx, bizTag)
+		cancel()
+
+		buffer.mutex.Lock()
+		if err != nil {
+			buffer.loadErr = fmt.Errorf("failed to load ids for bizTag %s: %w", bizTag, err)
+		} else {
+			buffer.next = ranges
+			buffer.size = size
+			metrics.IncSegmentLoads()
+		}
+		buffer.loading = nil
+		buffer.mutex.Unlock()
+		close(loading)
+	}()
+}
+
+// fetch 向服务端申请号段，服务端不支持时改为批量获取ID
+func (g *RemoteIDGenerator) fetch(ctx context.Context, bizTag string) ([]idRange, int64, error) {
+	if !g.noSegment.Load() {
+		resp, err := g.client.NextSegment(ctx, &idgenpb.NextSegmentRequest{BizTag: bizTag, Step: g.config.Step})
+		if err == nil {
+			return []idRange{{next: resp.GetMin(), max: resp.GetMax()}}, resp.GetMax() - resp.GetMin() + 1, nil
+		}
+		if status.Code(err) != codes.Unimplemented {
+			return nil, 0, err
+		}
+		g.noSegment.Store(true)
+	}
+
+	batchSize := g.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	resp, err := g.client.BatchNextID(ctx, &idgenpb.BatchNextIDRequest{BizTag: bizTag, Count: batchSize})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 合并连续的ID以减少区间数量
+	var ranges []idRange
+	for _, id := range resp.GetIds() {
+		if n := len(ranges); n > 0 && ranges[n-1].max+1 == id {
+			ranges[n-1].max = id
+			continue
+		}
+		ranges = append(ranges, idRange{next: id, max: id})
+	}
+	return ranges, int64(len(resp.GetIds())), nil
+}
+
+// getMetrics 获取业务标识的客户端指标
+func (g *RemoteIDGenerator) getMetrics(bizTag string) *LeafMetrics {
+	value, _ := g.metrics.LoadOrStore(bizTag, NewLeafMetrics())
+	return value.(*LeafMetrics)
+}
+
+// GetMetrics 获取客户端指标
+func (g *RemoteIDGenerator) GetMetrics(bizTag string) *LeafMetrics {
+	original := g.getMetrics(bizTag)
+	metrics := &LeafMetrics{
+		TotalRequests:   atomic.LoadInt64(&original.TotalRequests),
+		SuccessRequests: atomic.LoadInt64(&original.SuccessRequests),
+		FailedRequests:  atomic.LoadInt64(&original.FailedRequests),
+		SegmentLoads:    atomic.LoadInt64(&original.SegmentLoads),
+		BufferSwitches:  atomic.LoadInt64(&original.BufferSwitches),
+		LastUpdateTime:  original.LastUpdateTime,
+	}
+	metrics.CalculateQPS()
+	return metrics
+}
+
+// GetBufferStatus 获取客户端缓冲区状态
+func (g *RemoteIDGenerator) GetBufferStatus(bizTag string) map[string]interface{} {
+	status := map[string]interface{}{"biz_tag": bizTag, "type": "remote"}
+	if value, ok := g.buffers.Load(bizTag); ok {
+		buffer := value.(*remoteBuffer)
+		buffer.mutex.Lock()
+		status["remaining"] = buffer.remaining()
+		status["next_ready"] = buffer.next != nil
+		status["loading"] = buffer.loading != nil
+		buffer.mutex.Unlock()
+	}
+	return status
+}
+
+// Client 底层gRPC客户端，用于业务标识管理等操作
+func (g *RemoteIDGenerator) Client() idgenpb.IDGenServiceClient {
+	return g.client
+}
+
+// Close 关闭生成器，未用完的ID会被丢弃
+func (g *RemoteIDGenerator) Close() error {
+	g.closed.Store(true)
+	return nil
+}
@@ -0,0 +1,212 @@
+package idgen
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// opaqueIDAlphabet base62字母表
+	opaqueIDAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// OpaqueIDLength 编码后的定长长度，62^11 > 2^64，定长避免从长度推断数值大小
+	OpaqueIDLength = 11
+	// feistelRounds Feistel置换轮数
+	feistelRounds = 8
+	// MinCodecSecretLength 编码密钥的最小长度
+	MinCodecSecretLength = 16
+)
+
+var (
+	// ErrInvalidOpaqueID 不透明ID格式错误
+	ErrInvalidOpaqueID = NewLeafError("INVALID_OPAQUE_ID", "invalid opaque id")
+	// ErrCodecNotConfigured 未配置编码密钥
+	ErrCodecNotConfigured = NewLeafError("CODEC_NOT_CONFIGURED", "id codec secret is not configured")
+	// ErrCodecSecretTooShort 编码密钥过短
+	ErrCodecSecretTooShort = NewLeafError("CODEC_SECRET_TOO_SHORT", fmt.Sprintf("id codec secret must be at least %d bytes", MinCodecSecretLength))
+)
+
+// opaqueIDIndex base62字符到数值的映射，-1表示非法字符
+var opaqueIDIndex = func() [256]int8 {
+	var index [256]int8
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(opaqueIDAlphabet); i++ {
+		index[opaqueIDAlphabet[i]] = int8(i)
+	}
+	return index
+}()
+
+// IDCodec 将int64 ID与不透明字符串相互转换
+//
+// ID先经过以AES为轮函数的64位Feistel置换，再编码为定长base62字符串。
+// 每个业务标识使用由密钥派生的独立子密钥，同一ID在不同业务标识下编码不同，
+// 相邻ID的编码结果没有规律，不知道密钥时无法推算出数值或顺序。
+// 编码只用于隐藏ID，不能代替权限校验。
+type IDCodec struct {
+	secret []byte
+	blocks sync.Map // 业务标识到 cipher.Block 的映射
+}
+
+// NewIDCodec 使用密钥创建ID编解码器，各服务实例需使用相同密钥，
+// 密钥短于 MinCodecSecretLength 时返回 ErrCodecSecretTooShort
+func NewIDCodec(secret string) (*IDCodec, error) {
+	if len(secret) < MinCodecSecretLength {
+		return nil, ErrCodecSecretTooShort
+	}
+	return &IDCodec{secret: []byte(secret)}, nil
+}
+
+// Encode 将ID编码为不透明字符串
+func (c *IDCodec) Encode(bizTag string, id int64) string {
+	return encodeBase62(c.permute(bizTag, uint64(id), false))
+}
+
+// Decode 将不透明字符串解码为ID
+func (c *IDCodec) Decode(bizTag, opaque string) (int64, error) {
+	value, ok := decodeBase62(opaque)
+	if !ok {
+		return 0, ErrInvalidOpaqueID
+	}
+	return int64(c.permute(bizTag, value, true)), nil
+}
+
+// EncodeIDs 批量编码
+func (c *IDCodec) EncodeIDs(bizTag string, ids []int64) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = c.Encode(bizTag, id)
+	}
+	return result
+}
+
+// DecodeIDs 批量解码，任一字符串非法时返回错误
+func (c *IDCodec) DecodeIDs(bizTag string, opaques []string) ([]int64, error) {
+	result := make([]int64, len(opaques))
+	for i, opaque := range opaques {
+		id, err := c.Decode(bizTag, opaque)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = id
+	}
+	return result, nil
+}
+
+// block 获取业务标识的轮函数密钥
+func (c *IDCodec) block(bizTag string) cipher.Block {
+	if block, ok := c.blocks.Load(bizTag); ok {
+		return block.(cipher.Block)
+	}
+
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(bizTag))
+	block, _ := aes.NewCipher(mac.Sum(nil)[:16]) // 16字节密钥不会出错
+
+	actual, _ := c.blocks.LoadOrStore(bizTag, block)
+	return actual.(cipher.Block)
+}
+
+// permute Feistel置换，inverse为true时执行逆置换
+func (c *IDCodec) permute(bizTag string, value uint64, inverse bool) uint64 {
+	block := c.block(bizTag)
+	left, right := uint32(value>>32), uint32(value)
+
+	var in, out [aes.BlockSize]byte
+	round := func(i int, half uint32) uint32 {
+		binary.BigEndian.PutUint32(in[0:4], half)
+		in[4] = byte(i)
+		block.Encrypt(out[:], in[:])
+		return binary.BigEndian.Uint32(out[:4])
+	}
+
+	if !inverse {
+		for i := 0; i < feistelRounds; i++ {
+			left, right = right, left^round(i, right)
+		}
+	} else {
+		for i := feistelRounds - 1; i >= 0; i-- {
+			left, right = right^round(i, left), left
+		}
+	}
+	return uint64(left)<<32 | uint64(right)
+}
+
+// encodeBase62 定长base62编码
+func encodeBase62(value uint64) string {
+	var buf [OpaqueIDLength]byte
+	for i := OpaqueIDLength - 1; i >= 0; i-- {
+		buf[i] = opaqueIDAlphabet[value%62]
+		value /= 62
+	}
+	return string(buf[:])
+}
+
+// decodeBase62 定长base62解码，超出uint64范围视为非法
+func decodeBase62(s string) (uint64, bool) {
+	if len(s) != OpaqueIDLength {
+		return 0, false
+	}
+
+	var value uint64
+	for i := 0; i < len(s); i++ {
+		digit := opaqueIDIndex[s[i]]
+		if digit < 0 {
+			return 0, false
+		}
+		hi, lo := bits.Mul64(value, 62)
+		if hi != 0 {
+			return 0, false
+		}
+		var carry uint64
+		value, carry = bits.Add64(lo, uint64(digit), 0)
+		if carry != 0 {
+			return 0, false
+		}
+	}
+	return value, true
+}
+
+// defaultIDCodec 包级编解码器，由 SetDefaultIDCodec 或 idgen.codec 配置设置，未设置时编解码返回 ErrCodecNotConfigured
+var defaultIDCodec atomic.Pointer[IDCodec]
+
+// SetDefaultIDCodec 设置 EncodeID/DecodeID 使用的编解码器
+func SetDefaultIDCodec(codec *IDCodec) {
+	if codec != nil {
+		defaultIDCodec.Store(codec)
+	}
+}
+
+// DefaultIDCodec 获取包级编解码器，未配置密钥时返回 ErrCodecNotConfigured
+func DefaultIDCodec() (*IDCodec, error) {
+	codec := defaultIDCodec.Load()
+	if codec == nil {
+		return nil, ErrCodecNotConfigured
+	}
+	return codec, nil
+}
+
+// EncodeID 使用包级编解码器将ID编码为不透明字符串
+func EncodeID(bizTag string, id int64) (string, error) {
+	codec, err := DefaultIDCodec()
+	if err != nil {
+		return "", err
+	}
+	return codec.Encode(bizTag, id), nil
+}
+
+// DecodeID 使用包级编解码器将不透明字符串解码为ID
+func DecodeID(bizTag, opaque string) (int64, error) {
+	codec, err := DefaultIDCodec()
+	if err != nil {
+		return 0, err
+	}
+	return codec.Decode(bizTag, opaque)
+}
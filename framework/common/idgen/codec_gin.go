@@ -0,0 +1,101 @@
+package idgen
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BizTagger 为 OpaqueID 提供业务标识
+//
+//	type OrderTag struct{}
+//	func (OrderTag) BizTag() string { return "order" }
+type BizTagger interface {
+	BizTag() string
+}
+
+// OpaqueID 在JSON和请求参数中以不透明字符串表示的ID
+//
+// 作为请求或响应结构体字段时自动编解码，gin 的 ShouldBindJSON、ShouldBindUri、
+// ShouldBindQuery 均可直接绑定：
+//
+//	type OrderResponse struct {
+//		ID idgen.OpaqueID[OrderTag] `json:"id" uri:"id"`
+//	}
+type OpaqueID[T BizTagger] int64
+
+// Int64 原始ID
+func (id OpaqueID[T]) Int64() int64 {
+	return int64(id)
+}
+
+// String 编码后的字符串，未配置编码密钥时返回空字符串
+func (id OpaqueID[T]) String() string {
+	var tag T
+	opaque, _ := EncodeID(tag.BizTag(), int64(id))
+	return opaque
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (id OpaqueID[T]) MarshalText() ([]byte, error) {
+	var tag T
+	opaque, err := EncodeID(tag.BizTag(), int64(id))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(opaque), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (id *OpaqueID[T]) UnmarshalText(text []byte) error {
+	var tag T
+	value, err := DecodeID(tag.BizTag(), string(text))
+	if err != nil {
+		return err
+	}
+	*id = OpaqueID[T](value)
+	return nil
+}
+
+// UnmarshalParam 实现 gin 的 binding.BindUnmarshaler，用于uri/query/form绑定
+func (id *OpaqueID[T]) UnmarshalParam(param string) error {
+	return id.UnmarshalText([]byte(param))
+}
+
+// ParamID 解码路径参数中的不透明ID
+func ParamID(c *gin.Context, name, bizTag string) (int64, error) {
+	id, err := DecodeID(bizTag, c.Param(name))
+	if err != nil {
+		return 0, fmt.Errorf("invalid path parameter %s: %w", name, err)
+	}
+	return id, nil
+}
+
+// QueryID 解码查询参数中的不透明ID
+func QueryID(c *gin.Context, name, bizTag string) (int64, error) {
+	id, err := DecodeID(bizTag, c.Query(name))
+	if err != nil {
+		return 0, fmt.Errorf("invalid query parameter %s: %w", name, err)
+	}
+	return id, nil
+}
+
+// DecodeParamID 返回解码路径参数的中间件，解码后的ID以同名键存入上下文，
+// 处理函数通过 c.GetInt64(name) 读取；格式错误时返回400，未配置编码密钥时返回500
+func DecodeParamID(name, bizTag string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := ParamID(c, name, bizTag)
+		if errors.Is(err, ErrCodecNotConfigured) {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "ID codec is not configured"})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name})
+			return
+		}
+		c.Set(name, id)
+		c.Next()
+	}
+}
@@ -0,0 +1,50 @@
+package idgen
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DecodeIDField 解码gRPC请求中的不透明ID字段，格式错误时返回 InvalidArgument 状态错误，
+// 未配置编码密钥时返回 FailedPrecondition 状态错误
+//
+//	orderID, err := idgen.DecodeIDField("order", "order_id", req.GetOrderId())
+func DecodeIDField(bizTag, field, value string) (int64, error) {
+	id, err := DecodeID(bizTag, value)
+	if err != nil {
+		return 0, fieldError(field, err)
+	}
+	return id, nil
+}
+
+// DecodeIDsField 解码gRPC请求中的不透明ID列表字段，格式错误时返回 InvalidArgument 状态错误
+func DecodeIDsField(bizTag, field string, values []string) ([]int64, error) {
+	codec, err := DefaultIDCodec()
+	if err != nil {
+		return nil, fieldError(field, err)
+	}
+	ids, err := codec.DecodeIDs(bizTag, values)
+	if err != nil {
+		return nil, fieldError(field, err)
+	}
+	return ids, nil
+}
+
+// EncodeIDsField 将ID列表编码为gRPC响应中的不透明ID列表字段
+func EncodeIDsField(bizTag string, ids []int64) ([]string, error) {
+	codec, err := DefaultIDCodec()
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return codec.EncodeIDs(bizTag, ids), nil
+}
+
+// fieldError 将解码错误转换为gRPC状态错误
+func fieldError(field string, err error) error {
+	if errors.Is(err, ErrCodecNotConfigured) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Errorf(codes.InvalidArgument, "invalid %s: %v", field, err)
+}
@@ -0,0 +1,125 @@
+package idgen
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type orderTag struct{}
+
+func (orderTag) BizTag() string { return "order" }
+
+const testCodecSecret = "test-secret-0123456789"
+
+// newTestCodec 创建测试用编解码器
+func newTestCodec(t *testing.T, secret string) *IDCodec {
+	codec, err := NewIDCodec(secret)
+	if err != nil {
+		t.Fatalf("Failed to create codec: %v", err)
+	}
+	return codec
+}
+
+func TestIDCodec_RoundTrip(t *testing.T) {
+	codec := newTestCodec(t, testCodecSecret)
+
+	seen := make(map[string]bool)
+	for _, id := range []int64{0, 1, 2, 3, 1000, 1001, math.MaxInt64, -1, math.MinInt64} {
+		opaque := codec.Encode("order", id)
+		if len(opaque) != OpaqueIDLength {
+			t.Errorf("Expected length %d, got %q", OpaqueIDLength, opaque)
+		}
+		if seen[opaque] {
+			t.Errorf("Duplicate encoding %q", opaque)
+		}
+		seen[opaque] = true
+
+		decoded, err := codec.Decode("order", opaque)
+		if err != nil || decoded != id {
+			t.Errorf("Expected %d, got %d (%v)", id, decoded, err)
+		}
+	}
+
+	if codec.Encode("order", 1) == codec.Encode("user", 1) {
+		t.Error("Expected different encodings for different biz tags")
+	}
+	if codec.Encode("order", 1) == newTestCodec(t, "other-secret-0123456789").Encode("order", 1) {
+		t.Error("Expected different encodings for different secrets")
+	}
+
+	for _, invalid := range []string{"", "short", "0000000000!", "zzzzzzzzzzz"} {
+		if _, err := codec.Decode("order", invalid); !errors.Is(err, ErrInvalidOpaqueID) {
+			t.Errorf("Expected ErrInvalidOpaqueID for %q, got %v", invalid, err)
+		}
+	}
+}
+
+func TestIDCodec_FailClosed(t *testing.T) {
+	if _, err := NewIDCodec("short"); !errors.Is(err, ErrCodecSecretTooShort) {
+		t.Errorf("Expected ErrCodecSecretTooShort, got %v", err)
+	}
+
+	previous := defaultIDCodec.Swap(nil)
+	defer defaultIDCodec.Store(previous)
+
+	if _, err := EncodeID("order", 1); !errors.Is(err, ErrCodecNotConfigured) {
+		t.Errorf("Expected ErrCodecNotConfigured from EncodeID, got %v", err)
+	}
+	if _, err := DecodeID("order", "00000000000"); !errors.Is(err, ErrCodecNotConfigured) {
+		t.Errorf("Expected ErrCodecNotConfigured from DecodeID, got %v", err)
+	}
+	if _, err := json.Marshal(struct{ ID OpaqueID[orderTag] }{ID: 1}); err == nil {
+		t.Error("Expected marshal error without codec secret")
+	}
+}
+
+func TestOpaqueID_Binding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	previous := defaultIDCodec.Swap(newTestCodec(t, testCodecSecret))
+	defer defaultIDCodec.Store(previous)
+
+	type orderResponse struct {
+		ID OpaqueID[orderTag] `json:"id" uri:"id"`
+	}
+
+	data, err := json.Marshal(orderResponse{ID: 42})
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	var resp orderResponse
+	if err := json.Unmarshal(data, &resp); err != nil || resp.ID.Int64() != 42 {
+		t.Fatalf("Expected 42 after JSON round trip of %s, got %d (%v)", data, resp.ID, err)
+	}
+
+	engine := gin.New()
+	engine.GET("/orders/:id", func(c *gin.Context) {
+		var req orderResponse
+		if err := c.ShouldBindUri(&req); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.JSON(http.StatusOK, req)
+	})
+
+	recorder := httptest.NewRecorder()
+	opaque, err := EncodeID("order", 42)
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orders/"+opaque, nil))
+	if recorder.Code != http.StatusOK || recorder.Body.String() != string(data) {
+		t.Errorf("Expected %s, got %d %s", data, recorder.Code, recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orders/42", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for raw ID, got %d", recorder.Code)
+	}
+}
@@ -1,8 +1,9 @@
 package idgen
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"github.com/go-viper/mapstructure/v2"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
@@ -12,10 +13,11 @@ import (
 )
 
 type Config struct {
-	Type     string                 `json:"type" yaml:"type"`
-	Database *DatabaseConfig        `json:"database" yaml:"database"`
-	Leaf     *LeafConfig            `json:"leaf" yaml:"leaf"`
-	Settings map[string]interface{} `json:"settings" yaml:"settings"`
+	Type      string                 `json:"type" yaml:"type"`
+	Database  *DatabaseConfig        `json:"database" yaml:"database"`
+	Leaf      *LeafConfig            `json:"leaf" yaml:"leaf"`
+	Snowflake *SnowflakeConfig       `json:"snowflake" yaml:"snowflake"`
+	Settings  map[string]interface{} `json:"settings" yaml:"settings"`
 }
 
 type DatabaseConfig struct {
@@ -71,6 +73,11 @@ func (c *DatabaseConfig) GetLogLevel() logger.LogLevel {
 }
 
 func NewIDGeneratorFromConfig(config Config) (IDGenerator, error) {
+	// Snowflake不依赖数据库
+	if config.Type == "snowflake" {
+		return newSnowflakeFromConfig(config)
+	}
+
 	if config.Database == nil {
 		return nil, fmt.Errorf("database config is required")
 	}
@@ -104,10 +111,8 @@ func NewIDGeneratorFromConfig(config Config) (IDGenerator, error) {
 	}
 
 	// 从settings中解析配置
-	if config.Settings != nil {
-		if data, err := json.Marshal(config.Settings); err == nil {
-			_ = json.Unmarshal(data, leafConfig)
-		}
+	if err := decodeSettings(config.Settings, leafConfig); err != nil {
+		return nil, fmt.Errorf("invalid leaf settings: %w", err)
 	}
 
 	switch config.Type {
@@ -118,6 +123,47 @@ func NewIDGeneratorFromConfig(config Config) (IDGenerator, error) {
 	}
 }
 
+// newSnowflakeFromConfig 根据配置创建Snowflake生成器
+func newSnowflakeFromConfig(config Config) (IDGenerator, error) {
+	snowflakeConfig := config.Snowflake
+	if snowflakeConfig == nil {
+		snowflakeConfig = DefaultSnowflakeConfig()
+	}
+
+	// 从settings中解析配置
+	if err := decodeSettings(config.Settings, snowflakeConfig); err != nil {
+		return nil, fmt.Errorf("invalid snowflake settings: %w", err)
+	}
+
+	assigner, err := NewWorkerIDAssigner(snowflakeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worker id assigner: %w", err)
+	}
+
+	return NewSnowflakeIDGenerator(context.Background(), snowflakeConfig, assigner)
+}
+
+// decodeSettings 按json标签将settings解码到配置结构体，与框架配置一致，
+// 时长支持 "30s" 形式的字符串，时间支持RFC3339字符串
+func decodeSettings(settings map[string]interface{}, out interface{}) error {
+	if settings == nil {
+		return nil
+	}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		TagName:          "json",
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToTimeHookFunc(time.RFC3339),
+		),
+		Result: out,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(settings)
+}
+
 func createGormDB(config *DatabaseConfig) (*gorm.DB, error) {
 	dsn := config.BuildDSN()
 	if dsn == "" {
@@ -242,6 +288,13 @@ func (b *ConfigBuilder) WithDSN(driver, dsn string) *ConfigBuilder {
 	return b
 }
 
+// WithSnowflakeConfig 设置Snowflake配置
+func (b *ConfigBuilder) WithSnowflakeConfig(snowflakeConfig *SnowflakeConfig) *ConfigBuilder {
+	b.config.Type = "snowflake"
+	b.config.Snowflake = snowflakeConfig
+	return b
+}
+
 // WithLeafConfig 设置Leaf配置
 func (b *ConfigBuilder) WithLeafConfig(leafConfig *LeafConfig) *ConfigBuilder {
 	b.config.Leaf = leafConfig
@@ -39,6 +39,13 @@ type LeafDAO interface {
 	CreateTable(ctx context.Context) error
 }
 
+// 号段存储类型
+const (
+	LeafStorageDatabase = "database" // 关系型数据库（GORM）
+	LeafStorageRedis    = "redis"    // Redis哈希，HINCRBY分配号段
+	LeafStorageEtcd     = "etcd"     // etcd事务
+)
+
 // GormLeafDAO GORM实现的数据访问对象
 type GormLeafDAO struct {
 	db *gorm.DB
@@ -0,0 +1,213 @@
+package idgen
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/pkg/etcd"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// etcdMaxTxnRetries 号段更新乐观锁冲突时的最大重试次数
+	etcdMaxTxnRetries = 16
+	// etcdMaxTxnOps 单个事务的最大操作数，与etcd服务端默认的 --max-txn-ops 一致
+	etcdMaxTxnOps = 128
+)
+
+// EtcdLeafDAO 基于etcd事务的号段存储
+//
+// 每个业务标识保存为一个JSON值，更新时以ModRevision比较实现乐观锁，
+// 冲突时重新读取后重试。
+type EtcdLeafDAO struct {
+	client    *etcd.Client
+	keyPrefix string
+}
+
+// NewEtcdLeafDAO 创建etcd号段存储
+func NewEtcdLeafDAO(client *etcd.Client, keyPrefix string) LeafDAO {
+	if keyPrefix == "" {
+		keyPrefix = DefaultLeafKeyPrefix
+	}
+	return &EtcdLeafDAO{client: client, keyPrefix: keyPrefix}
+}
+
+// allocKey 业务标识对应的键
+func (dao *EtcdLeafDAO) allocKey(bizTag string) string {
+	return dao.keyPrefix + bizTag
+}
+
+// get 读取记录及其ModRevision
+func (dao *EtcdLeafDAO) get(ctx context.Context, bizTag string) (*LeafAlloc, int64, error) {
+	resp, err := dao.client.GetClient().Get(ctx, dao.allocKey(bizTag))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get leaf alloc: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, 0, ErrBizTagNotFound
+	}
+
+	var leafAlloc LeafAlloc
+	if err := json.Unmarshal(resp.Kvs[0].Value, &leafAlloc); err != nil {
+		return nil, 0, fmt.Errorf("invalid leaf alloc for biz tag %s: %w", bizTag, err)
+	}
+	return &leafAlloc, resp.Kvs[0].ModRevision, nil
+}
+
+// update 以ModRevision比较写回修改后的记录，冲突时重试
+func (dao *EtcdLeafDAO) update(ctx context.Context, bizTag string, mutate func(leafAlloc *LeafAlloc)) (*LeafAlloc, error) {
+	key := dao.allocKey(bizTag)
+	for i := 0; i < etcdMaxTxnRetries; i++ {
+		leafAlloc, revision, err := dao.get(ctx, bizTag)
+		if err != nil {
+			return nil, err
+		}
+
+		mutate(leafAlloc)
+		leafAlloc.UpdateTime = time.Now()
+		data, err := json.Marshal(leafAlloc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal leaf alloc: %w", err)
+		}
+
+		resp, err := dao.client.Transaction(ctx,
+			[]clientv3.Cmp{clientv3.Compare(clientv3.ModRevision(key), "=", revision)},
+			[]clientv3.Op{clientv3.OpPut(key, string(data))},
+			nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Succeeded {
+			return leafAlloc, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to update leaf alloc %s: too many concurrent updates", bizTag)
+}
+
+// GetLeafAlloc 获取叶子分配记录
+func (dao *EtcdLeafDAO) GetLeafAlloc(ctx context.Context, bizTag string) (*LeafAlloc, error) {
+	leafAlloc, _, err := dao.get(ctx, bizTag)
+	return leafAlloc, err
+}
+
+// UpdateMaxID 原子性更新最大ID并返回新值
+func (dao *EtcdLeafDAO) UpdateMaxID(ctx context.Context, bizTag string, step int32) (*LeafAlloc, error) {
+	leafAlloc, err := dao.update(ctx, bizTag, func(leafAlloc *LeafAlloc) {
+		leafAlloc.MaxID += int64(step)
+	})
+	if err != nil && !errors.Is(err, ErrBizTagNotFound) {
+		return nil, fmt.Errorf("failed to update max_id: %w", err)
+	}
+	return leafAlloc, err
+}
+
+// CreateLeafAlloc 创建新的业务标识，已存在时返回 ErrBizTagExists
+func (dao *EtcdLeafDAO) CreateLeafAlloc(ctx context.Context, bizTag string, step int32, description string) error {
+	data, err := json.Marshal(&LeafAlloc{
+		BizTag:      bizTag,
+		MaxID:       0,
+		Step:        step,
+		Description: description,
+		UpdateTime:  time.Now(),
+		AutoClean:   0,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal leaf alloc: %w", err)
+	}
+
+	key := dao.allocKey(bizTag)
+	resp, err := dao.client.Transaction(ctx,
+		[]clientv3.Cmp{clientv3.Compare(clientv3.CreateRevision(key), "=", 0)},
+		[]clientv3.Op{clientv3.OpPut(key, string(data))},
+		nil)
+	if err != nil {
+		return fmt.Errorf("failed to create leaf alloc: %w", err)
+	}
+	if !resp.Succeeded {
+		return ErrBizTagExists
+	}
+	return nil
+}
+
+// GetAllBizTags 获取所有业务标识
+func (dao *EtcdLeafDAO) GetAllBizTags(ctx context.Context) ([]string, error) {
+	resp, err := dao.client.GetClient().Get(ctx, dao.keyPrefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all biz tags: %w", err)
+	}
+
+	bizTags := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		bizTags = append(bizTags, strings.TrimPrefix(string(kv.Key), dao.keyPrefix))
+	}
+	return bizTags, nil
+}
+
+// UpdateStep 更新步长
+func (dao *EtcdLeafDAO) UpdateStep(ctx context.Context, bizTag string, newStep int32) error {
+	_, err := dao.update(ctx, bizTag, func(leafAlloc *LeafAlloc) {
+		leafAlloc.Step = newStep
+	})
+	if err != nil && !errors.Is(err, ErrBizTagNotFound) {
+		return fmt.Errorf("failed to update step: %w", err)
+	}
+	return err
+}
+
+// DeleteLeafAlloc 删除业务标识
+func (dao *EtcdLeafDAO) DeleteLeafAlloc(ctx context.Context, bizTag string) error {
+	resp, err := dao.client.GetClient().Delete(ctx, dao.allocKey(bizTag))
+	if err != nil {
+		return fmt.Errorf("failed to delete leaf alloc: %w", err)
+	}
+	if resp.Deleted == 0 {
+		return ErrBizTagNotFound
+	}
+	return nil
+}
+
+// GetLeafAllocWithLock 更新通过事务比较保证一致，无需加锁
+func (dao *EtcdLeafDAO) GetLeafAllocWithLock(ctx context.Context, bizTag string) (*LeafAlloc, error) {
+	return dao.GetLeafAlloc(ctx, bizTag)
+}
+
+// BatchGetLeafAllocs 批量获取叶子分配记录，不存在的业务标识被忽略
+//
+// etcd默认限制单个事务最多128个操作（--max-txn-ops），按 etcdMaxTxnOps 分批读取。
+func (dao *EtcdLeafDAO) BatchGetLeafAllocs(ctx context.Context, bizTags []string) ([]*LeafAlloc, error) {
+	leafAllocs := make([]*LeafAlloc, 0, len(bizTags))
+	for start := 0; start < len(bizTags); start += etcdMaxTxnOps {
+		chunk := bizTags[start:min(start+etcdMaxTxnOps, len(bizTags))]
+		ops := make([]clientv3.Op, len(chunk))
+		for i, bizTag := range chunk {
+			ops[i] = clientv3.OpGet(dao.allocKey(bizTag))
+		}
+
+		resp, err := dao.client.Transaction(ctx, nil, ops, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to batch get leaf allocs: %w", err)
+		}
+
+		for i, r := range resp.Responses {
+			rangeResp := r.GetResponseRange()
+			if rangeResp == nil || len(rangeResp.Kvs) == 0 {
+				continue
+			}
+			var leafAlloc LeafAlloc
+			if err := json.Unmarshal(rangeResp.Kvs[0].Value, &leafAlloc); err != nil {
+				return nil, fmt.Errorf("invalid leaf alloc for biz tag %s: %w", chunk[i], err)
+			}
+			leafAllocs = append(leafAllocs, &leafAlloc)
+		}
+	}
+	return leafAllocs, nil
+}
+
+// CreateTable etcd无需建表
+func (dao *EtcdLeafDAO) CreateTable(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,221 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// DefaultLeafKeyPrefix Redis/etcd号段存储的默认键前缀
+const DefaultLeafKeyPrefix = "idgen:leaf:"
+
+// redisLeafAllocFields 号段记录在哈希中的字段
+var redisLeafAllocFields = []string{"max_id", "step", "description", "update_time", "auto_clean"}
+
+// RedisLeafDAO 基于Redis的号段存储
+//
+// 每个业务标识保存为一个哈希，号段分配通过HINCRBY原子递增max_id，
+// 单个业务标识只涉及一个键，可以直接用于Redis Cluster。
+//
+// UpdateMaxID 返回时写入只保证到达主节点内存，主节点宕机或主从切换后max_id可能回退，
+// 已发出的号段会被重复分配。使用时Redis必须开启AOF且 appendfsync always，
+// 并避免异步复制下的故障切换；无法满足时应使用数据库或etcd存储。
+type RedisLeafDAO struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewRedisLeafDAO 创建Redis号段存储
+func NewRedisLeafDAO(client redis.UniversalClient, keyPrefix string) LeafDAO {
+	if keyPrefix == "" {
+		keyPrefix = DefaultLeafKeyPrefix
+	}
+	return &RedisLeafDAO{client: client, keyPrefix: keyPrefix}
+}
+
+// allocKey 业务标识对应的哈希键
+func (dao *RedisLeafDAO) allocKey(bizTag string) string {
+	return dao.keyPrefix + bizTag
+}
+
+// tagsKey 记录所有业务标识的集合
+func (dao *RedisLeafDAO) tagsKey() string {
+	return dao.keyPrefix + "__biz_tags__"
+}
+
+// GetLeafAlloc 获取叶子分配记录
+func (dao *RedisLeafDAO) GetLeafAlloc(ctx context.Context, bizTag string) (*LeafAlloc, error) {
+	values, err := dao.client.HMGet(ctx, dao.allocKey(bizTag), redisLeafAllocFields...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaf alloc: %w", err)
+	}
+	return parseRedisLeafAlloc(bizTag, values)
+}
+
+// UpdateMaxID 原子性递增max_id并返回新值
+func (dao *RedisLeafDAO) UpdateMaxID(ctx context.Context, bizTag string, step int32) (*LeafAlloc, error) {
+	luaScript := `
+		if redis.call("EXISTS", KEYS[1]) == 0 then
+			return false
+		end
+		redis.call("HINCRBY", KEYS[1], "max_id", ARGV[1])
+		redis.call("HSET", KEYS[1], "update_time", ARGV[2])
+		return redis.call("HMGET", KEYS[1], "max_id", "step", "description", "update_time", "auto_clean")
+	`
+
+	now := time.Now()
+	values, err := dao.client.Eval(ctx, luaScript, []string{dao.allocKey(bizTag)}, step, now.UnixMilli()).Slice()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrBizTagNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update max_id: %w", err)
+	}
+	return parseRedisLeafAlloc(bizTag, values)
+}
+
+// CreateLeafAlloc 创建新的业务标识，已存在时返回 ErrBizTagExists
+func (dao *RedisLeafDAO) CreateLeafAlloc(ctx context.Context, bizTag string, step int32, description string) error {
+	luaScript := `
+		if redis.call("EXISTS", KEYS[1]) == 1 then
+			return 0
+		end
+		redis.call("HSET", KEYS[1], "max_id", 0, "step", ARGV[1], "description", ARGV[2], "update_time", ARGV[3], "auto_clean", 0)
+		return 1
+	`
+
+	created, err := dao.client.Eval(ctx, luaScript, []string{dao.allocKey(bizTag)}, step, description, time.Now().UnixMilli()).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to create leaf alloc: %w", err)
+	}
+	// 集合只用于列出业务标识，与哈希分开写入以兼容Redis Cluster
+	if err := dao.client.SAdd(ctx, dao.tagsKey(), bizTag).Err(); err != nil {
+		return fmt.Errorf("failed to register biz tag: %w", err)
+	}
+	if created == 0 {
+		return ErrBizTagExists
+	}
+	return nil
+}
+
+// GetAllBizTags 获取所有业务标识
+func (dao *RedisLeafDAO) GetAllBizTags(ctx context.Context) ([]string, error) {
+	bizTags, err := dao.client.SMembers(ctx, dao.tagsKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all biz tags: %w", err)
+	}
+	return bizTags, nil
+}
+
+// UpdateStep 更新步长
+func (dao *RedisLeafDAO) UpdateStep(ctx context.Context, bizTag string, newStep int32) error {
+	luaScript := `
+		if redis.call("EXISTS", KEYS[1]) == 0 then
+			return 0
+		end
+		redis.call("HSET", KEYS[1], "step", ARGV[1], "update_time", ARGV[2])
+		return 1
+	`
+
+	updated, err := dao.client.Eval(ctx, luaScript, []string{dao.allocKey(bizTag)}, newStep, time.Now().UnixMilli()).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to update step: %w", err)
+	}
+	if updated == 0 {
+		return ErrBizTagNotFound
+	}
+	return nil
+}
+
+// DeleteLeafAlloc 删除业务标识
+func (dao *RedisLeafDAO) DeleteLeafAlloc(ctx context.Context, bizTag string) error {
+	deleted, err := dao.client.Del(ctx, dao.allocKey(bizTag)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to delete leaf alloc: %w", err)
+	}
+	if err := dao.client.SRem(ctx, dao.tagsKey(), bizTag).Err(); err != nil {
+		return fmt.Errorf("failed to unregister biz tag: %w", err)
+	}
+	if deleted == 0 {
+		return ErrBizTagNotFound
+	}
+	return nil
+}
+
+// GetLeafAllocWithLock 号段分配本身是原子操作，无需加锁
+func (dao *RedisLeafDAO) GetLeafAllocWithLock(ctx context.Context, bizTag string) (*LeafAlloc, error) {
+	return dao.GetLeafAlloc(ctx, bizTag)
+}
+
+// BatchGetLeafAllocs 批量获取叶子分配记录，不存在的业务标识被忽略
+func (dao *RedisLeafDAO) BatchGetLeafAllocs(ctx context.Context, bizTags []string) ([]*LeafAlloc, error) {
+	cmds := make([]*redis.SliceCmd, len(bizTags))
+	_, err := dao.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, bizTag := range bizTags {
+			cmds[i] = pipe.HMGet(ctx, dao.allocKey(bizTag), redisLeafAllocFields...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get leaf allocs: %w", err)
+	}
+
+	leafAllocs := make([]*LeafAlloc, 0, len(bizTags))
+	for i, cmd := range cmds {
+		leafAlloc, err := parseRedisLeafAlloc(bizTags[i], cmd.Val())
+		if errors.Is(err, ErrBizTagNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		leafAllocs = append(leafAllocs, leafAlloc)
+	}
+	return leafAllocs, nil
+}
+
+// CreateTable Redis无需建表
+func (dao *RedisLeafDAO) CreateTable(ctx context.Context) error {
+	return nil
+}
+
+// parseRedisLeafAlloc 解析HMGET返回的字段，按 redisLeafAllocFields 的顺序
+func parseRedisLeafAlloc(bizTag string, values []interface{}) (*LeafAlloc, error) {
+	if len(values) != len(redisLeafAllocFields) || values[0] == nil {
+		return nil, ErrBizTagNotFound
+	}
+
+	fields := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case string:
+			fields[i] = v
+		case int64:
+			fields[i] = strconv.FormatInt(v, 10)
+		}
+	}
+
+	maxID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid max_id for biz tag %s: %w", bizTag, err)
+	}
+	step, err := strconv.ParseInt(fields[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid step for biz tag %s: %w", bizTag, err)
+	}
+	updateTime, _ := strconv.ParseInt(fields[3], 10, 64)
+	autoClean, _ := strconv.ParseInt(fields[4], 10, 8)
+
+	return &LeafAlloc{
+		BizTag:      bizTag,
+		MaxID:       maxID,
+		Step:        int32(step),
+		Description: fields[2],
+		UpdateTime:  time.UnixMilli(updateTime),
+		AutoClean:   int8(autoClean),
+	}, nil
+}
@@ -0,0 +1,122 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+// newTestRedisLeafDAO 创建基于miniredis的号段存储
+func newTestRedisLeafDAO(t *testing.T) LeafDAO {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		client.Close()
+	})
+	return NewRedisLeafDAO(client, "")
+}
+
+func TestRedisLeafDAO_Errors(t *testing.T) {
+	dao := newTestRedisLeafDAO(t)
+	ctx := context.Background()
+
+	if _, err := dao.GetLeafAlloc(ctx, "order"); !errors.Is(err, ErrBizTagNotFound) {
+		t.Errorf("Expected ErrBizTagNotFound from GetLeafAlloc, got %v", err)
+	}
+	if _, err := dao.UpdateMaxID(ctx, "order", 10); !errors.Is(err, ErrBizTagNotFound) {
+		t.Errorf("Expected ErrBizTagNotFound from UpdateMaxID, got %v", err)
+	}
+	if err := dao.UpdateStep(ctx, "order", 10); !errors.Is(err, ErrBizTagNotFound) {
+		t.Errorf("Expected ErrBizTagNotFound from UpdateStep, got %v", err)
+	}
+	if err := dao.DeleteLeafAlloc(ctx, "order"); !errors.Is(err, ErrBizTagNotFound) {
+		t.Errorf("Expected ErrBizTagNotFound from DeleteLeafAlloc, got %v", err)
+	}
+
+	if err := dao.CreateLeafAlloc(ctx, "order", 10, "orders"); err != nil {
+		t.Fatalf("Failed to create leaf alloc: %v", err)
+	}
+	if err := dao.CreateLeafAlloc(ctx, "order", 20, "orders"); !errors.Is(err, ErrBizTagExists) {
+		t.Errorf("Expected ErrBizTagExists, got %v", err)
+	}
+
+	leafAlloc, err := dao.GetLeafAlloc(ctx, "order")
+	if err != nil {
+		t.Fatalf("Failed to get leaf alloc: %v", err)
+	}
+	if leafAlloc.MaxID != 0 || leafAlloc.Step != 10 || leafAlloc.Description != "orders" {
+		t.Errorf("Unexpected leaf alloc: %+v", leafAlloc)
+	}
+
+	if err := dao.UpdateStep(ctx, "order", 50); err != nil {
+		t.Fatalf("Failed to update step: %v", err)
+	}
+	leafAllocs, err := dao.BatchGetLeafAllocs(ctx, []string{"order", "missing"})
+	if err != nil {
+		t.Fatalf("Failed to batch get leaf allocs: %v", err)
+	}
+	if len(leafAllocs) != 1 || leafAllocs[0].Step != 50 {
+		t.Errorf("Expected only order with step 50, got %+v", leafAllocs)
+	}
+
+	if err := dao.DeleteLeafAlloc(ctx, "order"); err != nil {
+		t.Fatalf("Failed to delete leaf alloc: %v", err)
+	}
+	bizTags, err := dao.GetAllBizTags(ctx)
+	if err != nil || len(bizTags) != 0 {
+		t.Errorf("Expected no biz tags after delete, got %v (%v)", bizTags, err)
+	}
+}
+
+func TestRedisLeafDAO_ConcurrentUpdateMaxID(t *testing.T) {
+	dao := newTestRedisLeafDAO(t)
+	ctx := context.Background()
+
+	const (
+		step       = 10
+		workers    = 8
+		iterations = 50
+	)
+	if err := dao.CreateLeafAlloc(ctx, "order", step, ""); err != nil {
+		t.Fatalf("Failed to create leaf alloc: %v", err)
+	}
+
+	var (
+		mutex  sync.Mutex
+		wg     sync.WaitGroup
+		maxIDs []int64
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				leafAlloc, err := dao.UpdateMaxID(ctx, "order", step)
+				if err != nil {
+					t.Errorf("Failed to update max_id: %v", err)
+					return
+				}
+				mutex.Lock()
+				maxIDs = append(maxIDs, leafAlloc.MaxID)
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 每次分配得到 (MaxID-step, MaxID]，各号段的上界应恰好为step的连续倍数
+	sort.Slice(maxIDs, func(i, j int) bool { return maxIDs[i] < maxIDs[j] })
+	if len(maxIDs) != workers*iterations {
+		t.Fatalf("Expected %d segments, got %d", workers*iterations, len(maxIDs))
+	}
+	for i, maxID := range maxIDs {
+		if want := int64((i + 1) * step); maxID != want {
+			t.Fatalf("Expected segment %d to end at %d, got %d", i, want, maxID)
+		}
+	}
+}
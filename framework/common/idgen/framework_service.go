@@ -8,6 +8,7 @@ import (
 
 	"github.com/qiaojinxia/distributed-service/framework/config"
 	"github.com/qiaojinxia/distributed-service/framework/database"
+	"github.com/qiaojinxia/distributed-service/pkg/etcd"
 	"gorm.io/gorm"
 )
 
@@ -32,24 +33,24 @@ func (s *FrameworkIDGenService) Initialize(ctx context.Context) error {
 
 	s.config = &config.GlobalConfig.IDGen
 
-	// 获取数据库连接
-	if s.config.UseFramework {
-		// 使用框架数据库
-		if database.DB == nil {
-			return fmt.Errorf("framework database not initialized")
-		}
-		s.db = database.DB
-	} else {
-		// 使用自定义数据库配置
-		db, err := s.createCustomDB()
+	// 未配置密钥时不设置包级编解码器，EncodeID/DecodeID 返回 ErrCodecNotConfigured
+	if s.config.Codec.Secret != "" {
+		codec, err := NewIDCodec(s.config.Codec.Secret)
 		if err != nil {
-			return fmt.Errorf("failed to create custom database connection: %w", err)
+			return fmt.Errorf("invalid idgen codec config: %w", err)
+		}
+		SetDefaultIDCodec(codec)
+	}
+
+	// 获取数据库连接（Snowflake、128位有序ID及Redis/etcd号段存储无需数据库）
+	if s.config.Type != "snowflake" && !isKSortableType(s.config.Type) && s.leafStorage() == LeafStorageDatabase {
+		if err := s.initDB(); err != nil {
+			return err
 		}
-		s.db = db
 	}
 
 	// 创建ID生成器
-	generator, err := s.createGenerator()
+	generator, err := s.createGenerator(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create ID generator: %w", err)
 	}
@@ -98,6 +99,56 @@ func (s *FrameworkIDGenService) BatchNextID(ctx context.Context, bizTag string,
 	return s.generator.BatchNextID(ctx, bizTag, count)
 }
 
+// NextID128 获取下一个128位有序ID，仅 type 为 ksortable/ulid/uuidv7 时可用，否则返回 ErrNotSupported
+func (s *FrameworkIDGenService) NextID128(ctx context.Context, bizTag string) (ID128, error) {
+	generator, err := s.id128Generator()
+	if err != nil {
+		return ID128{}, err
+	}
+	return generator.NextID128(ctx, bizTag)
+}
+
+// BatchNextID128 批量获取128位有序ID，仅 type 为 ksortable/ulid/uuidv7 时可用，否则返回 ErrNotSupported
+func (s *FrameworkIDGenService) BatchNextID128(ctx context.Context, bizTag string, count int) ([]ID128, error) {
+	generator, err := s.id128Generator()
+	if err != nil {
+		return nil, err
+	}
+	return generator.BatchNextID128(ctx, bizTag, count)
+}
+
+// id128Generator 获取128位ID生成器
+func (s *FrameworkIDGenService) id128Generator() (ID128Generator, error) {
+	if s.generator == nil {
+		return nil, fmt.Errorf("ID generator not initialized")
+	}
+	generator, ok := s.generator.(ID128Generator)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	return generator, nil
+}
+
+// initDB 获取数据库连接
+func (s *FrameworkIDGenService) initDB() error {
+	if s.config.UseFramework {
+		// 使用框架数据库
+		if database.DB == nil {
+			return fmt.Errorf("framework database not initialized")
+		}
+		s.db = database.DB
+		return nil
+	}
+
+	// 使用自定义数据库配置
+	db, err := s.createCustomDB()
+	if err != nil {
+		return fmt.Errorf("failed to create custom database connection: %w", err)
+	}
+	s.db = db
+	return nil
+}
+
 // createCustomDB 创建自定义数据库连接
 func (s *FrameworkIDGenService) createCustomDB() (*gorm.DB, error) {
 	dbConfig := &DatabaseConfig{
@@ -138,19 +189,61 @@ func (s *FrameworkIDGenService) createCustomDB() (*gorm.DB, error) {
 }
 
 // createGenerator 创建ID生成器
-func (s *FrameworkIDGenService) createGenerator() (IDGenerator, error) {
-	// 创建Leaf配置
-	leafConfig := s.createLeafConfig()
-
+func (s *FrameworkIDGenService) createGenerator(ctx context.Context) (IDGenerator, error) {
 	// 根据类型创建生成器
 	switch s.config.Type {
 	case "leaf", "gorm-leaf", "":
-		return NewGormLeafIDGenerator(s.db, leafConfig), nil
+		dao, err := s.createLeafDAO()
+		if err != nil {
+			return nil, err
+		}
+		return NewLeafIDGenerator(dao, s.createLeafConfig()), nil
+	case "snowflake":
+		snowflakeConfig, err := s.createSnowflakeConfig()
+		if err != nil {
+			return nil, err
+		}
+		assigner, err := NewWorkerIDAssigner(snowflakeConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create worker id assigner: %w", err)
+		}
+		return NewSnowflakeIDGenerator(ctx, snowflakeConfig, assigner)
+	case "ksortable", "ulid", "uuidv7":
+		return NewKSortableIDGenerator(s.config.KSortable.RegionID), nil
 	default:
 		return nil, fmt.Errorf("unsupported ID generator type: %s", s.config.Type)
 	}
 }
 
+// leafStorage 号段存储类型
+func (s *FrameworkIDGenService) leafStorage() string {
+	if s.config.Leaf.Storage == "" {
+		return LeafStorageDatabase
+	}
+	return s.config.Leaf.Storage
+}
+
+// createLeafDAO 根据配置创建号段存储
+func (s *FrameworkIDGenService) createLeafDAO() (LeafDAO, error) {
+	switch storage := s.leafStorage(); storage {
+	case LeafStorageDatabase:
+		return NewGormLeafDAO(s.db), nil
+	case LeafStorageRedis:
+		if database.RedisClient == nil {
+			return nil, fmt.Errorf("framework redis not initialized")
+		}
+		return NewRedisLeafDAO(database.RedisClient, s.config.Leaf.KeyPrefix), nil
+	case LeafStorageEtcd:
+		client := etcd.GetClient()
+		if client == nil {
+			return nil, fmt.Errorf("framework etcd not initialized")
+		}
+		return NewEtcdLeafDAO(client, s.config.Leaf.KeyPrefix), nil
+	default:
+		return nil, fmt.Errorf("unsupported leaf storage: %s", storage)
+	}
+}
+
 // createLeafConfig 创建Leaf配置
 func (s *FrameworkIDGenService) createLeafConfig() *LeafConfig {
 	leafConfig := DefaultLeafConfig()
@@ -191,6 +284,64 @@ func (s *FrameworkIDGenService) createLeafConfig() *LeafConfig {
 	return leafConfig
 }
 
+// createSnowflakeConfig 创建Snowflake配置
+func (s *FrameworkIDGenService) createSnowflakeConfig() (*SnowflakeConfig, error) {
+	snowflakeConfig := DefaultSnowflakeConfig()
+	cfg := s.config.Snowflake
+
+	if cfg.Epoch != "" {
+		epoch, err := time.Parse(time.RFC3339, cfg.Epoch)
+		if err != nil {
+			if epoch, err = time.Parse("2006-01-02", cfg.Epoch); err != nil {
+				return nil, fmt.Errorf("invalid snowflake epoch %q: %w", cfg.Epoch, err)
+			}
+		}
+		snowflakeConfig.Epoch = epoch
+	}
+
+	if cfg.TimestampBits > 0 {
+		snowflakeConfig.TimestampBits = cfg.TimestampBits
+	}
+
+	if cfg.WorkerIDBits > 0 {
+		snowflakeConfig.WorkerIDBits = cfg.WorkerIDBits
+	}
+
+	if cfg.SequenceBits > 0 {
+		snowflakeConfig.SequenceBits = cfg.SequenceBits
+	}
+
+	snowflakeConfig.RegionBits = cfg.RegionBits
+	snowflakeConfig.RegionID = cfg.RegionID
+	snowflakeConfig.WorkerID = cfg.WorkerID
+
+	if cfg.WorkerIDSource != "" {
+		snowflakeConfig.WorkerIDSource = cfg.WorkerIDSource
+	}
+
+	if cfg.KeyPrefix != "" {
+		snowflakeConfig.KeyPrefix = cfg.KeyPrefix
+	}
+
+	if cfg.LeaseTTL != "" {
+		ttl, err := time.ParseDuration(cfg.LeaseTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snowflake lease_ttl %q: %w", cfg.LeaseTTL, err)
+		}
+		snowflakeConfig.LeaseTTL = ttl
+	}
+
+	if cfg.MaxBackwardWait != "" {
+		wait, err := time.ParseDuration(cfg.MaxBackwardWait)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snowflake max_backward_wait %q: %w", cfg.MaxBackwardWait, err)
+		}
+		snowflakeConfig.MaxBackwardWait = wait
+	}
+
+	return snowflakeConfig, nil
+}
+
 // createPredefinedBizTags 创建预定义的业务标识
 func (s *FrameworkIDGenService) createPredefinedBizTags(ctx context.Context) error {
 	if s.config.BizTags == nil || len(s.config.BizTags) == 0 {
@@ -230,6 +381,31 @@ func (s *FrameworkIDGenService) GetGenerator() IDGenerator {
 	return s.generator
 }
 
+// Config 获取ID生成器配置
+func (s *FrameworkIDGenService) Config() *config.IDGenConfig {
+	return s.config
+}
+
+// AllocSegment 分配号段供远程客户端在本地发号，仅号段模式支持
+func (s *FrameworkIDGenService) AllocSegment(ctx context.Context, bizTag string, step int32) (*LeafSegment, error) {
+	if allocator, ok := s.generator.(interface {
+		AllocSegment(ctx context.Context, bizTag string, step int32) (*LeafSegment, error)
+	}); ok {
+		return allocator.AllocSegment(ctx, bizTag, step)
+	}
+	return nil, fmt.Errorf("current generator does not support segment allocation: %w", ErrNotSupported)
+}
+
+// ListBizTags 列出所有业务标识
+func (s *FrameworkIDGenService) ListBizTags(ctx context.Context) ([]*LeafAlloc, error) {
+	if manager, ok := s.generator.(interface {
+		ListBizTags(ctx context.Context) ([]*LeafAlloc, error)
+	}); ok {
+		return manager.ListBizTags(ctx)
+	}
+	return nil, fmt.Errorf("current generator does not support biz tag management: %w", ErrNotSupported)
+}
+
 // CreateBizTag 创建业务标识
 func (s *FrameworkIDGenService) CreateBizTag(ctx context.Context, bizTag string, step int32, description string) error {
 	if manager, ok := s.generator.(interface {
@@ -237,7 +413,7 @@ func (s *FrameworkIDGenService) CreateBizTag(ctx context.Context, bizTag string,
 	}); ok {
 		return manager.CreateBizTag(ctx, bizTag, step, description)
 	}
-	return fmt.Errorf("current generator does not support biz tag management")
+	return fmt.Errorf("current generator does not support biz tag management: %w", ErrNotSupported)
 }
 
 // UpdateStep 更新步长
@@ -247,7 +423,7 @@ func (s *FrameworkIDGenService) UpdateStep(ctx context.Context, bizTag string, n
 	}); ok {
 		return manager.UpdateStep(ctx, bizTag, newStep)
 	}
-	return fmt.Errorf("current generator does not support step management")
+	return fmt.Errorf("current generator does not support step management: %w", ErrNotSupported)
 }
 
 // DeleteBizTag 删除业务标识
@@ -257,13 +433,13 @@ func (s *FrameworkIDGenService) DeleteBizTag(ctx context.Context, bizTag string)
 	}); ok {
 		return manager.DeleteBizTag(ctx, bizTag)
 	}
-	return fmt.Errorf("current generator does not support biz tag management")
+	return fmt.Errorf("current generator does not support biz tag management: %w", ErrNotSupported)
 }
 
 // GetMetrics 获取指标
 func (s *FrameworkIDGenService) GetMetrics(bizTag string) interface{} {
 	if metricsProvider, ok := s.generator.(interface {
-		GetMetrics(bizTag string) interface{}
+		GetMetrics(bizTag string) *LeafMetrics
 	}); ok {
 		return metricsProvider.GetMetrics(bizTag)
 	}
@@ -273,9 +449,13 @@ func (s *FrameworkIDGenService) GetMetrics(bizTag string) interface{} {
 // GetAllMetrics 获取所有指标
 func (s *FrameworkIDGenService) GetAllMetrics() map[string]interface{} {
 	if metricsProvider, ok := s.generator.(interface {
-		GetAllMetrics() map[string]interface{}
+		GetAllMetrics() map[string]*LeafMetrics
 	}); ok {
-		return metricsProvider.GetAllMetrics()
+		result := make(map[string]interface{})
+		for bizTag, metrics := range metricsProvider.GetAllMetrics() {
+			result[bizTag] = metrics
+		}
+		return result
 	}
 	return nil
 }
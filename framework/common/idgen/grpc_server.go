@@ -0,0 +1,293 @@
+package idgen
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/qiaojinxia/distributed-service/framework/auth"
+	"github.com/qiaojinxia/distributed-service/framework/common/idgen/idgenpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// MaxBatchCount 单次批量获取ID的最大数量
+const MaxBatchCount = 10000
+
+// GRPCAuthFunc 校验gRPC调用方，admin为true表示业务标识管理接口，返回的错误应为gRPC状态错误
+type GRPCAuthFunc func(ctx context.Context, admin bool) error
+
+// GRPCServer 将 FrameworkIDGenService 以gRPC服务的形式对外提供，接口定义见 idgenpb/idgen.proto
+type GRPCServer struct {
+	idgenpb.UnimplementedIDGenServiceServer
+	service    *FrameworkIDGenService
+	authFunc   GRPCAuthFunc
+	management bool
+}
+
+// NewGRPCServer 创建ID生成gRPC服务
+//
+// 默认不做认证，且不开放 CreateBizTag、UpdateStep、DeleteBizTag，
+// 通过 WithAuth 和 WithManagement 配置。
+func NewGRPCServer(service *FrameworkIDGenService) *GRPCServer {
+	return &GRPCServer{service: service}
+}
+
+// WithAuth 设置调用方校验，对所有接口生效
+func (s *GRPCServer) WithAuth(authFunc GRPCAuthFunc) *GRPCServer {
+	s.authFunc = authFunc
+	return s
+}
+
+// WithManagement 开放业务标识管理接口，未设置 WithAuth 时管理接口仍然拒绝访问
+func (s *GRPCServer) WithManagement(enabled bool) *GRPCServer {
+	s.management = enabled
+	return s
+}
+
+// authorize 校验调用方，管理接口需要同时开启管理和认证
+func (s *GRPCServer) authorize(ctx context.Context, admin bool) error {
+	if admin && (!s.management || s.authFunc == nil) {
+		return status.Error(codes.PermissionDenied, "biz tag management is disabled")
+	}
+	if s.authFunc != nil {
+		return s.authFunc(ctx, admin)
+	}
+	return nil
+}
+
+// JWTGRPCAuth 基于JWT的调用方校验，从metadata的 authorization: Bearer <token> 读取令牌，
+// 管理接口要求令牌中的角色为adminRole
+func JWTGRPCAuth(jwtManager *auth.JWTManager, adminRole string) GRPCAuthFunc {
+	return func(ctx context.Context, admin bool) error {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 || !strings.HasPrefix(values[0], "Bearer ") {
+			return status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+
+		claims, err := jwtManager.ValidateToken(ctx, strings.TrimPrefix(values[0], "Bearer "))
+		if err != nil {
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+		if admin && claims.Role != adminRole {
+			return status.Error(codes.PermissionDenied, "insufficient permissions")
+		}
+		return nil
+	}
+}
+
+// Register 注册到gRPC服务器，框架的gRPC传输层可直接传入 GetServer() 的返回值
+func (s *GRPCServer) Register(registrar grpc.ServiceRegistrar) {
+	idgenpb.RegisterIDGenServiceServer(registrar, s)
+}
+
+// NextID 获取单个ID
+func (s *GRPCServer) NextID(ctx context.Context, req *idgenpb.NextIDRequest) (*idgenpb.NextIDResponse, error) {
+	if err := s.authorize(ctx, false); err != nil {
+		return nil, err
+	}
+
+	if req.GetBizTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "biz_tag is required")
+	}
+
+	id, err := s.service.NextID(ctx, req.GetBizTag())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &idgenpb.NextIDResponse{Id: id}, nil
+}
+
+// BatchNextID 批量获取ID
+func (s *GRPCServer) BatchNextID(ctx context.Context, req *idgenpb.BatchNextIDRequest) (*idgenpb.BatchNextIDResponse, error) {
+	if err := s.authorize(ctx, false); err != nil {
+		return nil, err
+	}
+
+	if req.GetBizTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "biz_tag is required")
+	}
+	if req.GetCount() <= 0 || req.GetCount() > MaxBatchCount {
+		return nil, status.Errorf(codes.InvalidArgument, "count must be in [1, %d]", MaxBatchCount)
+	}
+
+	ids, err := s.service.BatchNextID(ctx, req.GetBizTag(), int(req.GetCount()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &idgenpb.BatchNextIDResponse{Ids: ids}, nil
+}
+
+// NextSegment 分配号段
+func (s *GRPCServer) NextSegment(ctx context.Context, req *idgenpb.NextSegmentRequest) (*idgenpb.NextSegmentResponse, error) {
+	if err := s.authorize(ctx, false); err != nil {
+		return nil, err
+	}
+
+	if req.GetBizTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "biz_tag is required")
+	}
+
+	segment, err := s.service.AllocSegment(ctx, req.GetBizTag(), req.GetStep())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &idgenpb.NextSegmentResponse{Min: segment.Min, Max: segment.Max, Step: segment.Step}, nil
+}
+
+// ListBizTags 列出业务标识
+func (s *GRPCServer) ListBizTags(ctx context.Context, req *idgenpb.ListBizTagsRequest) (*idgenpb.ListBizTagsResponse, error) {
+	if err := s.authorize(ctx, false); err != nil {
+		return nil, err
+	}
+
+	leafAllocs, err := s.service.ListBizTags(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	resp := &idgenpb.ListBizTagsResponse{BizTags: make([]*idgenpb.BizTag, 0, len(leafAllocs))}
+	for _, leafAlloc := range leafAllocs {
+		resp.BizTags = append(resp.BizTags, &idgenpb.BizTag{
+			BizTag:      leafAlloc.BizTag,
+			MaxId:       leafAlloc.MaxID,
+			Step:        leafAlloc.Step,
+			Description: leafAlloc.Description,
+			UpdateTime:  leafAlloc.UpdateTime.UnixMilli(),
+		})
+	}
+	return resp, nil
+}
+
+// CreateBizTag 创建业务标识
+func (s *GRPCServer) CreateBizTag(ctx context.Context, req *idgenpb.CreateBizTagRequest) (*idgenpb.CreateBizTagResponse, error) {
+	if err := s.authorize(ctx, true); err != nil {
+		return nil, err
+	}
+
+	if req.GetBizTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "biz_tag is required")
+	}
+
+	if err := s.service.CreateBizTag(ctx, req.GetBizTag(), req.GetStep(), req.GetDescription()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &idgenpb.CreateBizTagResponse{}, nil
+}
+
+// UpdateStep 更新步长
+func (s *GRPCServer) UpdateStep(ctx context.Context, req *idgenpb.UpdateStepRequest) (*idgenpb.UpdateStepResponse, error) {
+	if err := s.authorize(ctx, true); err != nil {
+		return nil, err
+	}
+
+	if req.GetBizTag() == "" || req.GetStep() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "biz_tag and a positive step are required")
+	}
+
+	if err := s.service.UpdateStep(ctx, req.GetBizTag(), req.GetStep()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &idgenpb.UpdateStepResponse{}, nil
+}
+
+// DeleteBizTag 删除业务标识
+//
+// 删除后再次请求该业务标识会从0开始重新创建，可能发出已经使用过的ID，仅用于清理不再使用的业务标识。
+func (s *GRPCServer) DeleteBizTag(ctx context.Context, req *idgenpb.DeleteBizTagRequest) (*idgenpb.DeleteBizTagResponse, error) {
+	if err := s.authorize(ctx, true); err != nil {
+		return nil, err
+	}
+
+	if req.GetBizTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "biz_tag is required")
+	}
+
+	if err := s.service.DeleteBizTag(ctx, req.GetBizTag()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &idgenpb.DeleteBizTagResponse{}, nil
+}
+
+// GetBufferStatus 获取服务端缓冲区状态
+func (s *GRPCServer) GetBufferStatus(ctx context.Context, req *idgenpb.GetBufferStatusRequest) (*idgenpb.GetBufferStatusResponse, error) {
+	if err := s.authorize(ctx, false); err != nil {
+		return nil, err
+	}
+
+	// 状态中包含时间、嵌套map等类型，先按JSON转换为structpb支持的类型
+	data, err := json.Marshal(s.service.GetBufferStatus(req.GetBizTag()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode buffer status: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode buffer status: %v", err)
+	}
+
+	bufferStatus, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode buffer status: %v", err)
+	}
+	return &idgenpb.GetBufferStatusResponse{Status: bufferStatus}, nil
+}
+
+// GetMetrics 获取指标
+func (s *GRPCServer) GetMetrics(ctx context.Context, req *idgenpb.GetMetricsRequest) (*idgenpb.GetMetricsResponse, error) {
+	if err := s.authorize(ctx, false); err != nil {
+		return nil, err
+	}
+
+	resp := &idgenpb.GetMetricsResponse{Metrics: make(map[string]*idgenpb.Metrics)}
+	if req.GetBizTag() != "" {
+		if metrics, ok := s.service.GetMetrics(req.GetBizTag()).(*LeafMetrics); ok {
+			resp.Metrics[req.GetBizTag()] = metricsToProto(metrics)
+		}
+		return resp, nil
+	}
+
+	// GetAllMetrics 返回的是实时对象，逐个通过 GetMetrics 读取副本
+	for bizTag := range s.service.GetAllMetrics() {
+		if metrics, ok := s.service.GetMetrics(bizTag).(*LeafMetrics); ok {
+			resp.Metrics[bizTag] = metricsToProto(metrics)
+		}
+	}
+	return resp, nil
+}
+
+// metricsToProto 转换指标
+func metricsToProto(metrics *LeafMetrics) *idgenpb.Metrics {
+	return &idgenpb.Metrics{
+		TotalRequests:   metrics.TotalRequests,
+		SuccessRequests: metrics.SuccessRequests,
+		FailedRequests:  metrics.FailedRequests,
+		SegmentLoads:    metrics.SegmentLoads,
+		BufferSwitches:  metrics.BufferSwitches,
+		AverageQps:      metrics.AverageQPS,
+	}
+}
+
+// grpcError 将生成器错误转换为gRPC状态错误
+func grpcError(err error) error {
+	var code codes.Code
+	switch {
+	case errors.Is(err, ErrBizTagNotFound):
+		code = codes.NotFound
+	case errors.Is(err, ErrBizTagExists):
+		code = codes.AlreadyExists
+	case errors.Is(err, ErrNotSupported):
+		code = codes.Unimplemented
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	default:
+		code = codes.Internal
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,186 @@
+package idgen
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/qiaojinxia/distributed-service/framework/auth"
+	"github.com/qiaojinxia/distributed-service/framework/common/idgen/idgenpb"
+	"github.com/qiaojinxia/distributed-service/framework/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// allowAll 放行所有调用方
+func allowAll(context.Context, bool) error { return nil }
+
+// newTestGRPCConn 启动基于内存号段存储的ID生成gRPC服务并返回客户端连接，configure用于设置认证和管理接口
+func newTestGRPCConn(t *testing.T, configure func(*GRPCServer)) *grpc.ClientConn {
+	generator := NewLeafIDGenerator(newMemoryLeafDAO(), DefaultLeafConfig())
+	service := &FrameworkIDGenService{generator: generator, config: &config.IDGenConfig{}}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	grpcServer := NewGRPCServer(service)
+	configure(grpcServer)
+	grpcServer.Register(server)
+	go server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+		generator.Close()
+	})
+	return conn
+}
+
+func TestRemoteIDGenerator_SharedSegments(t *testing.T) {
+	conn := newTestGRPCConn(t, func(s *GRPCServer) { s.WithAuth(allowAll).WithManagement(true) })
+	ctx := context.Background()
+
+	clients := []*RemoteIDGenerator{NewRemoteIDGenerator(conn, nil), NewRemoteIDGenerator(conn, nil)}
+	if _, err := clients[0].Client().CreateBizTag(ctx, &idgenpb.CreateBizTagRequest{BizTag: "order", Step: 10}); err != nil {
+		t.Fatalf("Failed to create biz tag: %v", err)
+	}
+
+	var (
+		mutex sync.Mutex
+		wg    sync.WaitGroup
+		seen  = make(map[int64]bool)
+	)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(client *RemoteIDGenerator) {
+			defer wg.Done()
+			var last int64
+			for j := 0; j < 50; j++ {
+				id, err := client.NextID(ctx, "order")
+				if err != nil {
+					t.Errorf("Failed to get next ID: %v", err)
+					return
+				}
+				if id <= last {
+					t.Errorf("Expected increasing IDs, got %d after %d", id, last)
+				}
+				last = id
+
+				mutex.Lock()
+				if seen[id] {
+					t.Errorf("Duplicate ID: %d", id)
+				}
+				seen[id] = true
+				mutex.Unlock()
+			}
+		}(clients[i%2])
+	}
+	wg.Wait()
+
+	if len(seen) != 200 {
+		t.Errorf("Expected 200 unique IDs, got %d", len(seen))
+	}
+
+	// 每个号段10个ID，客户端应复用本地号段而不是每次请求服务端
+	metrics := clients[0].GetMetrics("order")
+	if metrics.SegmentLoads == 0 || metrics.SegmentLoads >= metrics.SuccessRequests {
+		t.Errorf("Expected segments to be reused locally, got %d loads for %d requests",
+			metrics.SegmentLoads, metrics.SuccessRequests)
+	}
+}
+
+func TestGRPCServer_Errors(t *testing.T) {
+	client := idgenpb.NewIDGenServiceClient(newTestGRPCConn(t, func(s *GRPCServer) { s.WithAuth(allowAll).WithManagement(true) }))
+	ctx := context.Background()
+
+	_, err := client.DeleteBizTag(ctx, &idgenpb.DeleteBizTagRequest{BizTag: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+
+	if _, err := client.CreateBizTag(ctx, &idgenpb.CreateBizTagRequest{BizTag: "user"}); err != nil {
+		t.Fatalf("Failed to create biz tag: %v", err)
+	}
+	_, err = client.CreateBizTag(ctx, &idgenpb.CreateBizTagRequest{BizTag: "user"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists, got %v", err)
+	}
+
+	_, err = client.BatchNextID(ctx, &idgenpb.BatchNextIDRequest{BizTag: "user", Count: MaxBatchCount + 1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+
+	resp, err := client.ListBizTags(ctx, &idgenpb.ListBizTagsRequest{})
+	if err != nil {
+		t.Fatalf("Failed to list biz tags: %v", err)
+	}
+	if len(resp.GetBizTags()) != 1 || resp.GetBizTags()[0].GetBizTag() != "user" {
+		t.Errorf("Expected biz tag user, got %v", resp.GetBizTags())
+	}
+}
+
+func TestGRPCServer_ManagementDisabled(t *testing.T) {
+	// 未开启认证时即使开启管理也不允许增删改业务标识
+	client := idgenpb.NewIDGenServiceClient(newTestGRPCConn(t, func(s *GRPCServer) { s.WithManagement(true) }))
+	ctx := context.Background()
+
+	_, err := client.CreateBizTag(ctx, &idgenpb.CreateBizTagRequest{BizTag: "user"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for CreateBizTag, got %v", err)
+	}
+	_, err = client.DeleteBizTag(ctx, &idgenpb.DeleteBizTagRequest{BizTag: "user"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for DeleteBizTag, got %v", err)
+	}
+
+	if _, err := client.NextID(ctx, &idgenpb.NextIDRequest{BizTag: "user"}); err != nil {
+		t.Errorf("Expected NextID to be allowed, got %v", err)
+	}
+}
+
+func TestGRPCServer_JWTAuth(t *testing.T) {
+	jwtManager := auth.NewJWTManager("test-secret", "test")
+	client := idgenpb.NewIDGenServiceClient(newTestGRPCConn(t, func(s *GRPCServer) {
+		s.WithAuth(JWTGRPCAuth(jwtManager, "admin")).WithManagement(true)
+	}))
+	ctx := context.Background()
+
+	withToken := func(role string) context.Context {
+		token, err := jwtManager.GenerateTokenWithRole(ctx, 1, "tester", role)
+		if err != nil {
+			t.Fatalf("Failed to generate token: %v", err)
+		}
+		return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+
+	_, err := client.NextID(ctx, &idgenpb.NextIDRequest{BizTag: "user"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without token, got %v", err)
+	}
+
+	_, err = client.CreateBizTag(withToken("user"), &idgenpb.CreateBizTagRequest{BizTag: "user"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for non-admin, got %v", err)
+	}
+
+	if _, err := client.CreateBizTag(withToken("admin"), &idgenpb.CreateBizTagRequest{BizTag: "user"}); err != nil {
+		t.Errorf("Expected admin to create biz tag, got %v", err)
+	}
+	if _, err := client.NextID(withToken("user"), &idgenpb.NextIDRequest{BizTag: "user"}); err != nil {
+		t.Errorf("Expected authenticated NextID to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,1190 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: idgen.proto
+
+package idgenpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 获取单个ID请求
+type NextIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BizTag        string                 `protobuf:"bytes,1,opt,name=biz_tag,json=bizTag,proto3" json:"biz_tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NextIDRequest) Reset() {
+	*x = NextIDRequest{}
+	mi := &file_idgen_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextIDRequest) ProtoMessage() {}
+
+func (x *NextIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextIDRequest.ProtoReflect.Descriptor instead.
+func (*NextIDRequest) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NextIDRequest) GetBizTag() string {
+	if x != nil {
+		return x.BizTag
+	}
+	return ""
+}
+
+// 获取单个ID响应
+type NextIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NextIDResponse) Reset() {
+	*x = NextIDResponse{}
+	mi := &file_idgen_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextIDResponse) ProtoMessage() {}
+
+func (x *NextIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextIDResponse.ProtoReflect.Descriptor instead.
+func (*NextIDResponse) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NextIDResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// 批量获取ID请求
+type BatchNextIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BizTag        string                 `protobuf:"bytes,1,opt,name=biz_tag,json=bizTag,proto3" json:"biz_tag,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchNextIDRequest) Reset() {
+	*x = BatchNextIDRequest{}
+	mi := &file_idgen_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchNextIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchNextIDRequest) ProtoMessage() {}
+
+func (x *BatchNextIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchNextIDRequest.ProtoReflect.Descriptor instead.
+func (*BatchNextIDRequest) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchNextIDRequest) GetBizTag() string {
+	if x != nil {
+		return x.BizTag
+	}
+	return ""
+}
+
+func (x *BatchNextIDRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// 批量获取ID响应
+type BatchNextIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchNextIDResponse) Reset() {
+	*x = BatchNextIDResponse{}
+	mi := &file_idgen_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchNextIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchNextIDResponse) ProtoMessage() {}
+
+func (x *BatchNextIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchNextIDResponse.ProtoReflect.Descriptor instead.
+func (*BatchNextIDResponse) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchNextIDResponse) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// 分配号段请求
+type NextSegmentRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	BizTag string                 `protobuf:"bytes,1,opt,name=biz_tag,json=bizTag,proto3" json:"biz_tag,omitempty"`
+	// 号段长度，0表示使用业务标识配置的步长
+	Step          int32 `protobuf:"varint,2,opt,name=step,proto3" json:"step,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NextSegmentRequest) Reset() {
+	*x = NextSegmentRequest{}
+	mi := &file_idgen_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextSegmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextSegmentRequest) ProtoMessage() {}
+
+func (x *NextSegmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextSegmentRequest.ProtoReflect.Descriptor instead.
+func (*NextSegmentRequest) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NextSegmentRequest) GetBizTag() string {
+	if x != nil {
+		return x.BizTag
+	}
+	return ""
+}
+
+func (x *NextSegmentRequest) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+// 分配号段响应，min到max（含）的ID归调用方独占
+type NextSegmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Min           int64                  `protobuf:"varint,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max           int64                  `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	Step          int32                  `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NextSegmentResponse) Reset() {
+	*x = NextSegmentResponse{}
+	mi := &file_idgen_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextSegmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextSegmentResponse) ProtoMessage() {}
+
+func (x *NextSegmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextSegmentResponse.ProtoReflect.Descriptor instead.
+func (*NextSegmentResponse) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NextSegmentResponse) GetMin() int64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *NextSegmentResponse) GetMax() int64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *NextSegmentResponse) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+// 业务标识
+type BizTag struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	BizTag      string                 `protobuf:"bytes,1,opt,name=biz_tag,json=bizTag,proto3" json:"biz_tag,omitempty"`
+	MaxId       int64                  `protobuf:"varint,2,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Step        int32                  `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// 更新时间（Unix毫秒）
+	UpdateTime    int64 `protobuf:"varint,5,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BizTag) Reset() {
+	*x = BizTag{}
+	mi := &file_idgen_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BizTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BizTag) ProtoMessage() {}
+
+func (x *BizTag) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BizTag.ProtoReflect.Descriptor instead.
+func (*BizTag) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BizTag) GetBizTag() string {
+	if x != nil {
+		return x.BizTag
+	}
+	return ""
+}
+
+func (x *BizTag) GetMaxId() int64 {
+	if x != nil {
+		return x.MaxId
+	}
+	return 0
+}
+
+func (x *BizTag) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *BizTag) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *BizTag) GetUpdateTime() int64 {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return 0
+}
+
+// 列出业务标识请求
+type ListBizTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBizTagsRequest) Reset() {
+	*x = ListBizTagsRequest{}
+	mi := &file_idgen_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBizTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBizTagsRequest) ProtoMessage() {}
+
+func (x *ListBizTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBizTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListBizTagsRequest) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{7}
+}
+
+// 列出业务标识响应
+type ListBizTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BizTags       []*BizTag              `protobuf:"bytes,1,rep,name=biz_tags,json=bizTags,proto3" json:"biz_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBizTagsResponse) Reset() {
+	*x = ListBizTagsResponse{}
+	mi := &file_idgen_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBizTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBizTagsResponse) ProtoMessage() {}
+
+func (x *ListBizTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBizTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListBizTagsResponse) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListBizTagsResponse) GetBizTags() []*BizTag {
+	if x != nil {
+		return x.BizTags
+	}
+	return nil
+}
+
+// 创建业务标识请求
+type CreateBizTagRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	BizTag string                 `protobuf:"bytes,1,opt,name=biz_tag,json=bizTag,proto3" json:"biz_tag,omitempty"`
+	// 步长，0表示使用默认步长
+	Step          int32  `protobuf:"varint,2,opt,name=step,proto3" json:"step,omitempty"`
+	Description   string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBizTagRequest) Reset() {
+	*x = CreateBizTagRequest{}
+	mi := &file_idgen_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBizTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBizTagRequest) ProtoMessage() {}
+
+func (x *CreateBizTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBizTagRequest.ProtoReflect.Descriptor instead.
+func (*CreateBizTagRequest) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateBizTagRequest) GetBizTag() string {
+	if x != nil {
+		return x.BizTag
+	}
+	return ""
+}
+
+func (x *CreateBizTagRequest) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *CreateBizTagRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// 创建业务标识响应
+type CreateBizTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBizTagResponse) Reset() {
+	*x = CreateBizTagResponse{}
+	mi := &file_idgen_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBizTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBizTagResponse) ProtoMessage() {}
+
+func (x *CreateBizTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBizTagResponse.ProtoReflect.Descriptor instead.
+func (*CreateBizTagResponse) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{10}
+}
+
+// 更新步长请求
+type UpdateStepRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BizTag        string                 `protobuf:"bytes,1,opt,name=biz_tag,json=bizTag,proto3" json:"biz_tag,omitempty"`
+	Step          int32                  `protobuf:"varint,2,opt,name=step,proto3" json:"step,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateStepRequest) Reset() {
+	*x = UpdateStepRequest{}
+	mi := &file_idgen_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStepRequest) ProtoMessage() {}
+
+func (x *UpdateStepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStepRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStepRequest) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateStepRequest) GetBizTag() string {
+	if x != nil {
+		return x.BizTag
+	}
+	return ""
+}
+
+func (x *UpdateStepRequest) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+// 更新步长响应
+type UpdateStepResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateStepResponse) Reset() {
+	*x = UpdateStepResponse{}
+	mi := &file_idgen_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStepResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStepResponse) ProtoMessage() {}
+
+func (x *UpdateStepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStepResponse.ProtoReflect.Descriptor instead.
+func (*UpdateStepResponse) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{12}
+}
+
+// 删除业务标识请求
+type DeleteBizTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BizTag        string                 `protobuf:"bytes,1,opt,name=biz_tag,json=bizTag,proto3" json:"biz_tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBizTagRequest) Reset() {
+	*x = DeleteBizTagRequest{}
+	mi := &file_idgen_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBizTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBizTagRequest) ProtoMessage() {}
+
+func (x *DeleteBizTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBizTagRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBizTagRequest) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteBizTagRequest) GetBizTag() string {
+	if x != nil {
+		return x.BizTag
+	}
+	return ""
+}
+
+// 删除业务标识响应
+type DeleteBizTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBizTagResponse) Reset() {
+	*x = DeleteBizTagResponse{}
+	mi := &file_idgen_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBizTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBizTagResponse) ProtoMessage() {}
+
+func (x *DeleteBizTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBizTagResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBizTagResponse) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{14}
+}
+
+// 获取缓冲区状态请求
+type GetBufferStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BizTag        string                 `protobuf:"bytes,1,opt,name=biz_tag,json=bizTag,proto3" json:"biz_tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBufferStatusRequest) Reset() {
+	*x = GetBufferStatusRequest{}
+	mi := &file_idgen_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBufferStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBufferStatusRequest) ProtoMessage() {}
+
+func (x *GetBufferStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBufferStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBufferStatusRequest) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetBufferStatusRequest) GetBizTag() string {
+	if x != nil {
+		return x.BizTag
+	}
+	return ""
+}
+
+// 获取缓冲区状态响应，字段随生成器类型不同
+type GetBufferStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *structpb.Struct       `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBufferStatusResponse) Reset() {
+	*x = GetBufferStatusResponse{}
+	mi := &file_idgen_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBufferStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBufferStatusResponse) ProtoMessage() {}
+
+func (x *GetBufferStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBufferStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetBufferStatusResponse) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetBufferStatusResponse) GetStatus() *structpb.Struct {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// 指标
+type Metrics struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TotalRequests   int64                  `protobuf:"varint,1,opt,name=total_requests,json=totalRequests,proto3" json:"total_requests,omitempty"`
+	SuccessRequests int64                  `protobuf:"varint,2,opt,name=success_requests,json=successRequests,proto3" json:"success_requests,omitempty"`
+	FailedRequests  int64                  `protobuf:"varint,3,opt,name=failed_requests,json=failedRequests,proto3" json:"failed_requests,omitempty"`
+	SegmentLoads    int64                  `protobuf:"varint,4,opt,name=segment_loads,json=segmentLoads,proto3" json:"segment_loads,omitempty"`
+	BufferSwitches  int64                  `protobuf:"varint,5,opt,name=buffer_switches,json=bufferSwitches,proto3" json:"buffer_switches,omitempty"`
+	AverageQps      float64                `protobuf:"fixed64,6,opt,name=average_qps,json=averageQps,proto3" json:"average_qps,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Metrics) Reset() {
+	*x = Metrics{}
+	mi := &file_idgen_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metrics) ProtoMessage() {}
+
+func (x *Metrics) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metrics.ProtoReflect.Descriptor instead.
+func (*Metrics) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Metrics) GetTotalRequests() int64 {
+	if x != nil {
+		return x.TotalRequests
+	}
+	return 0
+}
+
+func (x *Metrics) GetSuccessRequests() int64 {
+	if x != nil {
+		return x.SuccessRequests
+	}
+	return 0
+}
+
+func (x *Metrics) GetFailedRequests() int64 {
+	if x != nil {
+		return x.FailedRequests
+	}
+	return 0
+}
+
+func (x *Metrics) GetSegmentLoads() int64 {
+	if x != nil {
+		return x.SegmentLoads
+	}
+	return 0
+}
+
+func (x *Metrics) GetBufferSwitches() int64 {
+	if x != nil {
+		return x.BufferSwitches
+	}
+	return 0
+}
+
+func (x *Metrics) GetAverageQps() float64 {
+	if x != nil {
+		return x.AverageQps
+	}
+	return 0
+}
+
+// 获取指标请求
+type GetMetricsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 业务标识，为空时返回所有业务标识的指标
+	BizTag        string `protobuf:"bytes,1,opt,name=biz_tag,json=bizTag,proto3" json:"biz_tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMetricsRequest) Reset() {
+	*x = GetMetricsRequest{}
+	mi := &file_idgen_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetricsRequest) ProtoMessage() {}
+
+func (x *GetMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetricsRequest.ProtoReflect.Descriptor instead.
+func (*GetMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetMetricsRequest) GetBizTag() string {
+	if x != nil {
+		return x.BizTag
+	}
+	return ""
+}
+
+// 获取指标响应
+type GetMetricsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metrics       map[string]*Metrics    `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMetricsResponse) Reset() {
+	*x = GetMetricsResponse{}
+	mi := &file_idgen_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetricsResponse) ProtoMessage() {}
+
+func (x *GetMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idgen_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetricsResponse.ProtoReflect.Descriptor instead.
+func (*GetMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_idgen_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetMetricsResponse) GetMetrics() map[string]*Metrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+var File_idgen_proto protoreflect.FileDescriptor
+
+const file_idgen_proto_rawDesc = "" +
+	"\n" +
+	"\vidgen.proto\x12\bidgen.v1\x1a\x1cgoogle/protobuf/struct.proto\"(\n" +
+	"\rNextIDRequest\x12\x17\n" +
+	"\abiz_tag\x18\x01 \x01(\tR\x06bizTag\" \n" +
+	"\x0eNextIDResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"C\n" +
+	"\x12BatchNextIDRequest\x12\x17\n" +
+	"\abiz_tag\x18\x01 \x01(\tR\x06bizTag\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"'\n" +
+	"\x13BatchNextIDResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"A\n" +
+	"\x12NextSegmentRequest\x12\x17\n" +
+	"\abiz_tag\x18\x01 \x01(\tR\x06bizTag\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\x05R\x04step\"M\n" +
+	"\x13NextSegmentResponse\x12\x10\n" +
+	"\x03min\x18\x01 \x01(\x03R\x03min\x12\x10\n" +
+	"\x03max\x18\x02 \x01(\x03R\x03max\x12\x12\n" +
+	"\x04step\x18\x03 \x01(\x05R\x04step\"\x8f\x01\n" +
+	"\x06BizTag\x12\x17\n" +
+	"\abiz_tag\x18\x01 \x01(\tR\x06bizTag\x12\x15\n" +
+	"\x06max_id\x18\x02 \x01(\x03R\x05maxId\x12\x12\n" +
+	"\x04step\x18\x03 \x01(\x05R\x04step\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vupdate_time\x18\x05 \x01(\x03R\n" +
+	"updateTime\"\x14\n" +
+	"\x12ListBizTagsRequest\"B\n" +
+	"\x13ListBizTagsResponse\x12+\n" +
+	"\bbiz_tags\x18\x01 \x03(\v2\x10.idgen.v1.BizTagR\abizTags\"d\n" +
+	"\x13CreateBizTagRequest\x12\x17\n" +
+	"\abiz_tag\x18\x01 \x01(\tR\x06bizTag\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\x05R\x04step\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\x16\n" +
+	"\x14CreateBizTagResponse\"@\n" +
+	"\x11UpdateStepRequest\x12\x17\n" +
+	"\abiz_tag\x18\x01 \x01(\tR\x06bizTag\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\x05R\x04step\"\x14\n" +
+	"\x12UpdateStepResponse\".\n" +
+	"\x13DeleteBizTagRequest\x12\x17\n" +
+	"\abiz_tag\x18\x01 \x01(\tR\x06bizTag\"\x16\n" +
+	"\x14DeleteBizTagResponse\"1\n" +
+	"\x16GetBufferStatusRequest\x12\x17\n" +
+	"\abiz_tag\x18\x01 \x01(\tR\x06bizTag\"J\n" +
+	"\x17GetBufferStatusResponse\x12/\n" +
+	"\x06status\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06status\"\xf3\x01\n" +
+	"\aMetrics\x12%\n" +
+	"\x0etotal_requests\x18\x01 \x01(\x03R\rtotalRequests\x12)\n" +
+	"\x10success_requests\x18\x02 \x01(\x03R\x0fsuccessRequests\x12'\n" +
+	"\x0ffailed_requests\x18\x03 \x01(\x03R\x0efailedRequests\x12#\n" +
+	"\rsegment_loads\x18\x04 \x01(\x03R\fsegmentLoads\x12'\n" +
+	"\x0fbuffer_switches\x18\x05 \x01(\x03R\x0ebufferSwitches\x12\x1f\n" +
+	"\vaverage_qps\x18\x06 \x01(\x01R\n" +
+	"averageQps\",\n" +
+	"\x11GetMetricsRequest\x12\x17\n" +
+	"\abiz_tag\x18\x01 \x01(\tR\x06bizTag\"\xa8\x01\n" +
+	"\x12GetMetricsResponse\x12C\n" +
+	"\ametrics\x18\x01 \x03(\v2).idgen.v1.GetMetricsResponse.MetricsEntryR\ametrics\x1aM\n" +
+	"\fMetricsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12'\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.idgen.v1.MetricsR\x05value:\x028\x012\xb7\x05\n" +
+	"\fIDGenService\x12;\n" +
+	"\x06NextID\x12\x17.idgen.v1.NextIDRequest\x1a\x18.idgen.v1.NextIDResponse\x12J\n" +
+	"\vBatchNextID\x12\x1c.idgen.v1.BatchNextIDRequest\x1a\x1d.idgen.v1.BatchNextIDResponse\x12J\n" +
+	"\vNextSegment\x12\x1c.idgen.v1.NextSegmentRequest\x1a\x1d.idgen.v1.NextSegmentResponse\x12J\n" +
+	"\vListBizTags\x12\x1c.idgen.v1.ListBizTagsRequest\x1a\x1d.idgen.v1.ListBizTagsResponse\x12M\n" +
+	"\fCreateBizTag\x12\x1d.idgen.v1.CreateBizTagRequest\x1a\x1e.idgen.v1.CreateBizTagResponse\x12G\n" +
+	"\n" +
+	"UpdateStep\x12\x1b.idgen.v1.UpdateStepRequest\x1a\x1c.idgen.v1.UpdateStepResponse\x12M\n" +
+	"\fDeleteBizTag\x12\x1d.idgen.v1.DeleteBizTagRequest\x1a\x1e.idgen.v1.DeleteBizTagResponse\x12V\n" +
+	"\x0fGetBufferStatus\x12 .idgen.v1.GetBufferStatusRequest\x1a!.idgen.v1.GetBufferStatusResponse\x12G\n" +
+	"\n" +
+	"GetMetrics\x12\x1b.idgen.v1.GetMetricsRequest\x1a\x1c.idgen.v1.GetMetricsResponseBl\n" +
+	"\x1ecom.github.qiaojinxia.idgen.v1P\x01ZHgithub.com/qiaojinxia/distributed-service/framework/common/idgen/idgenpbb\x06proto3"
+
+var (
+	file_idgen_proto_rawDescOnce sync.Once
+	file_idgen_proto_rawDescData []byte
+)
+
+func file_idgen_proto_rawDescGZIP() []byte {
+	file_idgen_proto_rawDescOnce.Do(func() {
+		file_idgen_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_idgen_proto_rawDesc), len(file_idgen_proto_rawDesc)))
+	})
+	return file_idgen_proto_rawDescData
+}
+
+var file_idgen_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_idgen_proto_goTypes = []any{
+	(*NextIDRequest)(nil),           // 0: idgen.v1.NextIDRequest
+	(*NextIDResponse)(nil),          // 1: idgen.v1.NextIDResponse
+	(*BatchNextIDRequest)(nil),      // 2: idgen.v1.BatchNextIDRequest
+	(*BatchNextIDResponse)(nil),     // 3: idgen.v1.BatchNextIDResponse
+	(*NextSegmentRequest)(nil),      // 4: idgen.v1.NextSegmentRequest
+	(*NextSegmentResponse)(nil),     // 5: idgen.v1.NextSegmentResponse
+	(*BizTag)(nil),                  // 6: idgen.v1.BizTag
+	(*ListBizTagsRequest)(nil),      // 7: idgen.v1.ListBizTagsRequest
+	(*ListBizTagsResponse)(nil),     // 8: idgen.v1.ListBizTagsResponse
+	(*CreateBizTagRequest)(nil),     // 9: idgen.v1.CreateBizTagRequest
+	(*CreateBizTagResponse)(nil),    // 10: idgen.v1.CreateBizTagResponse
+	(*UpdateStepRequest)(nil),       // 11: idgen.v1.UpdateStepRequest
+	(*UpdateStepResponse)(nil),      // 12: idgen.v1.UpdateStepResponse
+	(*DeleteBizTagRequest)(nil),     // 13: idgen.v1.DeleteBizTagRequest
+	(*DeleteBizTagResponse)(nil),    // 14: idgen.v1.DeleteBizTagResponse
+	(*GetBufferStatusRequest)(nil),  // 15: idgen.v1.GetBufferStatusRequest
+	(*GetBufferStatusResponse)(nil), // 16: idgen.v1.GetBufferStatusResponse
+	(*Metrics)(nil),                 // 17: idgen.v1.Metrics
+	(*GetMetricsRequest)(nil),       // 18: idgen.v1.GetMetricsRequest
+	(*GetMetricsResponse)(nil),      // 19: idgen.v1.GetMetricsResponse
+	nil,                             // 20: idgen.v1.GetMetricsResponse.MetricsEntry
+	(*structpb.Struct)(nil),         // 21: google.protobuf.Struct
+}
+var file_idgen_proto_depIdxs = []int32{
+	6,  // 0: idgen.v1.ListBizTagsResponse.biz_tags:type_name -> idgen.v1.BizTag
+	21, // 1: idgen.v1.GetBufferStatusResponse.status:type_name -> google.protobuf.Struct
+	20, // 2: idgen.v1.GetMetricsResponse.metrics:type_name -> idgen.v1.GetMetricsResponse.MetricsEntry
+	17, // 3: idgen.v1.GetMetricsResponse.MetricsEntry.value:type_name -> idgen.v1.Metrics
+	0,  // 4: idgen.v1.IDGenService.NextID:input_type -> idgen.v1.NextIDRequest
+	2,  // 5: idgen.v1.IDGenService.BatchNextID:input_type -> idgen.v1.BatchNextIDRequest
+	4,  // 6: idgen.v1.IDGenService.NextSegment:input_type -> idgen.v1.NextSegmentRequest
+	7,  // 7: idgen.v1.IDGenService.ListBizTags:input_type -> idgen.v1.ListBizTagsRequest
+	9,  // 8: idgen.v1.IDGenService.CreateBizTag:input_type -> idgen.v1.CreateBizTagRequest
+	11, // 9: idgen.v1.IDGenService.UpdateStep:input_type -> idgen.v1.UpdateStepRequest
+	13, // 10: idgen.v1.IDGenService.DeleteBizTag:input_type -> idgen.v1.DeleteBizTagRequest
+	15, // 11: idgen.v1.IDGenService.GetBufferStatus:input_type -> idgen.v1.GetBufferStatusRequest
+	18, // 12: idgen.v1.IDGenService.GetMetrics:input_type -> idgen.v1.GetMetricsRequest
+	1,  // 13: idgen.v1.IDGenService.NextID:output_type -> idgen.v1.NextIDResponse
+	3,  // 14: idgen.v1.IDGenService.BatchNextID:output_type -> idgen.v1.BatchNextIDResponse
+	5,  // 15: idgen.v1.IDGenService.NextSegment:output_type -> idgen.v1.NextSegmentResponse
+	8,  // 16: idgen.v1.IDGenService.ListBizTags:output_type -> idgen.v1.ListBizTagsResponse
+	10, // 17: idgen.v1.IDGenService.CreateBizTag:output_type -> idgen.v1.CreateBizTagResponse
+	12, // 18: idgen.v1.IDGenService.UpdateStep:output_type -> idgen.v1.UpdateStepResponse
+	14, // 19: idgen.v1.IDGenService.DeleteBizTag:output_type -> idgen.v1.DeleteBizTagResponse
+	16, // 20: idgen.v1.IDGenService.GetBufferStatus:output_type -> idgen.v1.GetBufferStatusResponse
+	19, // 21: idgen.v1.IDGenService.GetMetrics:output_type -> idgen.v1.GetMetricsResponse
+	13, // [13:22] is the sub-list for method output_type
+	4,  // [4:13] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_idgen_proto_init() }
+func file_idgen_proto_init() {
+	if File_idgen_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_idgen_proto_rawDesc), len(file_idgen_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_idgen_proto_goTypes,
+		DependencyIndexes: file_idgen_proto_depIdxs,
+		MessageInfos:      file_idgen_proto_msgTypes,
+	}.Build()
+	File_idgen_proto = out.File
+	file_idgen_proto_goTypes = nil
+	file_idgen_proto_depIdxs = nil
+}
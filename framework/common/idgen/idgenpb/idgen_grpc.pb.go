@@ -0,0 +1,447 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: idgen.proto
+
+package idgenpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	IDGenService_NextID_FullMethodName          = "/idgen.v1.IDGenService/NextID"
+	IDGenService_BatchNextID_FullMethodName     = "/idgen.v1.IDGenService/BatchNextID"
+	IDGenService_NextSegment_FullMethodName     = "/idgen.v1.IDGenService/NextSegment"
+	IDGenService_ListBizTags_FullMethodName     = "/idgen.v1.IDGenService/ListBizTags"
+	IDGenService_CreateBizTag_FullMethodName    = "/idgen.v1.IDGenService/CreateBizTag"
+	IDGenService_UpdateStep_FullMethodName      = "/idgen.v1.IDGenService/UpdateStep"
+	IDGenService_DeleteBizTag_FullMethodName    = "/idgen.v1.IDGenService/DeleteBizTag"
+	IDGenService_GetBufferStatus_FullMethodName = "/idgen.v1.IDGenService/GetBufferStatus"
+	IDGenService_GetMetrics_FullMethodName      = "/idgen.v1.IDGenService/GetMetrics"
+)
+
+// IDGenServiceClient is the client API for IDGenService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ID生成服务
+type IDGenServiceClient interface {
+	// 获取单个ID
+	NextID(ctx context.Context, in *NextIDRequest, opts ...grpc.CallOption) (*NextIDResponse, error)
+	// 批量获取ID
+	BatchNextID(ctx context.Context, in *BatchNextIDRequest, opts ...grpc.CallOption) (*BatchNextIDResponse, error)
+	// 分配一个号段，由客户端在本地发号，仅号段模式支持，其他模式返回UNIMPLEMENTED
+	NextSegment(ctx context.Context, in *NextSegmentRequest, opts ...grpc.CallOption) (*NextSegmentResponse, error)
+	// 列出业务标识
+	ListBizTags(ctx context.Context, in *ListBizTagsRequest, opts ...grpc.CallOption) (*ListBizTagsResponse, error)
+	// 创建业务标识
+	CreateBizTag(ctx context.Context, in *CreateBizTagRequest, opts ...grpc.CallOption) (*CreateBizTagResponse, error)
+	// 更新步长
+	UpdateStep(ctx context.Context, in *UpdateStepRequest, opts ...grpc.CallOption) (*UpdateStepResponse, error)
+	// 删除业务标识
+	DeleteBizTag(ctx context.Context, in *DeleteBizTagRequest, opts ...grpc.CallOption) (*DeleteBizTagResponse, error)
+	// 获取服务端缓冲区状态
+	GetBufferStatus(ctx context.Context, in *GetBufferStatusRequest, opts ...grpc.CallOption) (*GetBufferStatusResponse, error)
+	// 获取指标
+	GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsResponse, error)
+}
+
+type iDGenServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIDGenServiceClient(cc grpc.ClientConnInterface) IDGenServiceClient {
+	return &iDGenServiceClient{cc}
+}
+
+func (c *iDGenServiceClient) NextID(ctx context.Context, in *NextIDRequest, opts ...grpc.CallOption) (*NextIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NextIDResponse)
+	err := c.cc.Invoke(ctx, IDGenService_NextID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iDGenServiceClient) BatchNextID(ctx context.Context, in *BatchNextIDRequest, opts ...grpc.CallOption) (*BatchNextIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchNextIDResponse)
+	err := c.cc.Invoke(ctx, IDGenService_BatchNextID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iDGenServiceClient) NextSegment(ctx context.Context, in *NextSegmentRequest, opts ...grpc.CallOption) (*NextSegmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NextSegmentResponse)
+	err := c.cc.Invoke(ctx, IDGenService_NextSegment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iDGenServiceClient) ListBizTags(ctx context.Context, in *ListBizTagsRequest, opts ...grpc.CallOption) (*ListBizTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBizTagsResponse)
+	err := c.cc.Invoke(ctx, IDGenService_ListBizTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iDGenServiceClient) CreateBizTag(ctx context.Context, in *CreateBizTagRequest, opts ...grpc.CallOption) (*CreateBizTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBizTagResponse)
+	err := c.cc.Invoke(ctx, IDGenService_CreateBizTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iDGenServiceClient) UpdateStep(ctx context.Context, in *UpdateStepRequest, opts ...grpc.CallOption) (*UpdateStepResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateStepResponse)
+	err := c.cc.Invoke(ctx, IDGenService_UpdateStep_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iDGenServiceClient) DeleteBizTag(ctx context.Context, in *DeleteBizTagRequest, opts ...grpc.CallOption) (*DeleteBizTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBizTagResponse)
+	err := c.cc.Invoke(ctx, IDGenService_DeleteBizTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iDGenServiceClient) GetBufferStatus(ctx context.Context, in *GetBufferStatusRequest, opts ...grpc.CallOption) (*GetBufferStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBufferStatusResponse)
+	err := c.cc.Invoke(ctx, IDGenService_GetBufferStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iDGenServiceClient) GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMetricsResponse)
+	err := c.cc.Invoke(ctx, IDGenService_GetMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IDGenServiceServer is the server API for IDGenService service.
+// All implementations must embed UnimplementedIDGenServiceServer
+// for forward compatibility.
+//
+// ID生成服务
+type IDGenServiceServer interface {
+	// 获取单个ID
+	NextID(context.Context, *NextIDRequest) (*NextIDResponse, error)
+	// 批量获取ID
+	BatchNextID(context.Context, *BatchNextIDRequest) (*BatchNextIDResponse, error)
+	// 分配一个号段，由客户端在本地发号，仅号段模式支持，其他模式返回UNIMPLEMENTED
+	NextSegment(context.Context, *NextSegmentRequest) (*NextSegmentResponse, error)
+	// 列出业务标识
+	ListBizTags(context.Context, *ListBizTagsRequest) (*ListBizTagsResponse, error)
+	// 创建业务标识
+	CreateBizTag(context.Context, *CreateBizTagRequest) (*CreateBizTagResponse, error)
+	// 更新步长
+	UpdateStep(context.Context, *UpdateStepRequest) (*UpdateStepResponse, error)
+	// 删除业务标识
+	DeleteBizTag(context.Context, *DeleteBizTagRequest) (*DeleteBizTagResponse, error)
+	// 获取服务端缓冲区状态
+	GetBufferStatus(context.Context, *GetBufferStatusRequest) (*GetBufferStatusResponse, error)
+	// 获取指标
+	GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsResponse, error)
+	mustEmbedUnimplementedIDGenServiceServer()
+}
+
+// UnimplementedIDGenServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedIDGenServiceServer struct{}
+
+func (UnimplementedIDGenServiceServer) NextID(context.Context, *NextIDRequest) (*NextIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextID not implemented")
+}
+func (UnimplementedIDGenServiceServer) BatchNextID(context.Context, *BatchNextIDRequest) (*BatchNextIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchNextID not implemented")
+}
+func (UnimplementedIDGenServiceServer) NextSegment(context.Context, *NextSegmentRequest) (*NextSegmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextSegment not implemented")
+}
+func (UnimplementedIDGenServiceServer) ListBizTags(context.Context, *ListBizTagsRequest) (*ListBizTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBizTags not implemented")
+}
+func (UnimplementedIDGenServiceServer) CreateBizTag(context.Context, *CreateBizTagRequest) (*CreateBizTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBizTag not implemented")
+}
+func (UnimplementedIDGenServiceServer) UpdateStep(context.Context, *UpdateStepRequest) (*UpdateStepResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateStep not implemented")
+}
+func (UnimplementedIDGenServiceServer) DeleteBizTag(context.Context, *DeleteBizTagRequest) (*DeleteBizTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBizTag not implemented")
+}
+func (UnimplementedIDGenServiceServer) GetBufferStatus(context.Context, *GetBufferStatusRequest) (*GetBufferStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBufferStatus not implemented")
+}
+func (UnimplementedIDGenServiceServer) GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
+}
+func (UnimplementedIDGenServiceServer) mustEmbedUnimplementedIDGenServiceServer() {}
+func (UnimplementedIDGenServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeIDGenServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IDGenServiceServer will
+// result in compilation errors.
+type UnsafeIDGenServiceServer interface {
+	mustEmbedUnimplementedIDGenServiceServer()
+}
+
+func RegisterIDGenServiceServer(s grpc.ServiceRegistrar, srv IDGenServiceServer) {
+	// If the following call pancis, it indicates UnimplementedIDGenServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&IDGenService_ServiceDesc, srv)
+}
+
+func _IDGenService_NextID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IDGenServiceServer).NextID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IDGenService_NextID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IDGenServiceServer).NextID(ctx, req.(*NextIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IDGenService_BatchNextID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchNextIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IDGenServiceServer).BatchNextID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IDGenService_BatchNextID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IDGenServiceServer).BatchNextID(ctx, req.(*BatchNextIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IDGenService_NextSegment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextSegmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IDGenServiceServer).NextSegment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IDGenService_NextSegment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IDGenServiceServer).NextSegment(ctx, req.(*NextSegmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IDGenService_ListBizTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBizTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IDGenServiceServer).ListBizTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IDGenService_ListBizTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IDGenServiceServer).ListBizTags(ctx, req.(*ListBizTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IDGenService_CreateBizTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBizTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IDGenServiceServer).CreateBizTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IDGenService_CreateBizTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IDGenServiceServer).CreateBizTag(ctx, req.(*CreateBizTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IDGenService_UpdateStep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IDGenServiceServer).UpdateStep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IDGenService_UpdateStep_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IDGenServiceServer).UpdateStep(ctx, req.(*UpdateStepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IDGenService_DeleteBizTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBizTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IDGenServiceServer).DeleteBizTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IDGenService_DeleteBizTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IDGenServiceServer).DeleteBizTag(ctx, req.(*DeleteBizTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IDGenService_GetBufferStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBufferStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IDGenServiceServer).GetBufferStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IDGenService_GetBufferStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IDGenServiceServer).GetBufferStatus(ctx, req.(*GetBufferStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IDGenService_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IDGenServiceServer).GetMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IDGenService_GetMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IDGenServiceServer).GetMetrics(ctx, req.(*GetMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IDGenService_ServiceDesc is the grpc.ServiceDesc for IDGenService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IDGenService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "idgen.v1.IDGenService",
+	HandlerType: (*IDGenServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NextID",
+			Handler:    _IDGenService_NextID_Handler,
+		},
+		{
+			MethodName: "BatchNextID",
+			Handler:    _IDGenService_BatchNextID_Handler,
+		},
+		{
+			MethodName: "NextSegment",
+			Handler:    _IDGenService_NextSegment_Handler,
+		},
+		{
+			MethodName: "ListBizTags",
+			Handler:    _IDGenService_ListBizTags_Handler,
+		},
+		{
+			MethodName: "CreateBizTag",
+			Handler:    _IDGenService_CreateBizTag_Handler,
+		},
+		{
+			MethodName: "UpdateStep",
+			Handler:    _IDGenService_UpdateStep_Handler,
+		},
+		{
+			MethodName: "DeleteBizTag",
+			Handler:    _IDGenService_DeleteBizTag_Handler,
+		},
+		{
+			MethodName: "GetBufferStatus",
+			Handler:    _IDGenService_GetBufferStatus_Handler,
+		},
+		{
+			MethodName: "GetMetrics",
+			Handler:    _IDGenService_GetMetrics_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "idgen.proto",
+}
@@ -5,4 +5,10 @@ import "context"
 type IDGenerator interface {
 	NextID(ctx context.Context, bizTag string) (int64, error)
 	BatchNextID(ctx context.Context, bizTag string, count int) ([]int64, error)
+}
+
+// ID128Generator 生成128位ID的生成器
+type ID128Generator interface {
+	NextID128(ctx context.Context, bizTag string) (ID128, error)
+	BatchNextID128(ctx context.Context, bizTag string, count int) ([]ID128, error)
 }
\ No newline at end of file
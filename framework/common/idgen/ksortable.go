@@ -0,0 +1,235 @@
+package idgen
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// crockfordAlphabet ULID使用的Crockford base32字母表
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+const (
+	// id128CounterBits 同一毫秒内的计数器位数（UUIDv7的rand_a）
+	id128CounterBits = 12
+	id128MaxCounter  = 1<<id128CounterBits - 1
+)
+
+// ErrInvalidID128 128位ID格式错误
+var ErrInvalidID128 = NewLeafError("INVALID_ID128", "invalid 128-bit id")
+
+// ID128 按时间排序的128位ID，兼容UUIDv7与ULID两种字符串格式
+//
+// 布局（从高位到低位）: 48位Unix毫秒时间戳 | 4位版本(7) | 12位毫秒内计数器 |
+// 2位变体(10) | 8位区域ID | 54位随机数。
+// 时间戳和计数器在高位，字节序、UUID字符串和ULID字符串的字典序都与生成顺序一致。
+type ID128 [16]byte
+
+// String ULID格式（26位Crockford base32）
+func (id ID128) String() string {
+	var buf [26]byte
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	// 128位按5位一组编码，首字符只有3位有效
+	for i := 25; i >= 0; i-- {
+		buf[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
+
+// UUID 标准UUID格式（8-4-4-4-12）
+func (id ID128) UUID() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], id[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], id[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], id[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], id[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], id[10:])
+	return string(buf[:])
+}
+
+// Time ID中的时间戳
+func (id ID128) Time() time.Time {
+	return time.UnixMilli(int64(binary.BigEndian.Uint64(id[:8]) >> 16))
+}
+
+// RegionID ID中的区域ID
+func (id ID128) RegionID() uint8 {
+	return id[8]<<2 | id[9]>>6
+}
+
+// MarshalText 实现 encoding.TextMarshaler，使用ULID格式
+func (id ID128) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，支持ULID和UUID格式
+func (id *ID128) UnmarshalText(text []byte) error {
+	parsed, err := ParseID128(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// ParseID128 解析ULID或UUID格式的128位ID
+func ParseID128(s string) (ID128, error) {
+	var id ID128
+	switch len(s) {
+	case 26:
+		// 首字符最大为7，否则超出128位
+		if s[0] > '7' {
+			return id, ErrInvalidID128
+		}
+		var hi, lo uint64
+		for i := 0; i < len(s); i++ {
+			digit := strings.IndexByte(crockfordAlphabet, upperASCII(s[i]))
+			if digit < 0 {
+				return id, ErrInvalidID128
+			}
+			hi = hi<<5 | lo>>59
+			lo = lo<<5 | uint64(digit)
+		}
+		binary.BigEndian.PutUint64(id[:8], hi)
+		binary.BigEndian.PutUint64(id[8:], lo)
+		return id, nil
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return id, ErrInvalidID128
+		}
+		raw := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+		if _, err := hex.Decode(id[:], []byte(raw)); err != nil {
+			return id, ErrInvalidID128
+		}
+		return id, nil
+	default:
+		return id, ErrInvalidID128
+	}
+}
+
+// upperASCII 将小写字母转换为大写，ULID解析不区分大小写
+func upperASCII(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// KSortableIDGenerator 生成按时间排序的ID，适合作为跨实例、跨区域写入的表主键
+//
+// 同一生成器内严格单调递增：同一毫秒内递增计数器，计数器用完或时钟回拨时
+// 沿用上次的时间戳继续递增，不会等待也不会产生重复。不同实例之间按毫秒级时间大致有序。
+// Snowflake ID同样按时间排序，需要int64主键时可使用配置了区域ID的 SnowflakeIDGenerator。
+type KSortableIDGenerator struct {
+	regionID uint8
+	lastMs   int64
+	counter  uint16
+	mutex    sync.Mutex
+	now      func() time.Time // 时钟，便于测试注入
+}
+
+// NewKSortableIDGenerator 创建按时间排序的128位ID生成器，regionID写入每个ID
+func NewKSortableIDGenerator(regionID uint8) *KSortableIDGenerator {
+	return &KSortableIDGenerator{regionID: regionID, now: time.Now}
+}
+
+// NextID128 获取下一个ID，ID全局唯一，bizTag参数仅用于与 IDGenerator 保持一致
+func (g *KSortableIDGenerator) NextID128(ctx context.Context, bizTag string) (ID128, error) {
+	var id ID128
+	if _, err := rand.Read(id[8:]); err != nil {
+		return id, fmt.Errorf("failed to read random bytes: %w", err)
+	}
+
+	g.mutex.Lock()
+	ms := g.now().UnixMilli()
+	if ms > g.lastMs {
+		g.lastMs = ms
+		// 计数器从随机值开始，保留一半空间用于递增
+		g.counter = uint16(binary.BigEndian.Uint16(id[14:]) & (id128MaxCounter >> 1))
+	} else if g.counter < id128MaxCounter {
+		g.counter++
+	} else {
+		// 计数器用完，借用下一毫秒
+		g.lastMs++
+		g.counter = 0
+	}
+	ms, counter := g.lastMs, g.counter
+	g.mutex.Unlock()
+
+	binary.BigEndian.PutUint64(id[:8], uint64(ms)<<16|0x7000|uint64(counter))
+	id[8] = 0x80 | g.regionID>>2
+	id[9] = g.regionID<<6 | id[9]&0x3f
+	return id, nil
+}
+
+// BatchNextID128 批量获取ID
+func (g *KSortableIDGenerator) BatchNextID128(ctx context.Context, bizTag string, count int) ([]ID128, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("count must be positive")
+	}
+
+	ids := make([]ID128, count)
+	for i := range ids {
+		id, err := g.NextID128(ctx, bizTag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ID at index %d: %w", i, err)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// NextULID 获取下一个ULID格式的ID
+func (g *KSortableIDGenerator) NextULID(ctx context.Context, bizTag string) (string, error) {
+	id, err := g.NextID128(ctx, bizTag)
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// NextUUID 获取下一个UUIDv7格式的ID
+func (g *KSortableIDGenerator) NextUUID(ctx context.Context, bizTag string) (string, error) {
+	id, err := g.NextID128(ctx, bizTag)
+	if err != nil {
+		return "", err
+	}
+	return id.UUID(), nil
+}
+
+// RegionID 生成器的区域ID
+func (g *KSortableIDGenerator) RegionID() uint8 {
+	return g.regionID
+}
+
+// NextID 实现 IDGenerator，128位ID无法表示为int64，始终返回 ErrNotSupported，请使用 NextID128
+func (g *KSortableIDGenerator) NextID(ctx context.Context, bizTag string) (int64, error) {
+	return 0, ErrNotSupported
+}
+
+// BatchNextID 实现 IDGenerator，始终返回 ErrNotSupported，请使用 BatchNextID128
+func (g *KSortableIDGenerator) BatchNextID(ctx context.Context, bizTag string, count int) ([]int64, error) {
+	return nil, ErrNotSupported
+}
+
+// isKSortableType 是否为128位有序ID生成器类型
+func isKSortableType(generatorType string) bool {
+	switch generatorType {
+	case "ksortable", "ulid", "uuidv7":
+		return true
+	default:
+		return false
+	}
+}
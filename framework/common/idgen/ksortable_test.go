@@ -0,0 +1,102 @@
+package idgen
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/config"
+)
+
+func TestKSortableIDGenerator_Monotonic(t *testing.T) {
+	generator := NewKSortableIDGenerator(42)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	generator.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	var prev ID128
+	for i := 0; i < 5000; i++ {
+		// 时钟回拨和计数器用完时仍应递增
+		if i == 2500 {
+			now = now.Add(-time.Second)
+		}
+		id, err := generator.NextID128(ctx, "order")
+		if err != nil {
+			t.Fatalf("Failed to generate ID: %v", err)
+		}
+		if i > 0 && (id.String() <= prev.String() || id.UUID() <= prev.UUID()) {
+			t.Fatalf("Expected increasing IDs at %d: %s <= %s", i, id, prev)
+		}
+		if id.RegionID() != 42 {
+			t.Fatalf("Expected region 42, got %d", id.RegionID())
+		}
+		prev = id
+	}
+
+	first, _ := generator.NextID128(ctx, "order")
+	uuid := first.UUID()
+	if uuid[14] != '7' || !strings.ContainsRune("89ab", rune(uuid[19])) {
+		t.Errorf("Expected UUIDv7 version and variant, got %s", uuid)
+	}
+}
+
+func TestID128_Parse(t *testing.T) {
+	generator := NewKSortableIDGenerator(3)
+	id, _ := generator.NextID128(context.Background(), "order")
+
+	for _, s := range []string{id.String(), strings.ToLower(id.String()), id.UUID()} {
+		parsed, err := ParseID128(s)
+		if err != nil || parsed != id {
+			t.Errorf("Expected %s from %q, got %s (%v)", id, s, parsed, err)
+		}
+	}
+	if time.Since(id.Time()) > time.Minute {
+		t.Errorf("Unexpected timestamp %v", id.Time())
+	}
+
+	data, _ := json.Marshal(map[string]ID128{"id": id})
+	var decoded map[string]ID128
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded["id"] != id {
+		t.Errorf("Expected JSON round trip of %s, got %v (%v)", data, decoded, err)
+	}
+
+	for _, invalid := range []string{"", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", "01ARZ3NDEKTSV4RRFFQ69G5FAU", "not-a-uuid-000000000000000000000000"} {
+		if _, err := ParseID128(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestFrameworkIDGenService_KSortable(t *testing.T) {
+	ctx := context.Background()
+	service := &FrameworkIDGenService{config: &config.IDGenConfig{
+		Type:      "ulid",
+		KSortable: config.IDGenKSortableConfig{RegionID: 3},
+	}}
+	generator, err := service.createGenerator(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	service.generator = generator
+
+	id, err := service.NextID128(ctx, "order")
+	if err != nil {
+		t.Fatalf("Failed to get ID128: %v", err)
+	}
+	if id.RegionID() != 3 {
+		t.Errorf("Expected region 3, got %d", id.RegionID())
+	}
+	if _, err := service.NextID(ctx, "order"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported from NextID, got %v", err)
+	}
+
+	leaf := NewLeafIDGenerator(newMemoryLeafDAO(), DefaultLeafConfig())
+	defer leaf.Close()
+	service.generator = leaf
+	if _, err := service.NextID128(ctx, "order"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported from leaf generator, got %v", err)
+	}
+}
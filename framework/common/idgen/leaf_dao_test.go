@@ -0,0 +1,148 @@
+package idgen
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryLeafDAO 测试用内存号段存储，模拟Redis/etcd等共享存储
+type memoryLeafDAO struct {
+	mutex  sync.Mutex
+	allocs map[string]*LeafAlloc
+}
+
+func newMemoryLeafDAO() *memoryLeafDAO {
+	return &memoryLeafDAO{allocs: make(map[string]*LeafAlloc)}
+}
+
+func (dao *memoryLeafDAO) GetLeafAlloc(ctx context.Context, bizTag string) (*LeafAlloc, error) {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	leafAlloc, ok := dao.allocs[bizTag]
+	if !ok {
+		return nil, ErrBizTagNotFound
+	}
+	copied := *leafAlloc
+	return &copied, nil
+}
+
+func (dao *memoryLeafDAO) UpdateMaxID(ctx context.Context, bizTag string, step int32) (*LeafAlloc, error) {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	leafAlloc, ok := dao.allocs[bizTag]
+	if !ok {
+		return nil, ErrBizTagNotFound
+	}
+	leafAlloc.MaxID += int64(step)
+	copied := *leafAlloc
+	return &copied, nil
+}
+
+func (dao *memoryLeafDAO) CreateLeafAlloc(ctx context.Context, bizTag string, step int32, description string) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	if _, ok := dao.allocs[bizTag]; ok {
+		return ErrBizTagExists
+	}
+	dao.allocs[bizTag] = &LeafAlloc{BizTag: bizTag, Step: step, Description: description, UpdateTime: time.Now()}
+	return nil
+}
+
+func (dao *memoryLeafDAO) GetAllBizTags(ctx context.Context) ([]string, error) {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	bizTags := make([]string, 0, len(dao.allocs))
+	for bizTag := range dao.allocs {
+		bizTags = append(bizTags, bizTag)
+	}
+	return bizTags, nil
+}
+
+func (dao *memoryLeafDAO) UpdateStep(ctx context.Context, bizTag string, newStep int32) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	leafAlloc, ok := dao.allocs[bizTag]
+	if !ok {
+		return ErrBizTagNotFound
+	}
+	leafAlloc.Step = newStep
+	return nil
+}
+
+func (dao *memoryLeafDAO) DeleteLeafAlloc(ctx context.Context, bizTag string) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	if _, ok := dao.allocs[bizTag]; !ok {
+		return ErrBizTagNotFound
+	}
+	delete(dao.allocs, bizTag)
+	return nil
+}
+
+func (dao *memoryLeafDAO) GetLeafAllocWithLock(ctx context.Context, bizTag string) (*LeafAlloc, error) {
+	return dao.GetLeafAlloc(ctx, bizTag)
+}
+
+func (dao *memoryLeafDAO) BatchGetLeafAllocs(ctx context.Context, bizTags []string) ([]*LeafAlloc, error) {
+	var leafAllocs []*LeafAlloc
+	for _, bizTag := range bizTags {
+		if leafAlloc, err := dao.GetLeafAlloc(ctx, bizTag); err == nil {
+			leafAllocs = append(leafAllocs, leafAlloc)
+		}
+	}
+	return leafAllocs, nil
+}
+
+func (dao *memoryLeafDAO) CreateTable(ctx context.Context) error {
+	return nil
+}
+
+func TestLeafIDGenerator_SharedDAO(t *testing.T) {
+	dao := newMemoryLeafDAO()
+	config := DefaultLeafConfig()
+	config.DefaultStep = 10
+
+	// 两个实例共享同一存储，模拟多个服务实例
+	generators := []*GormLeafIDGenerator{NewLeafIDGenerator(dao, config), NewLeafIDGenerator(dao, config)}
+	defer func() {
+		for _, generator := range generators {
+			generator.Close()
+		}
+	}()
+
+	ctx := context.Background()
+	var (
+		mutex sync.Mutex
+		wg    sync.WaitGroup
+		seen  = make(map[int64]bool)
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(generator *GormLeafIDGenerator) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				id, err := generator.NextID(ctx, "order")
+				if err != nil {
+					t.Errorf("Failed to generate ID: %v", err)
+					return
+				}
+				mutex.Lock()
+				if seen[id] {
+					t.Errorf("Duplicate ID %d", id)
+				}
+				seen[id] = true
+				mutex.Unlock()
+			}
+		}(generators[i%2])
+	}
+	wg.Wait()
+
+	if len(seen) != 400 {
+		t.Errorf("Expected 400 unique IDs, got %d", len(seen))
+	}
+	if err := dao.CreateLeafAlloc(ctx, "order", 10, ""); err != ErrBizTagExists {
+		t.Errorf("Expected ErrBizTagExists, got %v", err)
+	}
+}
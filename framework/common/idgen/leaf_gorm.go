@@ -53,7 +53,15 @@ func NewGormLeafIDGenerator(db *gorm.DB, config *LeafConfig) *GormLeafIDGenerato
 		config = DefaultLeafConfig()
 	}
 
-	dao := NewGormLeafDAO(db)
+	return NewLeafIDGenerator(NewGormLeafDAO(db), config)
+}
+
+// NewLeafIDGenerator 使用指定的号段存储创建Leaf ID生成器，
+// 可配合 NewRedisLeafDAO、NewEtcdLeafDAO 在没有关系型数据库时使用号段模式
+func NewLeafIDGenerator(dao LeafDAO, config *LeafConfig) *GormLeafIDGenerator {
+	if config == nil {
+		config = DefaultLeafConfig()
+	}
 
 	generator := &GormLeafIDGenerator{
 		dao:      dao,
@@ -152,24 +160,9 @@ func (g *GormLeafIDGenerator) initBuffer(ctx context.Context, bizTag string) (*S
 	}
 
 	// 获取或创建叶子分配记录
-	leafAlloc, err := g.dao.GetLeafAlloc(ctx, bizTag)
+	leafAlloc, err := g.getOrCreateLeafAlloc(ctx, bizTag)
 	if err != nil {
-		if errors.Is(err, ErrBizTagNotFound) {
-			// 自动创建新的业务标识
-			description := fmt.Sprintf("Auto created for %s", bizTag)
-			createErr := g.dao.CreateLeafAlloc(ctx, bizTag, g.config.DefaultStep, description)
-			if createErr != nil {
-				return nil, fmt.Errorf("failed to create leaf alloc: %w", createErr)
-			}
-
-			// 重新获取
-			leafAlloc, err = g.dao.GetLeafAlloc(ctx, bizTag)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get leaf alloc after creation: %w", err)
-			}
-		} else {
-			return nil, fmt.Errorf("failed to get leaf alloc: %w", err)
-		}
+		return nil, err
 	}
 
 	// 初始化指标（在加载segment之前）
@@ -195,6 +188,68 @@ func (g *GormLeafIDGenerator) initBuffer(ctx context.Context, bizTag string) (*S
 	return buffer, nil
 }
 
+// getOrCreateLeafAlloc 获取叶子分配记录，不存在时自动创建
+func (g *GormLeafIDGenerator) getOrCreateLeafAlloc(ctx context.Context, bizTag string) (*LeafAlloc, error) {
+	leafAlloc, err := g.dao.GetLeafAlloc(ctx, bizTag)
+	if err == nil {
+		return leafAlloc, nil
+	}
+	if !errors.Is(err, ErrBizTagNotFound) {
+		return nil, fmt.Errorf("failed to get leaf alloc: %w", err)
+	}
+
+	// 自动创建新的业务标识
+	description := fmt.Sprintf("Auto created for %s", bizTag)
+	createErr := g.dao.CreateLeafAlloc(ctx, bizTag, g.config.DefaultStep, description)
+	// 其他实例可能已并发创建
+	if createErr != nil && !errors.Is(createErr, ErrBizTagExists) {
+		return nil, fmt.Errorf("failed to create leaf alloc: %w", createErr)
+	}
+
+	// 重新获取
+	leafAlloc, err = g.dao.GetLeafAlloc(ctx, bizTag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaf alloc after creation: %w", err)
+	}
+	return leafAlloc, nil
+}
+
+// AllocSegment 直接从存储分配一个号段，不经过本地缓冲，供远程客户端在本地发号。
+// step为0时使用业务标识配置的步长，否则限制在 [MinStepSize, MaxStepSize] 之内
+func (g *GormLeafIDGenerator) AllocSegment(ctx context.Context, bizTag string, step int32) (*LeafSegment, error) {
+	if err := g.ensureTableCreated(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure table created: %w", err)
+	}
+
+	leafAlloc, err := g.getOrCreateLeafAlloc(ctx, bizTag)
+	if err != nil {
+		return nil, err
+	}
+	if step <= 0 {
+		step = leafAlloc.Step
+	} else {
+		step = max(g.config.MinStepSize, min(step, g.config.MaxStepSize))
+	}
+
+	return g.loadSegment(ctx, bizTag, step)
+}
+
+// ListBizTags 列出所有业务标识
+func (g *GormLeafIDGenerator) ListBizTags(ctx context.Context) ([]*LeafAlloc, error) {
+	if err := g.ensureTableCreated(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure table created: %w", err)
+	}
+
+	bizTags, err := g.dao.GetAllBizTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(bizTags) == 0 {
+		return nil, nil
+	}
+	return g.dao.BatchGetLeafAllocs(ctx, bizTags)
+}
+
 // loadSegment 加载号段
 func (g *GormLeafIDGenerator) loadSegment(ctx context.Context, bizTag string, step int32) (*LeafSegment, error) {
 	// 从数据库获取新的号段
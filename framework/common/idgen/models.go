@@ -169,6 +169,8 @@ var (
 	ErrSegmentNotAvailable = NewLeafError("SEGMENT_NOT_AVAILABLE", "segment not available")
 	ErrBufferNotReady      = NewLeafError("BUFFER_NOT_READY", "buffer not ready")
 	ErrBizTagNotFound      = NewLeafError("BIZ_TAG_NOT_FOUND", "biz tag not found")
+	ErrBizTagExists        = NewLeafError("BIZ_TAG_EXISTS", "biz tag already exists")
+	ErrNotSupported        = NewLeafError("NOT_SUPPORTED", "operation not supported by current generator")
 )
 
 // LeafError 自定义错误类型
@@ -0,0 +1,374 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SnowflakeIDGenerator 基于Snowflake算法的分布式ID生成器（无需数据库）
+//
+// ID布局（从高位到低位）: 符号位(0) | 时间戳 | 区域ID | 机器ID | 序列号
+// 时间戳在最高位，多个实例、多个区域生成的ID大致按时间排序；区域ID位数默认为0。
+// 机器ID由 WorkerIDAssigner 分配，支持静态配置以及通过Redis/etcd自动租约分配。
+// Snowflake ID全局唯一，与业务标识无关，bizTag参数仅用于兼容 IDGenerator 接口。
+type SnowflakeIDGenerator struct {
+	config   *SnowflakeConfig
+	assigner WorkerIDAssigner
+
+	epochMillis    int64 // 起始时间（毫秒）
+	workerID       int64 // 当前持有的机器ID
+	maxWorkerID    int64 // 机器ID最大值
+	maxRegionID    int64 // 区域ID最大值
+	maxSequence    int64 // 序列号最大值
+	maxTimestamp   int64 // 时间戳最大值
+	workerShift    uint8 // 机器ID左移位数
+	regionShift    uint8 // 区域ID左移位数
+	timestampShift uint8 // 时间戳左移位数
+
+	lastTimestamp int64 // 上次生成ID的时间戳（相对epoch的毫秒数）
+	sequence      int64 // 当前毫秒内的序列号
+	mutex         sync.Mutex
+
+	leaseDeadline int64 // 机器ID租约截止时间（UnixNano），0表示永不过期
+	metrics       *LeafMetrics
+	now           func() time.Time // 时钟，便于测试注入
+	stopChan      chan struct{}
+	wg            sync.WaitGroup
+	closeOnce     sync.Once
+}
+
+// SnowflakeConfig Snowflake配置
+type SnowflakeConfig struct {
+	Epoch           time.Time     `json:"epoch"`             // 起始时间
+	TimestampBits   uint8         `json:"timestamp_bits"`    // 时间戳位数
+	WorkerIDBits    uint8         `json:"worker_id_bits"`    // 机器ID位数
+	SequenceBits    uint8         `json:"sequence_bits"`     // 序列号位数
+	RegionBits      uint8         `json:"region_bits"`       // 区域（数据中心）ID位数，0表示不区分区域
+	RegionID        int64         `json:"region_id"`         // 区域（数据中心）ID
+	WorkerID        int64         `json:"worker_id"`         // 静态机器ID（worker_id_source为static时生效）
+	WorkerIDSource  string        `json:"worker_id_source"`  // 机器ID来源 (static, redis, etcd)
+	KeyPrefix       string        `json:"key_prefix"`        // 机器ID租约键前缀
+	LeaseTTL        time.Duration `json:"lease_ttl"`         // 机器ID租约有效期
+	MaxBackwardWait time.Duration `json:"max_backward_wait"` // 允许等待的最大时钟回拨时长
+}
+
+// DefaultSnowflakeConfig 默认配置（41位时间戳 + 10位机器ID + 12位序列号）
+func DefaultSnowflakeConfig() *SnowflakeConfig {
+	return &SnowflakeConfig{
+		Epoch:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		TimestampBits:   41,
+		WorkerIDBits:    10,
+		SequenceBits:    12,
+		WorkerIDSource:  WorkerIDSourceStatic,
+		KeyPrefix:       "idgen:snowflake:worker:",
+		LeaseTTL:        30 * time.Second,
+		MaxBackwardWait: 10 * time.Millisecond,
+	}
+}
+
+// Validate 校验配置
+func (c *SnowflakeConfig) Validate() error {
+	if c.TimestampBits == 0 || c.WorkerIDBits == 0 || c.SequenceBits == 0 {
+		return fmt.Errorf("snowflake bits must be positive")
+	}
+	if int(c.TimestampBits)+int(c.RegionBits)+int(c.WorkerIDBits)+int(c.SequenceBits) > 63 {
+		return fmt.Errorf("snowflake bits exceed 63: timestamp=%d region=%d worker=%d sequence=%d",
+			c.TimestampBits, c.RegionBits, c.WorkerIDBits, c.SequenceBits)
+	}
+	if c.Epoch.After(time.Now()) {
+		return fmt.Errorf("snowflake epoch %s is in the future", c.Epoch.Format(time.RFC3339))
+	}
+	maxWorkerID := int64(1)<<c.WorkerIDBits - 1
+	if c.WorkerID < 0 || c.WorkerID > maxWorkerID {
+		return fmt.Errorf("worker id %d is out of range [0, %d]", c.WorkerID, maxWorkerID)
+	}
+	maxRegionID := int64(1)<<c.RegionBits - 1
+	if c.RegionID < 0 || c.RegionID > maxRegionID {
+		return fmt.Errorf("region id %d is out of range [0, %d]", c.RegionID, maxRegionID)
+	}
+	return nil
+}
+
+// workerKeyPrefix 机器ID租约键前缀，启用区域时按区域隔离，多个区域共用存储也不会冲突
+func (c *SnowflakeConfig) workerKeyPrefix() string {
+	if c.RegionBits == 0 {
+		return c.KeyPrefix
+	}
+	return fmt.Sprintf("%sregion-%d:", c.KeyPrefix, c.RegionID)
+}
+
+// SnowflakeIDParts Snowflake ID的组成部分
+type SnowflakeIDParts struct {
+	Timestamp time.Time `json:"timestamp"`
+	RegionID  int64     `json:"region_id"`
+	WorkerID  int64     `json:"worker_id"`
+	Sequence  int64     `json:"sequence"`
+}
+
+// 预定义错误
+var (
+	ErrClockMovedBackwards = NewLeafError("CLOCK_MOVED_BACKWARDS", "clock moved backwards")
+	ErrTimestampOverflow   = NewLeafError("TIMESTAMP_OVERFLOW", "snowflake timestamp overflow")
+	ErrWorkerIDLeaseLost   = NewLeafError("WORKER_ID_LEASE_LOST", "worker id lease lost")
+)
+
+// NewSnowflakeIDGenerator 创建新的Snowflake ID生成器，assigner为nil时使用配置中的静态机器ID
+func NewSnowflakeIDGenerator(ctx context.Context, config *SnowflakeConfig, assigner WorkerIDAssigner) (*SnowflakeIDGenerator, error) {
+	if config == nil {
+		config = DefaultSnowflakeConfig()
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if assigner == nil {
+		assigner = NewStaticWorkerIDAssigner(config.WorkerID)
+	}
+
+	g := &SnowflakeIDGenerator{
+		config:         config,
+		assigner:       assigner,
+		epochMillis:    config.Epoch.UnixMilli(),
+		maxWorkerID:    int64(1)<<config.WorkerIDBits - 1,
+		maxRegionID:    int64(1)<<config.RegionBits - 1,
+		maxSequence:    int64(1)<<config.SequenceBits - 1,
+		maxTimestamp:   int64(1)<<config.TimestampBits - 1,
+		workerShift:    config.SequenceBits,
+		regionShift:    config.SequenceBits + config.WorkerIDBits,
+		timestampShift: config.SequenceBits + config.WorkerIDBits + config.RegionBits,
+		lastTimestamp:  -1,
+		metrics:        NewLeafMetrics(),
+		now:            time.Now,
+		stopChan:       make(chan struct{}),
+	}
+
+	// 申请机器ID
+	workerID, err := assigner.Acquire(ctx, g.maxWorkerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire worker id: %w", err)
+	}
+	g.workerID = workerID
+
+	// 启动租约续期协程
+	if ttl := assigner.LeaseTTL(); ttl > 0 {
+		atomic.StoreInt64(&g.leaseDeadline, g.now().Add(ttl).UnixNano())
+		g.startRenewWorker(ttl)
+	}
+
+	return g, nil
+}
+
+// NextID 获取下一个ID
+func (g *SnowflakeIDGenerator) NextID(ctx context.Context, bizTag string) (int64, error) {
+	g.metrics.IncTotalRequests()
+
+	g.mutex.Lock()
+	id, err := g.nextIDLocked(ctx)
+	g.mutex.Unlock()
+
+	if err != nil {
+		g.metrics.IncFailedRequests()
+		return 0, err
+	}
+	g.metrics.IncSuccessRequests()
+	return id, nil
+}
+
+// BatchNextID 批量获取ID
+func (g *SnowflakeIDGenerator) BatchNextID(ctx context.Context, bizTag string, count int) ([]int64, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("count must be positive")
+	}
+
+	ids := make([]int64, count)
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for i := 0; i < count; i++ {
+		g.metrics.IncTotalRequests()
+		id, err := g.nextIDLocked(ctx)
+		if err != nil {
+			g.metrics.IncFailedRequests()
+			return nil, fmt.Errorf("failed to get ID at index %d: %w", i, err)
+		}
+		g.metrics.IncSuccessRequests()
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// nextIDLocked 生成ID（调用方需持有mutex）
+func (g *SnowflakeIDGenerator) nextIDLocked(ctx context.Context) (int64, error) {
+	// 租约过期后机器ID可能已被其他实例占用，拒绝继续发号
+	if deadline := atomic.LoadInt64(&g.leaseDeadline); deadline > 0 && g.now().UnixNano() > deadline {
+		return 0, ErrWorkerIDLeaseLost
+	}
+
+	timestamp := g.currentMillis()
+
+	// 时钟回拨检测
+	if timestamp < g.lastTimestamp {
+		offset := time.Duration(g.lastTimestamp-timestamp) * time.Millisecond
+		if offset > g.config.MaxBackwardWait {
+			return 0, fmt.Errorf("%w: refusing to generate id for %s", ErrClockMovedBackwards, offset)
+		}
+
+		// 小幅回拨，等待时钟追上
+		timer := time.NewTimer(offset)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return 0, ctx.Err()
+		case <-timer.C:
+		}
+
+		timestamp = g.currentMillis()
+		if timestamp < g.lastTimestamp {
+			return 0, fmt.Errorf("%w: clock still behind after waiting %s", ErrClockMovedBackwards, offset)
+		}
+	}
+
+	if timestamp == g.lastTimestamp {
+		g.sequence = (g.sequence + 1) & g.maxSequence
+		if g.sequence == 0 {
+			// 当前毫秒序列号用完，等待下一毫秒
+			timestamp = g.waitNextMillis(g.lastTimestamp)
+		}
+	} else {
+		g.sequence = 0
+	}
+
+	if timestamp > g.maxTimestamp {
+		return 0, ErrTimestampOverflow
+	}
+
+	g.lastTimestamp = timestamp
+
+	return timestamp<<g.timestampShift | g.config.RegionID<<g.regionShift | g.workerID<<g.workerShift | g.sequence, nil
+}
+
+// currentMillis 当前时间相对epoch的毫秒数
+func (g *SnowflakeIDGenerator) currentMillis() int64 {
+	return g.now().UnixMilli() - g.epochMillis
+}
+
+// waitNextMillis 自旋等待直到进入下一毫秒
+func (g *SnowflakeIDGenerator) waitNextMillis(last int64) int64 {
+	timestamp := g.currentMillis()
+	for timestamp <= last {
+		time.Sleep(100 * time.Microsecond)
+		timestamp = g.currentMillis()
+	}
+	return timestamp
+}
+
+// Decompose 解析ID的组成部分
+func (g *SnowflakeIDGenerator) Decompose(id int64) SnowflakeIDParts {
+	timestamp := id >> g.timestampShift
+	return SnowflakeIDParts{
+		Timestamp: time.UnixMilli(g.epochMillis + timestamp),
+		RegionID:  (id >> g.regionShift) & g.maxRegionID,
+		WorkerID:  (id >> g.workerShift) & g.maxWorkerID,
+		Sequence:  id & g.maxSequence,
+	}
+}
+
+// WorkerID 获取当前持有的机器ID
+func (g *SnowflakeIDGenerator) WorkerID() int64 {
+	return g.workerID
+}
+
+// GetMetrics 获取指标
+func (g *SnowflakeIDGenerator) GetMetrics(bizTag string) *LeafMetrics {
+	metrics := &LeafMetrics{
+		TotalRequests:   atomic.LoadInt64(&g.metrics.TotalRequests),
+		SuccessRequests: atomic.LoadInt64(&g.metrics.SuccessRequests),
+		FailedRequests:  atomic.LoadInt64(&g.metrics.FailedRequests),
+		LastUpdateTime:  g.metrics.LastUpdateTime,
+	}
+	metrics.CalculateQPS()
+	return metrics
+}
+
+// GetBufferStatus 获取生成器状态
+func (g *SnowflakeIDGenerator) GetBufferStatus(bizTag string) map[string]interface{} {
+	g.mutex.Lock()
+	lastTimestamp := g.lastTimestamp
+	sequence := g.sequence
+	g.mutex.Unlock()
+
+	status := map[string]interface{}{
+		"biz_tag":          bizTag,
+		"type":             "snowflake",
+		"region_id":        g.config.RegionID,
+		"worker_id":        g.workerID,
+		"worker_id_source": g.config.WorkerIDSource,
+		"epoch":            g.config.Epoch,
+		"last_timestamp":   lastTimestamp,
+		"sequence":         sequence,
+	}
+	if deadline := atomic.LoadInt64(&g.leaseDeadline); deadline > 0 {
+		status["lease_deadline"] = time.Unix(0, deadline)
+	}
+	return status
+}
+
+// startRenewWorker 启动机器ID租约续期协程
+func (g *SnowflakeIDGenerator) startRenewWorker(ttl time.Duration) {
+	interval := ttl / 3
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-g.stopChan:
+				return
+			case <-ticker.C:
+				// 以发起续期的时间计算截止时间，避免网络延迟导致本地截止时间晚于服务端
+				renewedAt := g.now()
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				err := g.assigner.Renew(ctx)
+				cancel()
+				if err != nil {
+					log.Printf("Failed to renew snowflake worker id %d: %v", g.workerID, err)
+					if errors.Is(err, ErrWorkerIDLeaseLost) {
+						// 机器ID已被其他实例占用，立即停止发号
+						atomic.StoreInt64(&g.leaseDeadline, 1)
+						return
+					}
+					// 临时故障不立即停止发号，租约截止时间到达后NextID会拒绝服务
+					continue
+				}
+				atomic.StoreInt64(&g.leaseDeadline, renewedAt.Add(ttl).UnixNano())
+			}
+		}
+	}()
+}
+
+// Close 关闭生成器并释放机器ID
+func (g *SnowflakeIDGenerator) Close() error {
+	var err error
+	g.closeOnce.Do(func() {
+		close(g.stopChan)
+		g.wg.Wait()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err = g.assigner.Release(ctx)
+	})
+	return err
+}
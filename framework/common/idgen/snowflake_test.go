@@ -0,0 +1,277 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	frameworkconfig "github.com/qiaojinxia/distributed-service/framework/config"
+)
+
+// fakeWorkerIDAssigner 测试用机器ID分配器
+type fakeWorkerIDAssigner struct {
+	workerID int64
+	ttl      time.Duration
+	renewErr error
+	mutex    sync.Mutex
+	released bool
+}
+
+func (a *fakeWorkerIDAssigner) Acquire(ctx context.Context, maxWorkerID int64) (int64, error) {
+	return a.workerID, nil
+}
+
+func (a *fakeWorkerIDAssigner) Renew(ctx context.Context) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.renewErr
+}
+
+func (a *fakeWorkerIDAssigner) Release(ctx context.Context) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.released = true
+	return nil
+}
+
+func (a *fakeWorkerIDAssigner) LeaseTTL() time.Duration {
+	return a.ttl
+}
+
+func TestSnowflakeIDGenerator_Uniqueness(t *testing.T) {
+	config := DefaultSnowflakeConfig()
+	config.WorkerID = 7
+
+	generator, err := NewSnowflakeIDGenerator(context.Background(), config, nil)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	defer generator.Close()
+
+	const goroutines = 10
+	const idsPerGoroutine = 2000
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	seen := make(map[int64]bool, goroutines*idsPerGoroutine)
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids, err := generator.BatchNextID(context.Background(), "any", idsPerGoroutine)
+			if err != nil {
+				t.Errorf("Failed to generate IDs: %v", err)
+				return
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, id := range ids {
+				if seen[id] {
+					t.Errorf("Duplicate ID: %d", id)
+				}
+				seen[id] = true
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != goroutines*idsPerGoroutine {
+		t.Errorf("Expected %d unique IDs, got %d", goroutines*idsPerGoroutine, len(seen))
+	}
+}
+
+func TestSnowflakeIDGenerator_Decompose(t *testing.T) {
+	config := DefaultSnowflakeConfig()
+	config.WorkerID = 513
+
+	generator, err := NewSnowflakeIDGenerator(context.Background(), config, nil)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	defer generator.Close()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	generator.now = func() time.Time { return now }
+
+	id1, _ := generator.NextID(context.Background(), "test")
+	id2, _ := generator.NextID(context.Background(), "test")
+
+	parts := generator.Decompose(id2)
+	if !parts.Timestamp.Equal(now) {
+		t.Errorf("Expected timestamp %v, got %v", now, parts.Timestamp)
+	}
+	if parts.WorkerID != 513 {
+		t.Errorf("Expected worker id 513, got %d", parts.WorkerID)
+	}
+	if parts.Sequence != 1 {
+		t.Errorf("Expected sequence 1, got %d", parts.Sequence)
+	}
+	if id2 <= id1 {
+		t.Errorf("Expected id2 (%d) > id1 (%d)", id2, id1)
+	}
+}
+
+func TestSnowflakeIDGenerator_Region(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var ids []int64
+	for _, regionID := range []int64{5, 2} {
+		config := DefaultSnowflakeConfig()
+		config.RegionBits = 3
+		config.WorkerIDBits = 7
+		config.RegionID = regionID
+		config.WorkerID = 100
+
+		generator, err := NewSnowflakeIDGenerator(context.Background(), config, nil)
+		if err != nil {
+			t.Fatalf("Failed to create generator: %v", err)
+		}
+		generator.now = func() time.Time { return now }
+		now = now.Add(time.Millisecond)
+
+		id, _ := generator.NextID(context.Background(), "order")
+		parts := generator.Decompose(id)
+		if parts.RegionID != regionID || parts.WorkerID != 100 {
+			t.Errorf("Expected region %d worker 100, got %+v", regionID, parts)
+		}
+		ids = append(ids, id)
+		generator.Close()
+	}
+
+	// 时间戳在区域ID之前，后生成的ID更大
+	if ids[1] <= ids[0] {
+		t.Errorf("Expected time-ordered IDs across regions, got %d then %d", ids[0], ids[1])
+	}
+
+	config := DefaultSnowflakeConfig()
+	config.RegionBits = 1
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error when bits exceed 63")
+	}
+}
+
+func TestSnowflakeIDGenerator_ClockMovedBackwards(t *testing.T) {
+	config := DefaultSnowflakeConfig()
+	config.MaxBackwardWait = 5 * time.Millisecond
+
+	generator, err := NewSnowflakeIDGenerator(context.Background(), config, nil)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	defer generator.Close()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	generator.now = func() time.Time { return now }
+
+	if _, err := generator.NextID(context.Background(), "test"); err != nil {
+		t.Fatalf("Failed to generate ID: %v", err)
+	}
+
+	// 超过允许等待时长的回拨直接返回错误
+	now = now.Add(-time.Second)
+	if _, err := generator.NextID(context.Background(), "test"); !errors.Is(err, ErrClockMovedBackwards) {
+		t.Errorf("Expected ErrClockMovedBackwards, got %v", err)
+	}
+
+	// 时钟恢复后继续发号
+	now = now.Add(2 * time.Second)
+	if _, err := generator.NextID(context.Background(), "test"); err != nil {
+		t.Errorf("Expected recovery after clock caught up, got %v", err)
+	}
+}
+
+func TestSnowflakeIDGenerator_LeaseLost(t *testing.T) {
+	assigner := &fakeWorkerIDAssigner{workerID: 3, ttl: 30 * time.Millisecond}
+
+	generator, err := NewSnowflakeIDGenerator(context.Background(), DefaultSnowflakeConfig(), assigner)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	if _, err := generator.NextID(context.Background(), "test"); err != nil {
+		t.Fatalf("Failed to generate ID: %v", err)
+	}
+
+	assigner.mutex.Lock()
+	assigner.renewErr = ErrWorkerIDLeaseLost
+	assigner.mutex.Unlock()
+
+	time.Sleep(60 * time.Millisecond)
+
+	if _, err := generator.NextID(context.Background(), "test"); !errors.Is(err, ErrWorkerIDLeaseLost) {
+		t.Errorf("Expected ErrWorkerIDLeaseLost, got %v", err)
+	}
+
+	generator.Close()
+	if !assigner.released {
+		t.Error("Expected worker id to be released on close")
+	}
+}
+
+func TestSnowflakeConfig_Validate(t *testing.T) {
+	config := DefaultSnowflakeConfig()
+	config.TimestampBits = 42
+	config.WorkerIDBits = 10
+	config.SequenceBits = 12
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error when bits exceed 63")
+	}
+
+	config = DefaultSnowflakeConfig()
+	config.WorkerIDBits = 5
+	config.WorkerID = 32
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error when worker id is out of range")
+	}
+
+	config = DefaultSnowflakeConfig()
+	config.Epoch = time.Now().Add(time.Hour)
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error when epoch is in the future")
+	}
+}
+
+func TestSnowflakeConfig_Settings(t *testing.T) {
+	config := DefaultSnowflakeConfig()
+	err := decodeSettings(map[string]interface{}{
+		"lease_ttl":         "45s",
+		"max_backward_wait": "20ms",
+		"epoch":             "2025-01-01T00:00:00Z",
+		"worker_id":         "7",
+	}, config)
+	if err != nil {
+		t.Fatalf("Failed to decode settings: %v", err)
+	}
+	if config.LeaseTTL != 45*time.Second || config.MaxBackwardWait != 20*time.Millisecond {
+		t.Errorf("Expected durations 45s and 20ms, got %v and %v", config.LeaseTTL, config.MaxBackwardWait)
+	}
+	if !config.Epoch.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) || config.WorkerID != 7 {
+		t.Errorf("Unexpected epoch or worker id: %v, %d", config.Epoch, config.WorkerID)
+	}
+	if config.SequenceBits != 12 {
+		t.Errorf("Expected unset fields to keep defaults, got sequence bits %d", config.SequenceBits)
+	}
+
+	// 无效配置应返回错误而不是被忽略
+	_, err = NewIDGeneratorFromConfig(Config{
+		Type:      "snowflake",
+		Snowflake: DefaultSnowflakeConfig(),
+		Settings:  map[string]interface{}{"lease_ttl": "thirty seconds"},
+	})
+	if err == nil {
+		t.Error("Expected error for invalid lease_ttl")
+	}
+
+	// 框架配置中的无效时长同样返回错误
+	for _, cfg := range []frameworkconfig.IDGenSnowflakeConfig{
+		{LeaseTTL: "thirty seconds"},
+		{MaxBackwardWait: "10"},
+	} {
+		service := &FrameworkIDGenService{config: &frameworkconfig.IDGenConfig{Type: "snowflake", Snowflake: cfg}}
+		if _, err := service.createSnowflakeConfig(); err == nil {
+			t.Errorf("Expected error for invalid framework snowflake config %+v", cfg)
+		}
+	}
+}
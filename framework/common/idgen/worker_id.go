@@ -0,0 +1,326 @@
+package idgen
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/qiaojinxia/distributed-service/framework/database"
+	"github.com/qiaojinxia/distributed-service/pkg/etcd"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// 机器ID来源
+const (
+	WorkerIDSourceStatic = "static" // 静态配置
+	WorkerIDSourceRedis  = "redis"  // Redis租约
+	WorkerIDSourceEtcd   = "etcd"   // etcd租约
+)
+
+// ErrNoWorkerIDAvailable 所有机器ID均已被占用
+var ErrNoWorkerIDAvailable = NewLeafError("NO_WORKER_ID_AVAILABLE", "no worker id available")
+
+// WorkerIDAssigner 机器ID分配器接口
+type WorkerIDAssigner interface {
+	// Acquire 申请一个 [0, maxWorkerID] 范围内未被占用的机器ID
+	Acquire(ctx context.Context, maxWorkerID int64) (int64, error)
+
+	// Renew 续期当前持有的机器ID，机器ID已被其他实例占用时返回 ErrWorkerIDLeaseLost
+	Renew(ctx context.Context) error
+
+	// Release 释放当前持有的机器ID
+	Release(ctx context.Context) error
+
+	// LeaseTTL 租约有效期，0表示永不过期
+	LeaseTTL() time.Duration
+}
+
+// NewWorkerIDAssigner 根据配置创建机器ID分配器，redis/etcd来源使用框架已初始化的客户端
+func NewWorkerIDAssigner(config *SnowflakeConfig) (WorkerIDAssigner, error) {
+	switch config.WorkerIDSource {
+	case WorkerIDSourceStatic, "":
+		return NewStaticWorkerIDAssigner(config.WorkerID), nil
+	case WorkerIDSourceRedis:
+		if database.RedisClient == nil {
+			return nil, fmt.Errorf("framework redis not initialized")
+		}
+		return NewRedisWorkerIDAssigner(database.RedisClient, config.workerKeyPrefix(), config.LeaseTTL), nil
+	case WorkerIDSourceEtcd:
+		client := etcd.GetClient()
+		if client == nil {
+			return nil, fmt.Errorf("framework etcd not initialized")
+		}
+		return NewEtcdWorkerIDAssigner(client, config.workerKeyPrefix(), config.LeaseTTL), nil
+	default:
+		return nil, fmt.Errorf("unsupported worker id source: %s", config.WorkerIDSource)
+	}
+}
+
+// StaticWorkerIDAssigner 静态机器ID分配器
+type StaticWorkerIDAssigner struct {
+	workerID int64
+}
+
+// NewStaticWorkerIDAssigner 创建静态机器ID分配器
+func NewStaticWorkerIDAssigner(workerID int64) *StaticWorkerIDAssigner {
+	return &StaticWorkerIDAssigner{workerID: workerID}
+}
+
+// Acquire 返回配置的机器ID
+func (a *StaticWorkerIDAssigner) Acquire(ctx context.Context, maxWorkerID int64) (int64, error) {
+	if a.workerID < 0 || a.workerID > maxWorkerID {
+		return 0, fmt.Errorf("worker id %d is out of range [0, %d]", a.workerID, maxWorkerID)
+	}
+	return a.workerID, nil
+}
+
+// Renew 静态机器ID无需续期
+func (a *StaticWorkerIDAssigner) Renew(ctx context.Context) error {
+	return nil
+}
+
+// Release 静态机器ID无需释放
+func (a *StaticWorkerIDAssigner) Release(ctx context.Context) error {
+	return nil
+}
+
+// LeaseTTL 静态机器ID永不过期
+func (a *StaticWorkerIDAssigner) LeaseTTL() time.Duration {
+	return 0
+}
+
+// RedisWorkerIDAssigner 基于Redis SET NX + 过期时间的机器ID分配器
+type RedisWorkerIDAssigner struct {
+	client    *redis.Client
+	keyPrefix string
+	ttl       time.Duration
+	owner     string // 租约持有者标识
+
+	workerID int64
+	key      string
+	mutex    sync.Mutex
+}
+
+// NewRedisWorkerIDAssigner 创建Redis机器ID分配器
+func NewRedisWorkerIDAssigner(client *redis.Client, keyPrefix string, ttl time.Duration) *RedisWorkerIDAssigner {
+	if keyPrefix == "" {
+		keyPrefix = DefaultSnowflakeConfig().KeyPrefix
+	}
+	if ttl <= 0 {
+		ttl = DefaultSnowflakeConfig().LeaseTTL
+	}
+	return &RedisWorkerIDAssigner{
+		client:    client,
+		keyPrefix: keyPrefix,
+		ttl:       ttl,
+		owner:     newWorkerOwner(),
+		workerID:  -1,
+	}
+}
+
+// Acquire 从随机位置开始依次尝试占用机器ID
+func (a *RedisWorkerIDAssigner) Acquire(ctx context.Context, maxWorkerID int64) (int64, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	start := randomWorkerID(maxWorkerID)
+	for i := int64(0); i <= maxWorkerID; i++ {
+		workerID := (start + i) % (maxWorkerID + 1)
+		key := a.keyPrefix + strconv.FormatInt(workerID, 10)
+
+		ok, err := a.client.SetNX(ctx, key, a.owner, a.ttl).Result()
+		if err != nil {
+			return 0, fmt.Errorf("failed to acquire worker id %d: %w", workerID, err)
+		}
+		if ok {
+			a.workerID = workerID
+			a.key = key
+			return workerID, nil
+		}
+	}
+
+	return 0, ErrNoWorkerIDAvailable
+}
+
+// Renew 仅当租约仍由自己持有时续期
+func (a *RedisWorkerIDAssigner) Renew(ctx context.Context) error {
+	a.mutex.Lock()
+	key := a.key
+	a.mutex.Unlock()
+
+	if key == "" {
+		return ErrWorkerIDLeaseLost
+	}
+
+	luaScript := `
+		if redis.call("GET", KEYS[1]) == ARGV[1] then
+			return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+		else
+			return 0
+		end
+	`
+
+	result, err := a.client.Eval(ctx, luaScript, []string{key}, a.owner, a.ttl.Milliseconds()).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to renew worker id: %w", err)
+	}
+	if result == 0 {
+		return ErrWorkerIDLeaseLost
+	}
+	return nil
+}
+
+// Release 仅当租约仍由自己持有时删除
+func (a *RedisWorkerIDAssigner) Release(ctx context.Context) error {
+	a.mutex.Lock()
+	key := a.key
+	a.key = ""
+	a.workerID = -1
+	a.mutex.Unlock()
+
+	if key == "" {
+		return nil
+	}
+
+	luaScript := `
+		if redis.call("GET", KEYS[1]) == ARGV[1] then
+			return redis.call("DEL", KEYS[1])
+		else
+			return 0
+		end
+	`
+
+	if err := a.client.Eval(ctx, luaScript, []string{key}, a.owner).Err(); err != nil {
+		return fmt.Errorf("failed to release worker id: %w", err)
+	}
+	return nil
+}
+
+// LeaseTTL 租约有效期
+func (a *RedisWorkerIDAssigner) LeaseTTL() time.Duration {
+	return a.ttl
+}
+
+// EtcdWorkerIDAssigner 基于etcd租约的机器ID分配器
+type EtcdWorkerIDAssigner struct {
+	client    *etcd.Client
+	keyPrefix string
+	ttl       time.Duration
+	owner     string
+
+	leaseID clientv3.LeaseID
+	mutex   sync.Mutex
+}
+
+// NewEtcdWorkerIDAssigner 创建etcd机器ID分配器
+func NewEtcdWorkerIDAssigner(client *etcd.Client, keyPrefix string, ttl time.Duration) *EtcdWorkerIDAssigner {
+	if keyPrefix == "" {
+		keyPrefix = DefaultSnowflakeConfig().KeyPrefix
+	}
+	if ttl < time.Second {
+		ttl = DefaultSnowflakeConfig().LeaseTTL
+	}
+	return &EtcdWorkerIDAssigner{
+		client:    client,
+		keyPrefix: keyPrefix,
+		ttl:       ttl,
+		owner:     newWorkerOwner(),
+	}
+}
+
+// Acquire 申请租约，并通过事务占用第一个未被创建的机器ID键
+func (a *EtcdWorkerIDAssigner) Acquire(ctx context.Context, maxWorkerID int64) (int64, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	lease, err := a.client.GrantLease(ctx, int64(a.ttl.Seconds()))
+	if err != nil {
+		return 0, err
+	}
+
+	start := randomWorkerID(maxWorkerID)
+	for i := int64(0); i <= maxWorkerID; i++ {
+		workerID := (start + i) % (maxWorkerID + 1)
+		key := a.keyPrefix + strconv.FormatInt(workerID, 10)
+
+		resp, err := a.client.Transaction(ctx,
+			[]clientv3.Cmp{clientv3.Compare(clientv3.CreateRevision(key), "=", 0)},
+			[]clientv3.Op{clientv3.OpPut(key, a.owner, clientv3.WithLease(lease.ID))},
+			nil,
+		)
+		if err != nil {
+			_ = a.client.RevokeLease(context.Background(), lease.ID)
+			return 0, fmt.Errorf("failed to acquire worker id %d: %w", workerID, err)
+		}
+		if resp.Succeeded {
+			a.leaseID = lease.ID
+			return workerID, nil
+		}
+	}
+
+	_ = a.client.RevokeLease(context.Background(), lease.ID)
+	return 0, ErrNoWorkerIDAvailable
+}
+
+// Renew 续期租约
+func (a *EtcdWorkerIDAssigner) Renew(ctx context.Context) error {
+	a.mutex.Lock()
+	leaseID := a.leaseID
+	a.mutex.Unlock()
+
+	if leaseID == clientv3.NoLease {
+		return ErrWorkerIDLeaseLost
+	}
+
+	if _, err := a.client.GetClient().KeepAliveOnce(ctx, leaseID); err != nil {
+		if errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			return ErrWorkerIDLeaseLost
+		}
+		return fmt.Errorf("failed to renew worker id lease: %w", err)
+	}
+	return nil
+}
+
+// Release 撤销租约，机器ID键随之删除
+func (a *EtcdWorkerIDAssigner) Release(ctx context.Context) error {
+	a.mutex.Lock()
+	leaseID := a.leaseID
+	a.leaseID = clientv3.NoLease
+	a.mutex.Unlock()
+
+	if leaseID == clientv3.NoLease {
+		return nil
+	}
+	return a.client.RevokeLease(ctx, leaseID)
+}
+
+// LeaseTTL 租约有效期
+func (a *EtcdWorkerIDAssigner) LeaseTTL() time.Duration {
+	return a.ttl
+}
+
+// newWorkerOwner 生成租约持有者标识（主机名:进程号:随机串）
+func newWorkerOwner() string {
+	hostname, _ := os.Hostname()
+	bytes := make([]byte, 8)
+	_, _ = rand.Read(bytes)
+	return fmt.Sprintf("%s:%d:%s", hostname, os.Getpid(), hex.EncodeToString(bytes))
+}
+
+// randomWorkerID 随机选择起始机器ID，降低多实例同时启动时的冲突
+func randomWorkerID(maxWorkerID int64) int64 {
+	n, err := rand.Int(rand.Reader, big.NewInt(maxWorkerID+1))
+	if err != nil {
+		return 0
+	}
+	return n.Int64()
+}
@@ -0,0 +1,275 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/logger"
+	"github.com/qiaojinxia/distributed-service/pkg/etcd"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+// EtcdLock etcd分布式锁实现
+//
+// 每次加锁申请一个租约并写入 prefix+key+"/"+租约ID 作为排队键，创建revision最小的键持有锁。
+// 等待者只监听排在自己前一位的键被删除，按申请顺序公平获取，无需轮询，
+// 算法与etcd官方 concurrency.Mutex 一致。持有者崩溃时租约到期，排队键随之删除。
+type EtcdLock struct {
+	client *etcd.Client
+	prefix string
+}
+
+// NewEtcdLock 创建etcd分布式锁
+func NewEtcdLock(client *etcd.Client, prefix string) *EtcdLock {
+	if prefix == "" {
+		prefix = "/lock/"
+	}
+	return &EtcdLock{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Lock 获取锁（阻塞直到获取成功或上下文取消）
+func (e *EtcdLock) Lock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
+	return e.acquire(ctx, key, ttl, true)
+}
+
+// TryLock 尝试获取锁（不阻塞）
+func (e *EtcdLock) TryLock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
+	return e.acquire(ctx, key, ttl, false)
+}
+
+// LockWithRetry 带超时的获取锁
+//
+// etcd锁通过watch等待，不需要按间隔重试；maxRetries大于0时最长等待 retryInterval*maxRetries，
+// 超时返回 ErrLockTimeout，否则与 Lock 相同。
+func (e *EtcdLock) LockWithRetry(ctx context.Context, key string, ttl time.Duration, retryInterval time.Duration, maxRetries int) (*Handle, error) {
+	if maxRetries <= 0 {
+		return e.Lock(ctx, key, ttl)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, retryInterval*time.Duration(maxRetries))
+	defer cancel()
+
+	handle, err := e.acquire(waitCtx, key, ttl, true)
+	if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return nil, ErrLockTimeout
+	}
+	return handle, err
+}
+
+// acquire 写入排队键，wait为false时排在别人之后立即放弃
+func (e *EtcdLock) acquire(ctx context.Context, key string, ttl time.Duration, wait bool) (*Handle, error) {
+	// 租约TTL以秒为单位，向上取整
+	seconds := int64(math.Ceil(ttl.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	lease, err := e.client.GrantLease(ctx, seconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	lockPrefix := e.getLockPrefix(key)
+	value := fmt.Sprintf("%s%x", lockPrefix, lease.ID)
+
+	// 写入排队键并读取当前持有者（创建revision最小的键）
+	resp, err := e.client.Transaction(ctx, nil,
+		[]clientv3.Op{
+			clientv3.OpPut(value, "", clientv3.WithLease(lease.ID)),
+			clientv3.OpGet(lockPrefix, clientv3.WithFirstCreate()...),
+		}, nil)
+	if err != nil {
+		e.revoke(lease.ID)
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	revision := resp.Header.Revision
+	owners := resp.Responses[1].GetResponseRange().GetKvs()
+	if len(owners) == 0 || owners[0].CreateRevision != revision {
+		if !wait {
+			e.revoke(lease.ID)
+			return nil, ErrLockNotAcquired
+		}
+
+		if err := e.waitTurn(ctx, lease.ID, lockPrefix, value, revision); err != nil {
+			e.revoke(lease.ID)
+			return nil, err
+		}
+	}
+
+	// 排队键的创建revision全局单调递增，直接作为防护令牌
+	handle := newHandle(key, value, revision, ttl, e)
+
+	logger.Debug(ctx, "Lock acquired",
+		zap.String("key", key),
+		zap.String("value", value),
+		zap.Int64("token", revision),
+		zap.Duration("ttl", ttl))
+
+	return handle, nil
+}
+
+// waitTurn 等待排在前面的键全部删除，等待期间保持租约存活
+func (e *EtcdLock) waitTurn(ctx context.Context, leaseID clientv3.LeaseID, lockPrefix, value string, revision int64) error {
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// 等待时间可能超过TTL，排队期间由客户端续租，获取锁后改由持有者 Extend
+	keepAlive, err := e.client.KeepAlive(waitCtx, leaseID)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for range keepAlive {
+		}
+	}()
+
+	cli := e.client.GetClient()
+	for {
+		// 只关注排在自己前一位的键，避免所有等待者同时被唤醒
+		opts := append(clientv3.WithLastCreate(), clientv3.WithMaxCreateRev(revision-1))
+		resp, err := cli.Get(waitCtx, lockPrefix, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to get lock waiters: %w", err)
+		}
+		if len(resp.Kvs) == 0 {
+			break
+		}
+		if err := e.waitDelete(waitCtx, string(resp.Kvs[0].Key), resp.Header.Revision); err != nil {
+			return err
+		}
+	}
+
+	// 排队期间租约可能已失效，确认排队键仍然存在
+	resp, err := cli.Get(ctx, value)
+	if err != nil {
+		return fmt.Errorf("failed to get lock key: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return fmt.Errorf("lock lease expired while waiting: %w", ErrLockNotAcquired)
+	}
+	return nil
+}
+
+// waitDelete 监听键在指定revision之后被删除
+func (e *EtcdLock) waitDelete(ctx context.Context, key string, revision int64) error {
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for resp := range e.client.GetClient().Watch(watchCtx, key, clientv3.WithRev(revision+1)) {
+		if err := resp.Err(); err != nil {
+			return fmt.Errorf("failed to watch lock key: %w", err)
+		}
+		for _, event := range resp.Events {
+			if event.Type == clientv3.EventTypeDelete {
+				return nil
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("watch on lock key %s closed", key)
+}
+
+// unlock 释放锁（内部方法）
+func (e *EtcdLock) unlock(handle *Handle) error {
+	leaseID, err := leaseFromValue(handle.Value)
+	if err != nil {
+		return err
+	}
+
+	// 只有排队键仍存在才删除，随后撤销租约
+	ctx := context.Background()
+	resp, err := e.client.Transaction(ctx,
+		[]clientv3.Cmp{clientv3.Compare(clientv3.CreateRevision(handle.Value), ">", 0)},
+		[]clientv3.Op{clientv3.OpDelete(handle.Value)},
+		nil)
+	if err != nil {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+	e.revoke(leaseID)
+
+	if !resp.Succeeded {
+		return ErrLockNotOwned
+	}
+
+	logger.Debug(ctx, "Lock released",
+		zap.String("key", handle.Key),
+		zap.String("value", handle.Value))
+
+	return nil
+}
+
+// extend 续期（内部方法）
+//
+// etcd租约的TTL在申请后不能修改，续期会将租约恢复为加锁时的TTL，ttl参数不生效。
+func (e *EtcdLock) extend(handle *Handle, ttl time.Duration) error {
+	leaseID, err := leaseFromValue(handle.Value)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	cli := e.client.GetClient()
+	if _, err := cli.KeepAliveOnce(ctx, leaseID); err != nil {
+		if errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			return ErrLockNotOwned
+		}
+		return fmt.Errorf("failed to extend lock: %w", err)
+	}
+
+	resp, err := cli.Get(ctx, handle.Value)
+	if err != nil {
+		return fmt.Errorf("failed to extend lock: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return ErrLockNotOwned
+	}
+
+	logger.Debug(ctx, "Lock extended",
+		zap.String("key", handle.Key),
+		zap.String("value", handle.Value))
+
+	return nil
+}
+
+// StartAutoRenew 启动自动续期
+func (e *EtcdLock) StartAutoRenew(handle *Handle, renewInterval time.Duration) {
+	handle.startAutoRenew(renewInterval)
+}
+
+// revoke 撤销租约，与租约关联的排队键随之删除
+func (e *EtcdLock) revoke(leaseID clientv3.LeaseID) {
+	if err := e.client.RevokeLease(context.Background(), leaseID); err != nil {
+		logger.Debug(context.Background(), "Failed to revoke lock lease",
+			zap.Int64("lease", int64(leaseID)),
+			zap.Error(err))
+	}
+}
+
+// getLockPrefix 获取锁的排队键前缀
+func (e *EtcdLock) getLockPrefix(key string) string {
+	return e.prefix + key + "/"
+}
+
+// leaseFromValue 从排队键中解析租约ID
+func leaseFromValue(value string) (clientv3.LeaseID, error) {
+	index := strings.LastIndexByte(value, '/')
+	leaseID, err := strconv.ParseInt(value[index+1:], 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid etcd lock value %q: %w", value, err)
+	}
+	return clientv3.LeaseID(leaseID), nil
+}
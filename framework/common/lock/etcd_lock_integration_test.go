@@ -0,0 +1,213 @@
+//go:build integration
+
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/pkg/etcd"
+)
+
+// 需要运行中的etcd：ETCD_ENDPOINTS=127.0.0.1:2379 go test -tags integration ./framework/common/lock
+
+// newTestEtcdLock 连接 ETCD_ENDPOINTS 指定的etcd，每个测试使用独立的锁前缀
+func newTestEtcdLock(t *testing.T) *EtcdLock {
+	endpoints := os.Getenv("ETCD_ENDPOINTS")
+	if endpoints == "" {
+		t.Skip("ETCD_ENDPOINTS not set")
+	}
+
+	client, err := etcd.NewClient(&etcd.Config{Endpoints: strings.Split(endpoints, ",")})
+	if err != nil {
+		t.Fatalf("Failed to connect to etcd: %v", err)
+	}
+	t.Cleanup(func() {
+		client.Close()
+	})
+	return NewEtcdLock(client, fmt.Sprintf("/lock-test/%s/%d/", t.Name(), time.Now().UnixNano()))
+}
+
+func TestEtcdLock_TryLockContention(t *testing.T) {
+	locker := newTestEtcdLock(t)
+	ctx := context.Background()
+
+	handle, err := locker.TryLock(ctx, "order", 5*time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	if _, err := locker.TryLock(ctx, "order", 5*time.Second); !errors.Is(err, ErrLockNotAcquired) {
+		t.Errorf("Expected ErrLockNotAcquired while held, got %v", err)
+	}
+
+	if err := handle.Unlock(); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+	if err := handle.Unlock(); !errors.Is(err, ErrLockNotOwned) {
+		t.Errorf("Expected ErrLockNotOwned on second unlock, got %v", err)
+	}
+
+	next, err := locker.TryLock(ctx, "order", 5*time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock after unlock: %v", err)
+	}
+	defer next.Unlock()
+	if next.Token <= handle.Token {
+		t.Errorf("Expected increasing token, got %d after %d", next.Token, handle.Token)
+	}
+}
+
+func TestEtcdLock_FIFO(t *testing.T) {
+	locker := newTestEtcdLock(t)
+	ctx := context.Background()
+
+	holder, err := locker.Lock(ctx, "order", 5*time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	var (
+		mutex sync.Mutex
+		order []int
+		wg    sync.WaitGroup
+	)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			handle, err := locker.Lock(ctx, "order", 5*time.Second)
+			if err != nil {
+				t.Errorf("Waiter %d failed to acquire lock: %v", i, err)
+				return
+			}
+			mutex.Lock()
+			order = append(order, i)
+			mutex.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			_ = handle.Unlock()
+		}(i)
+		// 确保按顺序排队
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if err := holder.Unlock(); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+	wg.Wait()
+
+	if fmt.Sprint(order) != "[0 1 2]" {
+		t.Errorf("Expected waiters to acquire in order [0 1 2], got %v", order)
+	}
+}
+
+func TestEtcdLock_LockWithRetryTimeout(t *testing.T) {
+	locker := newTestEtcdLock(t)
+	ctx := context.Background()
+
+	holder, err := locker.Lock(ctx, "order", 5*time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer holder.Unlock()
+
+	if _, err := locker.LockWithRetry(ctx, "order", 5*time.Second, 50*time.Millisecond, 4); !errors.Is(err, ErrLockTimeout) {
+		t.Errorf("Expected ErrLockTimeout, got %v", err)
+	}
+
+	// 超时放弃的等待者不应阻塞后续加锁
+	_ = holder.Unlock()
+	handle, err := locker.TryLock(ctx, "order", 5*time.Second)
+	if err != nil {
+		t.Fatalf("Expected lock to be free after timed-out waiter, got %v", err)
+	}
+	defer handle.Unlock()
+}
+
+func TestEtcdLock_Extend(t *testing.T) {
+	locker := newTestEtcdLock(t)
+	ctx := context.Background()
+
+	handle, err := locker.Lock(ctx, "order", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	// 持续续期超过TTL后锁仍然有效
+	for i := 0; i < 4; i++ {
+		time.Sleep(500 * time.Millisecond)
+		if err := handle.Extend(time.Second); err != nil {
+			t.Fatalf("Failed to extend lock: %v", err)
+		}
+	}
+	if _, err := locker.TryLock(ctx, "order", time.Second); !errors.Is(err, ErrLockNotAcquired) {
+		t.Errorf("Expected extended lock to still be held, got %v", err)
+	}
+
+	if err := handle.Unlock(); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+	if err := handle.Extend(time.Second); !errors.Is(err, ErrLockNotOwned) {
+		t.Errorf("Expected ErrLockNotOwned after unlock, got %v", err)
+	}
+}
+
+func TestEtcdLock_LeaseExpiryWhileWaiting(t *testing.T) {
+	locker := newTestEtcdLock(t)
+	ctx := context.Background()
+
+	// 持有者不续期也不释放，模拟崩溃，租约到期后等待者获得锁
+	crashed, err := locker.Lock(ctx, "order", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	// 等待者的租约TTL短于等待时间，排队期间由客户端续租
+	start := time.Now()
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	handle, err := locker.Lock(waitCtx, "order", time.Second)
+	if err != nil {
+		t.Fatalf("Waiter failed to acquire lock after holder lease expired: %v", err)
+	}
+	defer handle.Unlock()
+	if time.Since(start) < 500*time.Millisecond {
+		t.Errorf("Expected waiter to block until lease expiry, acquired after %v", time.Since(start))
+	}
+
+	if err := crashed.Extend(time.Second); !errors.Is(err, ErrLockNotOwned) {
+		t.Errorf("Expected ErrLockNotOwned for expired holder, got %v", err)
+	}
+	if handle.Token <= crashed.Token {
+		t.Errorf("Expected increasing token, got %d after %d", handle.Token, crashed.Token)
+	}
+}
+
+func TestEtcdLock_AutoRenewLost(t *testing.T) {
+	locker := newTestEtcdLock(t)
+	ctx := context.Background()
+
+	handle, err := locker.Lock(ctx, "order", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	// 外部撤销租约后自动续期失败，通知锁丢失
+	leaseID, err := leaseFromValue(handle.Value)
+	if err != nil {
+		t.Fatalf("Failed to parse lease: %v", err)
+	}
+	locker.StartAutoRenew(handle, 200*time.Millisecond)
+	locker.revoke(leaseID)
+
+	select {
+	case <-handle.Lost():
+	case <-time.After(3 * time.Second):
+		t.Error("Expected Lost to be closed after lease revoked")
+	}
+}
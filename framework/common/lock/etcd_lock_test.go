@@ -0,0 +1,36 @@
+package lock
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/qiaojinxia/distributed-service/framework/config"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func TestLeaseFromValue(t *testing.T) {
+	locker := NewEtcdLock(nil, "")
+	leaseID := clientv3.LeaseID(0x694d7a3c1f2e0b15)
+	value := fmt.Sprintf("%s%x", locker.getLockPrefix("order/123"), leaseID)
+
+	parsed, err := leaseFromValue(value)
+	if err != nil {
+		t.Fatalf("Failed to parse lease: %v", err)
+	}
+	if parsed != leaseID {
+		t.Errorf("Expected lease %x, got %x", leaseID, parsed)
+	}
+
+	if _, err := leaseFromValue("/lock/order/not-a-lease"); err == nil {
+		t.Error("Expected error for invalid lock value")
+	}
+}
+
+func TestNewDistributedLock(t *testing.T) {
+	if _, err := NewDistributedLock(&config.LockConfig{Backend: "zookeeper"}); err == nil {
+		t.Error("Expected error for unsupported backend")
+	}
+	if _, err := NewDistributedLock(&config.LockConfig{Backend: BackendEtcd}); err == nil {
+		t.Error("Expected error when etcd is not initialized")
+	}
+}
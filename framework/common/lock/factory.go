@@ -0,0 +1,42 @@
+package lock
+
+import (
+	"fmt"
+
+	"github.com/qiaojinxia/distributed-service/framework/config"
+	"github.com/qiaojinxia/distributed-service/framework/database"
+	"github.com/qiaojinxia/distributed-service/pkg/etcd"
+)
+
+// 锁后端类型
+const (
+	BackendRedis = "redis"
+	BackendEtcd  = "etcd"
+)
+
+// NewDistributedLock 按配置创建分布式锁，使用框架已初始化的Redis或etcd客户端
+func NewDistributedLock(cfg *config.LockConfig) (DistributedLock, error) {
+	backend, prefix := BackendRedis, ""
+	if cfg != nil {
+		prefix = cfg.Prefix
+		if cfg.Backend != "" {
+			backend = cfg.Backend
+		}
+	}
+
+	switch backend {
+	case BackendRedis:
+		if database.RedisClient == nil {
+			return nil, fmt.Errorf("framework redis not initialized")
+		}
+		return NewRedisLock(database.RedisClient, prefix), nil
+	case BackendEtcd:
+		client := etcd.GetClient()
+		if client == nil {
+			return nil, fmt.Errorf("framework etcd not initialized")
+		}
+		return NewEtcdLock(client, prefix), nil
+	default:
+		return nil, fmt.Errorf("unsupported lock backend: %s", backend)
+	}
+}
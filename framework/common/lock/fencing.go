@@ -0,0 +1,56 @@
+package lock
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultFencingColumn 默认的令牌列名
+const DefaultFencingColumn = "fencing_token"
+
+// ErrStaleToken 记录不存在，或记录已被持有更新令牌的锁持有者写入过
+var ErrStaleToken = errors.New("stale fencing token")
+
+// Fenced 可嵌入GORM模型，记录最后一次写入时使用的锁令牌
+type Fenced struct {
+	FencingToken int64 `gorm:"column:fencing_token;not null;default:0" json:"-"`
+}
+
+// FencingScope GORM作用域，只匹配令牌列不大于token的记录
+func FencingScope(column string, token int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.Lte{Column: clause.Column{Name: column}, Value: token})
+	}
+}
+
+// FencedUpdates 使用锁令牌保护的更新
+//
+// 仅当记录中的令牌不大于 handle.Token 时写入values，并把令牌列更新为 handle.Token，
+// 锁过期后被其他实例获取并写入过的记录会返回 ErrStaleToken。db需已指定Model和查询条件，
+// 例如 lock.FencedUpdates(db.Model(&Order{}).Where("id = ?", id), handle, values)。
+//
+// MySQL默认按实际变更的行数统计，值和令牌都未变化时同样返回 ErrStaleToken，可在DSN中设置 clientFoundRows=true。
+func FencedUpdates(db *gorm.DB, handle *Handle, values map[string]interface{}) error {
+	return FencedUpdatesColumn(db, DefaultFencingColumn, handle.Token, values)
+}
+
+// FencedUpdatesColumn 同 FencedUpdates，使用自定义令牌列
+func FencedUpdatesColumn(db *gorm.DB, column string, token int64, values map[string]interface{}) error {
+	fencedValues := make(map[string]interface{}, len(values)+1)
+	for name, value := range values {
+		fencedValues[name] = value
+	}
+	fencedValues[column] = token
+
+	result := db.Scopes(FencingScope(column, token)).Updates(fencedValues)
+	if result.Error != nil {
+		return fmt.Errorf("failed to apply fenced update: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrStaleToken
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package lock
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type fencedOrder struct {
+	ID     int64 `gorm:"primaryKey"`
+	Status string
+	Fenced
+}
+
+func TestFencedUpdates(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	if err := db.AutoMigrate(&fencedOrder{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if err := db.Create(&fencedOrder{ID: 1, Status: "created"}).Error; err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+
+	oldHolder := &Handle{Key: "order:1", Token: 5}
+	newHolder := &Handle{Key: "order:1", Token: 6}
+
+	// 新持有者写入后，暂停恢复的旧持有者不能再覆盖
+	if err := FencedUpdates(db.Model(&fencedOrder{}).Where("id = ?", 1), newHolder, map[string]interface{}{"status": "paid"}); err != nil {
+		t.Fatalf("Failed to apply fenced update: %v", err)
+	}
+	err = FencedUpdates(db.Model(&fencedOrder{}).Where("id = ?", 1), oldHolder, map[string]interface{}{"status": "cancelled"})
+	if !errors.Is(err, ErrStaleToken) {
+		t.Errorf("Expected ErrStaleToken, got %v", err)
+	}
+
+	var order fencedOrder
+	if err := db.First(&order, 1).Error; err != nil {
+		t.Fatalf("Failed to load order: %v", err)
+	}
+	if order.Status != "paid" || order.FencingToken != 6 {
+		t.Errorf("Expected status paid with token 6, got %s with token %d", order.Status, order.FencingToken)
+	}
+}
+
+// failingBackend 续期总是失败的锁后端
+type failingBackend struct{}
+
+func (failingBackend) unlock(handle *Handle) error {
+	return nil
+}
+
+func (failingBackend) extend(handle *Handle, ttl time.Duration) error {
+	return ErrLockNotOwned
+}
+
+func TestHandle_LostOnRenewFailure(t *testing.T) {
+	handle := newHandle("job", "value", 1, time.Second, failingBackend{})
+	handle.startAutoRenew(10 * time.Millisecond)
+	defer handle.Unlock()
+
+	select {
+	case <-handle.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("Expected Lost to fire after renewal failure")
+	}
+}
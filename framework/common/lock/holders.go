@@ -0,0 +1,95 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// luaNowMs Lua片段，按Redis服务器时间计算当前毫秒数，过期判断不受各客户端时钟偏差影响
+//
+// 在脚本中先调用TIME再写入需要Redis 5及以上版本（默认按命令效果复制）。
+const luaNowMs = `
+	local serverTime = redis.call("TIME")
+	local now = tonumber(serverTime[1]) * 1000 + math.floor(tonumber(serverTime[2]) / 1000)
+`
+
+// luaKeepZSetAlive Lua片段，保证有序集合KEYS[1]的过期时间不短于ARGV[2]毫秒
+const luaKeepZSetAlive = `
+	if redis.call("PTTL", KEYS[1]) < tonumber(ARGV[2]) then
+		redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	end
+`
+
+// zsetHolders 以有序集合记录多个持有者，分值为各持有者的过期时间（毫秒），
+// 持有者崩溃后其成员按分值过期，不影响其他持有者。读写锁的读锁和信号量的许可共用该实现。
+type zsetHolders struct {
+	client *redis.Client
+}
+
+// release 移除持有者，持有者不存在或已过期时返回 ErrLockNotOwned
+func (z zsetHolders) release(ctx context.Context, holdersKey, member string) error {
+	luaScript := luaNowMs + `
+		local expireAt = redis.call("ZSCORE", KEYS[1], ARGV[1])
+		if expireAt == false then
+			return 0
+		end
+		redis.call("ZREM", KEYS[1], ARGV[1])
+		if tonumber(expireAt) <= now then
+			return 0
+		end
+		return 1
+	`
+
+	result, err := z.client.Eval(ctx, luaScript, []string{holdersKey}, member).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+
+	if result == 0 {
+		return ErrLockNotOwned
+	}
+	return nil
+}
+
+// extend 将持有者的过期时间重置为ttl，持有者不存在或已过期时返回 ErrLockNotOwned
+func (z zsetHolders) extend(ctx context.Context, holdersKey, member string, ttl time.Duration) error {
+	luaScript := luaNowMs + `
+		local expireAt = redis.call("ZSCORE", KEYS[1], ARGV[1])
+		if expireAt == false then
+			return 0
+		end
+		if tonumber(expireAt) <= now then
+			redis.call("ZREM", KEYS[1], ARGV[1])
+			return 0
+		end
+		redis.call("ZADD", KEYS[1], now + tonumber(ARGV[2]), ARGV[1])
+	` + luaKeepZSetAlive + `
+		return 1
+	`
+
+	result, err := z.client.Eval(ctx, luaScript, []string{holdersKey}, member, ttlMilliseconds(ttl)).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to extend lock: %w", err)
+	}
+
+	if result == 0 {
+		return ErrLockNotOwned
+	}
+	return nil
+}
+
+// count 当前未过期的持有者数量
+func (z zsetHolders) count(ctx context.Context, holdersKey string) (int64, error) {
+	luaScript := luaNowMs + `
+		return redis.call("ZCOUNT", KEYS[1], "(" .. now, "+inf")
+	`
+
+	count, err := z.client.Eval(ctx, luaScript, []string{holdersKey}).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count lock holders: %w", err)
+	}
+	return count, nil
+}
@@ -2,7 +2,11 @@ package lock
 
 import (
 	"context"
+	"sync"
 	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/logger"
+	"go.uber.org/zap"
 )
 
 // DistributedLock 分布式锁接口
@@ -15,17 +19,58 @@ type DistributedLock interface {
 
 	// LockWithRetry 带重试的获取锁
 	LockWithRetry(ctx context.Context, key string, ttl time.Duration, retryInterval time.Duration, maxRetries int) (*Handle, error)
+
+	// StartAutoRenew 启动自动续期，Unlock时停止
+	StartAutoRenew(handle *Handle, renewInterval time.Duration)
+}
+
+// handleBackend 锁句柄释放和续期所依赖的后端操作，由各锁实现提供
+type handleBackend interface {
+	unlock(handle *Handle) error
+	extend(handle *Handle, ttl time.Duration) error
 }
 
 // Handle LockHandle 锁句柄
 type Handle struct {
 	Key       string
 	Value     string
+	Token     int64 // 防护令牌，同一个锁每次获取时单调递增，写入共享资源时用于拒绝过期持有者
 	TTL       time.Duration
 	CreatedAt time.Time
-	locker    DistributedLock
+	backend   handleBackend
 	ctx       context.Context
 	cancel    context.CancelFunc
+	lost      chan struct{}
+	lostOnce  sync.Once
+}
+
+// newHandle 创建锁句柄
+func newHandle(key, value string, token int64, ttl time.Duration, backend handleBackend) *Handle {
+	return &Handle{
+		Key:       key,
+		Value:     value,
+		Token:     token,
+		TTL:       ttl,
+		CreatedAt: time.Now(),
+		backend:   backend,
+		lost:      make(chan struct{}),
+	}
+}
+
+// Lost 返回锁丢失通知通道，StartAutoRenew 续期失败时关闭
+//
+// 通道关闭后锁可能已过期并被其他实例获取，持有者应停止依赖该锁的操作。
+func (h *Handle) Lost() <-chan struct{} {
+	return h.lost
+}
+
+// markLost 标记锁已丢失
+func (h *Handle) markLost() {
+	h.lostOnce.Do(func() {
+		if h.lost != nil {
+			close(h.lost)
+		}
+	})
 }
 
 // Unlock 释放锁
@@ -33,18 +78,48 @@ func (h *Handle) Unlock() error {
 	if h.cancel != nil {
 		h.cancel()
 	}
-	if redisLock, ok := h.locker.(*RedisLock); ok {
-		return redisLock.unlock(h.Key, h.Value)
+	if h.backend == nil {
+		return nil
 	}
-	return nil
+	return h.backend.unlock(h)
 }
 
 // Extend 延长锁的过期时间
 func (h *Handle) Extend(ttl time.Duration) error {
-	if redisLock, ok := h.locker.(*RedisLock); ok {
-		return redisLock.extend(h.Key, h.Value, ttl)
+	if h.backend == nil {
+		return nil
 	}
-	return nil
+	return h.backend.extend(h, ttl)
+}
+
+// startAutoRenew 按间隔调用 Extend 续期，Unlock 后停止，续期失败时通知 Lost
+func (h *Handle) startAutoRenew(renewInterval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	h.ctx = ctx
+	h.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(renewInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := h.Extend(h.TTL); err != nil {
+					logger.Error(ctx, "Failed to auto-renew lock",
+						zap.String("key", h.Key),
+						zap.Int64("token", h.Token),
+						zap.Error(err))
+					h.markLost()
+					return
+				}
+				logger.Debug(ctx, "Lock auto-renewed",
+					zap.String("key", h.Key))
+			}
+		}
+	}()
 }
 
 // Options LockOptions 锁选项
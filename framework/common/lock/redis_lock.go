@@ -45,29 +45,32 @@ func (r *RedisLock) Lock(ctx context.Context, key string, ttl time.Duration) (*H
 // TryLock 尝试获取锁（不阻塞）
 func (r *RedisLock) TryLock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
 	lockKey := r.getLockKey(key)
-	value := r.generateLockValue()
+	value := generateLockValue()
 
-	// 使用SET命令的NX选项实现原子性加锁
-	result, err := r.client.SetNX(ctx, lockKey, value, ttl).Result()
+	// 使用Lua脚本确保原子性：SET NX加锁成功后递增令牌计数器，计数器不过期以保证令牌单调递增
+	luaScript := `
+		if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+			return redis.call("INCR", KEYS[2])
+		else
+			return 0
+		end
+	`
+
+	token, err := r.client.Eval(ctx, luaScript, []string{lockKey, r.getTokenKey(key)}, value, ttlMilliseconds(ttl)).Int64()
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire lock: %w", err)
 	}
 
-	if !result {
+	if token == 0 {
 		return nil, ErrLockNotAcquired
 	}
 
-	handle := &Handle{
-		Key:       key,
-		Value:     value,
-		TTL:       ttl,
-		CreatedAt: time.Now(),
-		locker:    r,
-	}
+	handle := newHandle(key, value, token, ttl, r)
 
 	logger.Debug(ctx, "Lock acquired",
 		zap.String("key", key),
 		zap.String("value", value),
+		zap.Int64("token", token),
 		zap.Duration("ttl", ttl))
 
 	return handle, nil
@@ -75,12 +78,19 @@ func (r *RedisLock) TryLock(ctx context.Context, key string, ttl time.Duration)
 
 // LockWithRetry 带重试的获取锁
 func (r *RedisLock) LockWithRetry(ctx context.Context, key string, ttl time.Duration, retryInterval time.Duration, maxRetries int) (*Handle, error) {
+	return retryLock(ctx, retryInterval, maxRetries, func() (*Handle, error) {
+		return r.TryLock(ctx, key, ttl)
+	})
+}
+
+// retryLock 按间隔重试tryLock直到成功、出错、超过重试次数或上下文取消，maxRetries不大于0表示无限重试
+func retryLock(ctx context.Context, retryInterval time.Duration, maxRetries int, tryLock func() (*Handle, error)) (*Handle, error) {
 	retries := 0
 	ticker := time.NewTicker(retryInterval)
 	defer ticker.Stop()
 
 	for {
-		handle, err := r.TryLock(ctx, key, ttl)
+		handle, err := tryLock()
 		if err == nil {
 			return handle, nil
 		}
@@ -107,9 +117,36 @@ func (r *RedisLock) LockWithRetry(ctx context.Context, key string, ttl time.Dura
 }
 
 // unlock 释放锁（内部方法）
-func (r *RedisLock) unlock(key, value string) error {
-	lockKey := r.getLockKey(key)
+func (r *RedisLock) unlock(handle *Handle) error {
+	ctx := context.Background()
+	if err := releaseValue(ctx, r.client, r.getLockKey(handle.Key), handle.Value); err != nil {
+		return err
+	}
+
+	logger.Debug(ctx, "Lock released",
+		zap.String("key", handle.Key),
+		zap.String("value", handle.Value))
+
+	return nil
+}
+
+// extend 延长锁的过期时间（内部方法）
+func (r *RedisLock) extend(handle *Handle, ttl time.Duration) error {
+	ctx := context.Background()
+	if err := extendValue(ctx, r.client, r.getLockKey(handle.Key), handle.Value, ttl); err != nil {
+		return err
+	}
+
+	logger.Debug(ctx, "Lock extended",
+		zap.String("key", handle.Key),
+		zap.String("value", handle.Value),
+		zap.Duration("ttl", ttl))
+
+	return nil
+}
 
+// releaseValue 仅当锁键的值与value匹配时删除
+func releaseValue(ctx context.Context, client *redis.Client, lockKey, value string) error {
 	// 使用Lua脚本确保原子性：只有值匹配才删除
 	luaScript := `
 		if redis.call("GET", KEYS[1]) == ARGV[1] then
@@ -119,80 +156,42 @@ func (r *RedisLock) unlock(key, value string) error {
 		end
 	`
 
-	ctx := context.Background()
-	result, err := r.client.Eval(ctx, luaScript, []string{lockKey}, value).Result()
+	result, err := client.Eval(ctx, luaScript, []string{lockKey}, value).Int64()
 	if err != nil {
 		return fmt.Errorf("failed to release lock: %w", err)
 	}
 
-	if result.(int64) == 0 {
+	if result == 0 {
 		return ErrLockNotOwned
 	}
-
-	logger.Debug(ctx, "Lock released",
-		zap.String("key", key),
-		zap.String("value", value))
-
 	return nil
 }
 
-// extend 延长锁的过期时间（内部方法）
-func (r *RedisLock) extend(key, value string, ttl time.Duration) error {
-	lockKey := r.getLockKey(key)
-
+// extendValue 仅当锁键的值与value匹配时按毫秒精度重置过期时间
+func extendValue(ctx context.Context, client *redis.Client, lockKey, value string, ttl time.Duration) error {
 	// 使用Lua脚本确保原子性：只有值匹配才延期
 	luaScript := `
 		if redis.call("GET", KEYS[1]) == ARGV[1] then
-			return redis.call("EXPIRE", KEYS[1], ARGV[2])
+			return redis.call("PEXPIRE", KEYS[1], ARGV[2])
 		else
 			return 0
 		end
 	`
 
-	ctx := context.Background()
-	result, err := r.client.Eval(ctx, luaScript, []string{lockKey}, value, int(ttl.Seconds())).Result()
+	result, err := client.Eval(ctx, luaScript, []string{lockKey}, value, ttlMilliseconds(ttl)).Int64()
 	if err != nil {
 		return fmt.Errorf("failed to extend lock: %w", err)
 	}
 
-	if result.(int64) == 0 {
+	if result == 0 {
 		return ErrLockNotOwned
 	}
-
-	logger.Debug(ctx, "Lock extended",
-		zap.String("key", key),
-		zap.String("value", value),
-		zap.Duration("ttl", ttl))
-
 	return nil
 }
 
 // StartAutoRenew 启动自动续期
 func (r *RedisLock) StartAutoRenew(handle *Handle, renewInterval time.Duration) {
-	ctx, cancel := context.WithCancel(context.Background())
-	handle.ctx = ctx
-	handle.cancel = cancel
-
-	go func() {
-		ticker := time.NewTicker(renewInterval)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				if err := handle.Extend(handle.TTL); err != nil {
-					logger.Error(ctx, "Failed to auto-renew lock",
-						zap.String("key", handle.Key),
-						zap.Error(err))
-					return
-				}
-				logger.Debug(ctx, "Lock auto-renewed",
-					zap.String("key", handle.Key))
-			}
-		}
-	}()
+	handle.startAutoRenew(renewInterval)
 }
 
 // getLockKey 获取完整的锁键名
@@ -200,8 +199,26 @@ func (r *RedisLock) getLockKey(key string) string {
 	return r.prefix + key
 }
 
+// getTokenKey 获取锁令牌计数器的键名
+func (r *RedisLock) getTokenKey(key string) string {
+	return tokenKey(r.prefix, key)
+}
+
+// tokenKey 令牌计数器键名，放在独立的命名空间下，避免与以":token"结尾的业务锁键冲突
+func tokenKey(prefix, key string) string {
+	return prefix + "__token__:" + key
+}
+
+// ttlMilliseconds 转换为毫秒，不足1毫秒按1毫秒处理
+func ttlMilliseconds(ttl time.Duration) int64 {
+	if ms := ttl.Milliseconds(); ms > 0 {
+		return ms
+	}
+	return 1
+}
+
 // generateLockValue 生成锁的唯一值
-func (r *RedisLock) generateLockValue() string {
+func generateLockValue() string {
 	bytes := make([]byte, 16)
 	_, _ = rand.Read(bytes)
 	return hex.EncodeToString(bytes)
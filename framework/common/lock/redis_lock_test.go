@@ -0,0 +1,83 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+// newTestRedis 启动内存Redis，服务器时间固定为当前时间，需配合 advance 推进
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	server := miniredis.RunT(t)
+	server.SetTime(time.Now())
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		client.Close()
+	})
+	return server, client
+}
+
+// advance 同时推进服务器时间（用于TIME）和键的TTL
+func advance(server *miniredis.Miniredis, d time.Duration) {
+	server.SetTime(time.Now().Add(d))
+	server.FastForward(d)
+}
+
+func TestRedisLock_FencingTokenAndExtend(t *testing.T) {
+	server, client := newTestRedis(t)
+	locker := NewRedisLock(client, "")
+	ctx := context.Background()
+
+	first, err := locker.TryLock(ctx, "job", 1500*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	if _, err := locker.TryLock(ctx, "job", time.Second); !errors.Is(err, ErrLockNotAcquired) {
+		t.Errorf("Expected ErrLockNotAcquired, got %v", err)
+	}
+
+	// 续期保留毫秒精度
+	if err := first.Extend(2500 * time.Millisecond); err != nil {
+		t.Fatalf("Failed to extend lock: %v", err)
+	}
+	if ttl := server.TTL("lock:job"); ttl != 2500*time.Millisecond {
+		t.Errorf("Expected TTL 2.5s, got %v", ttl)
+	}
+
+	if err := first.Unlock(); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+	second, err := locker.TryLock(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to reacquire lock: %v", err)
+	}
+	if second.Token <= first.Token {
+		t.Errorf("Expected increasing tokens, got %d after %d", second.Token, first.Token)
+	}
+}
+
+func TestRedisLock_TokenKeyDoesNotCollide(t *testing.T) {
+	server, client := newTestRedis(t)
+	locker := NewRedisLock(client, "")
+	ctx := context.Background()
+
+	// 业务锁键以":token"结尾时不应与"job"的令牌计数器冲突
+	job, err := locker.TryLock(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer job.Unlock()
+	counter, err := locker.TryLock(ctx, "job:token", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock on key ending with :token: %v", err)
+	}
+	defer counter.Unlock()
+
+	if !server.Exists("lock:__token__:job") || !server.Exists("lock:__token__:job:token") {
+		t.Errorf("Expected token counters in separate namespace, got keys %v", server.Keys())
+	}
+}
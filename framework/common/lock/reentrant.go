@@ -0,0 +1,184 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/qiaojinxia/distributed-service/framework/logger"
+	"go.uber.org/zap"
+)
+
+// ReentrantLock Redis可重入锁
+//
+// 锁以哈希存储持有者ID、重入次数和令牌，同一持有者可以重复加锁，每次加锁都需要对应一次 Unlock，
+// 重入次数归零时释放。每次加锁都会把过期时间重置为本次的ttl。
+// 令牌只在首次获取时递增，重入时返回相同的令牌。
+type ReentrantLock struct {
+	client *redis.Client
+	prefix string
+	owner  string
+}
+
+// NewReentrantLock 创建Redis可重入锁，owner为持有者ID（如实例ID加任务ID），为空时随机生成
+func NewReentrantLock(client *redis.Client, prefix, owner string) *ReentrantLock {
+	if prefix == "" {
+		prefix = "lock:reentrant:"
+	}
+	if owner == "" {
+		owner = generateLockValue()
+	}
+	return &ReentrantLock{
+		client: client,
+		prefix: prefix,
+		owner:  owner,
+	}
+}
+
+// WithOwner 返回使用相同存储、不同持有者ID的可重入锁
+func (r *ReentrantLock) WithOwner(owner string) *ReentrantLock {
+	return NewReentrantLock(r.client, r.prefix, owner)
+}
+
+// Owner 持有者ID
+func (r *ReentrantLock) Owner() string {
+	return r.owner
+}
+
+// Lock 获取锁（阻塞直到获取成功或上下文取消）
+func (r *ReentrantLock) Lock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
+	return r.LockWithRetry(ctx, key, ttl, 100*time.Millisecond, -1)
+}
+
+// TryLock 尝试获取锁（不阻塞），锁已被当前持有者持有时重入次数加一
+func (r *ReentrantLock) TryLock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
+	lockKey := r.getLockKey(key)
+
+	// 使用Lua脚本确保原子性：空闲时获取并递增令牌，同一持有者重入时增加次数
+	luaScript := `
+		local owner = redis.call("HGET", KEYS[1], "owner")
+		if owner == false then
+			local token = redis.call("INCR", KEYS[2])
+			redis.call("HSET", KEYS[1], "owner", ARGV[1], "count", 1, "token", token)
+			redis.call("PEXPIRE", KEYS[1], ARGV[2])
+			return token
+		end
+		if owner == ARGV[1] then
+			redis.call("HINCRBY", KEYS[1], "count", 1)
+			redis.call("PEXPIRE", KEYS[1], ARGV[2])
+			return tonumber(redis.call("HGET", KEYS[1], "token"))
+		end
+		return 0
+	`
+
+	token, err := r.client.Eval(ctx, luaScript, []string{lockKey, tokenKey(r.prefix, key)}, r.owner, ttlMilliseconds(ttl)).Int64()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	if token == 0 {
+		return nil, ErrLockNotAcquired
+	}
+
+	handle := newHandle(key, r.owner, token, ttl, r)
+
+	logger.Debug(ctx, "Reentrant lock acquired",
+		zap.String("key", key),
+		zap.String("owner", r.owner),
+		zap.Int64("token", token),
+		zap.Duration("ttl", ttl))
+
+	return handle, nil
+}
+
+// LockWithRetry 带重试的获取锁
+func (r *ReentrantLock) LockWithRetry(ctx context.Context, key string, ttl time.Duration, retryInterval time.Duration, maxRetries int) (*Handle, error) {
+	return retryLock(ctx, retryInterval, maxRetries, func() (*Handle, error) {
+		return r.TryLock(ctx, key, ttl)
+	})
+}
+
+// StartAutoRenew 启动自动续期
+func (r *ReentrantLock) StartAutoRenew(handle *Handle, renewInterval time.Duration) {
+	handle.startAutoRenew(renewInterval)
+}
+
+// HoldCount 当前持有者的重入次数，未持有时返回0
+func (r *ReentrantLock) HoldCount(ctx context.Context, key string) (int, error) {
+	values, err := r.client.HMGet(ctx, r.getLockKey(key), "owner", "count").Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get lock hold count: %w", err)
+	}
+
+	if owner, _ := values[0].(string); owner != r.owner {
+		return 0, nil
+	}
+	value, _ := values[1].(string)
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid lock hold count %q: %w", value, err)
+	}
+	return count, nil
+}
+
+// unlock 重入次数减一，归零时删除锁（内部方法）
+func (r *ReentrantLock) unlock(handle *Handle) error {
+	// 使用Lua脚本确保原子性：只有持有者匹配才递减
+	luaScript := `
+		if redis.call("HGET", KEYS[1], "owner") ~= ARGV[1] then
+			return -1
+		end
+		local count = redis.call("HINCRBY", KEYS[1], "count", -1)
+		if count <= 0 then
+			redis.call("DEL", KEYS[1])
+		end
+		return count
+	`
+
+	ctx := context.Background()
+	count, err := r.client.Eval(ctx, luaScript, []string{r.getLockKey(handle.Key)}, handle.Value).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+
+	if count < 0 {
+		return ErrLockNotOwned
+	}
+
+	logger.Debug(ctx, "Reentrant lock released",
+		zap.String("key", handle.Key),
+		zap.String("owner", handle.Value),
+		zap.Int64("remaining", count))
+
+	return nil
+}
+
+// extend 延长锁的过期时间（内部方法）
+func (r *ReentrantLock) extend(handle *Handle, ttl time.Duration) error {
+	// 使用Lua脚本确保原子性：只有持有者匹配才延期
+	luaScript := `
+		if redis.call("HGET", KEYS[1], "owner") == ARGV[1] then
+			return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+		else
+			return 0
+		end
+	`
+
+	ctx := context.Background()
+	result, err := r.client.Eval(ctx, luaScript, []string{r.getLockKey(handle.Key)}, handle.Value, ttlMilliseconds(ttl)).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to extend lock: %w", err)
+	}
+
+	if result == 0 {
+		return ErrLockNotOwned
+	}
+	return nil
+}
+
+// getLockKey 获取完整的锁键名
+func (r *ReentrantLock) getLockKey(key string) string {
+	return r.prefix + key
+}
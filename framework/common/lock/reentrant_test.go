@@ -0,0 +1,56 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReentrantLock(t *testing.T) {
+	_, client := newTestRedis(t)
+	worker := NewReentrantLock(client, "", "worker-1")
+	other := worker.WithOwner("worker-2")
+	ctx := context.Background()
+
+	outer, err := worker.TryLock(ctx, "order:1", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	inner, err := worker.TryLock(ctx, "order:1", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to reenter lock: %v", err)
+	}
+	if inner.Token != outer.Token {
+		t.Errorf("Expected reentry to keep token %d, got %d", outer.Token, inner.Token)
+	}
+	if count, _ := worker.HoldCount(ctx, "order:1"); count != 2 {
+		t.Errorf("Expected hold count 2, got %d", count)
+	}
+
+	if _, err := other.TryLock(ctx, "order:1", time.Second); !errors.Is(err, ErrLockNotAcquired) {
+		t.Errorf("Expected ErrLockNotAcquired for other owner, got %v", err)
+	}
+
+	// 释放一次后仍由当前持有者持有
+	if err := inner.Unlock(); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+	if _, err := other.TryLock(ctx, "order:1", time.Second); !errors.Is(err, ErrLockNotAcquired) {
+		t.Errorf("Expected lock to be held after partial unlock, got %v", err)
+	}
+
+	if err := outer.Unlock(); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+	handle, err := other.TryLock(ctx, "order:1", time.Second)
+	if err != nil {
+		t.Fatalf("Expected other owner to acquire released lock: %v", err)
+	}
+	if handle.Token <= outer.Token {
+		t.Errorf("Expected increasing tokens, got %d after %d", handle.Token, outer.Token)
+	}
+	if err := outer.Unlock(); !errors.Is(err, ErrLockNotOwned) {
+		t.Errorf("Expected ErrLockNotOwned, got %v", err)
+	}
+}
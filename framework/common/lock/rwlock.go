@@ -0,0 +1,202 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/qiaojinxia/distributed-service/framework/logger"
+	"go.uber.org/zap"
+)
+
+// RWLock Redis读写锁
+//
+// 写锁为独占的字符串键，读锁持有者记录在有序集合中并各自过期，读锁之间共享，读写互斥。
+// 持有读锁期间新的读锁仍可获取（读优先），写锁需要等待所有读锁释放。
+// RWLock 本身按写锁实现 DistributedLock，RLocker 返回按读锁实现的 DistributedLock。
+// 写锁句柄带有递增的令牌，读锁句柄不分配令牌（Token为0）。
+type RWLock struct {
+	client  *redis.Client
+	prefix  string
+	readers zsetHolders
+}
+
+// NewRWLock 创建Redis读写锁
+func NewRWLock(client *redis.Client, prefix string) *RWLock {
+	if prefix == "" {
+		prefix = "lock:rw:"
+	}
+	return &RWLock{
+		client:  client,
+		prefix:  prefix,
+		readers: zsetHolders{client: client},
+	}
+}
+
+// Lock 获取写锁（阻塞直到获取成功或上下文取消）
+func (l *RWLock) Lock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
+	return l.LockWithRetry(ctx, key, ttl, 100*time.Millisecond, -1)
+}
+
+// TryLock 尝试获取写锁（不阻塞）
+func (l *RWLock) TryLock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
+	value := generateLockValue()
+
+	// 使用Lua脚本确保原子性：没有写锁且没有未过期的读锁时加锁并递增令牌
+	luaScript := luaNowMs + `
+		if redis.call("EXISTS", KEYS[1]) == 1 then
+			return 0
+		end
+		redis.call("ZREMRANGEBYSCORE", KEYS[2], "-inf", now)
+		if redis.call("ZCARD", KEYS[2]) > 0 then
+			return 0
+		end
+		redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+		return redis.call("INCR", KEYS[3])
+	`
+
+	keys := []string{l.getWriteKey(key), l.getReadersKey(key), l.getTokenKey(key)}
+	token, err := l.client.Eval(ctx, luaScript, keys, value, ttlMilliseconds(ttl)).Int64()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire write lock: %w", err)
+	}
+
+	if token == 0 {
+		return nil, ErrLockNotAcquired
+	}
+
+	handle := newHandle(key, value, token, ttl, l)
+
+	logger.Debug(ctx, "Write lock acquired",
+		zap.String("key", key),
+		zap.String("value", value),
+		zap.Int64("token", token),
+		zap.Duration("ttl", ttl))
+
+	return handle, nil
+}
+
+// LockWithRetry 带重试的获取写锁
+func (l *RWLock) LockWithRetry(ctx context.Context, key string, ttl time.Duration, retryInterval time.Duration, maxRetries int) (*Handle, error) {
+	return retryLock(ctx, retryInterval, maxRetries, func() (*Handle, error) {
+		return l.TryLock(ctx, key, ttl)
+	})
+}
+
+// StartAutoRenew 启动自动续期，读锁和写锁句柄均可使用
+func (l *RWLock) StartAutoRenew(handle *Handle, renewInterval time.Duration) {
+	handle.startAutoRenew(renewInterval)
+}
+
+// RLock 获取读锁（阻塞直到获取成功或上下文取消）
+func (l *RWLock) RLock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
+	return l.RLockWithRetry(ctx, key, ttl, 100*time.Millisecond, -1)
+}
+
+// TryRLock 尝试获取读锁（不阻塞）
+func (l *RWLock) TryRLock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
+	value := generateLockValue()
+
+	// 使用Lua脚本确保原子性：没有写锁时登记读锁，顺带清理已过期的读锁
+	luaScript := luaNowMs + `
+		if redis.call("EXISTS", KEYS[2]) == 1 then
+			return 0
+		end
+		redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now)
+		redis.call("ZADD", KEYS[1], now + tonumber(ARGV[2]), ARGV[1])
+	` + luaKeepZSetAlive + `
+		return 1
+	`
+
+	keys := []string{l.getReadersKey(key), l.getWriteKey(key)}
+	result, err := l.client.Eval(ctx, luaScript, keys, value, ttlMilliseconds(ttl)).Int64()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire read lock: %w", err)
+	}
+
+	if result == 0 {
+		return nil, ErrLockNotAcquired
+	}
+
+	handle := newHandle(key, value, 0, ttl, readLocker{l})
+
+	logger.Debug(ctx, "Read lock acquired",
+		zap.String("key", key),
+		zap.String("value", value),
+		zap.Duration("ttl", ttl))
+
+	return handle, nil
+}
+
+// RLockWithRetry 带重试的获取读锁
+func (l *RWLock) RLockWithRetry(ctx context.Context, key string, ttl time.Duration, retryInterval time.Duration, maxRetries int) (*Handle, error) {
+	return retryLock(ctx, retryInterval, maxRetries, func() (*Handle, error) {
+		return l.TryRLock(ctx, key, ttl)
+	})
+}
+
+// RLocker 返回以读锁实现 DistributedLock 的视图，便于传给只依赖该接口的代码
+func (l *RWLock) RLocker() DistributedLock {
+	return readLocker{l}
+}
+
+// unlock 释放写锁（内部方法）
+func (l *RWLock) unlock(handle *Handle) error {
+	return releaseValue(context.Background(), l.client, l.getWriteKey(handle.Key), handle.Value)
+}
+
+// extend 延长写锁的过期时间（内部方法）
+func (l *RWLock) extend(handle *Handle, ttl time.Duration) error {
+	return extendValue(context.Background(), l.client, l.getWriteKey(handle.Key), handle.Value, ttl)
+}
+
+// getWriteKey 获取写锁键名
+func (l *RWLock) getWriteKey(key string) string {
+	return l.prefix + key + ":write"
+}
+
+// getReadersKey 获取读锁持有者集合键名
+func (l *RWLock) getReadersKey(key string) string {
+	return l.prefix + key + ":readers"
+}
+
+// getTokenKey 获取写锁令牌计数器键名
+func (l *RWLock) getTokenKey(key string) string {
+	return tokenKey(l.prefix, key)
+}
+
+// readLocker 读锁视图
+type readLocker struct {
+	rw *RWLock
+}
+
+// Lock 获取读锁
+func (r readLocker) Lock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
+	return r.rw.RLock(ctx, key, ttl)
+}
+
+// TryLock 尝试获取读锁
+func (r readLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
+	return r.rw.TryRLock(ctx, key, ttl)
+}
+
+// LockWithRetry 带重试的获取读锁
+func (r readLocker) LockWithRetry(ctx context.Context, key string, ttl time.Duration, retryInterval time.Duration, maxRetries int) (*Handle, error) {
+	return r.rw.RLockWithRetry(ctx, key, ttl, retryInterval, maxRetries)
+}
+
+// StartAutoRenew 启动自动续期
+func (r readLocker) StartAutoRenew(handle *Handle, renewInterval time.Duration) {
+	handle.startAutoRenew(renewInterval)
+}
+
+// unlock 释放读锁（内部方法）
+func (r readLocker) unlock(handle *Handle) error {
+	return r.rw.readers.release(context.Background(), r.rw.getReadersKey(handle.Key), handle.Value)
+}
+
+// extend 延长读锁的过期时间（内部方法）
+func (r readLocker) extend(handle *Handle, ttl time.Duration) error {
+	return r.rw.readers.extend(context.Background(), r.rw.getReadersKey(handle.Key), handle.Value, ttl)
+}
@@ -0,0 +1,52 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRWLock(t *testing.T) {
+	server, client := newTestRedis(t)
+	rw := NewRWLock(client, "")
+	ctx := context.Background()
+
+	reader1, err := rw.TryRLock(ctx, "report", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire read lock: %v", err)
+	}
+	if _, err := rw.RLocker().TryLock(ctx, "report", 3*time.Second); err != nil {
+		t.Fatalf("Expected read locks to be shared: %v", err)
+	}
+	if _, err := rw.TryLock(ctx, "report", time.Second); !errors.Is(err, ErrLockNotAcquired) {
+		t.Errorf("Expected write lock to wait for readers, got %v", err)
+	}
+
+	// 第一个读锁释放后，第二个读锁仍阻止写锁，直到其过期
+	if err := reader1.Unlock(); err != nil {
+		t.Fatalf("Failed to release read lock: %v", err)
+	}
+	if _, err := rw.TryLock(ctx, "report", time.Second); !errors.Is(err, ErrLockNotAcquired) {
+		t.Errorf("Expected write lock to wait for remaining reader, got %v", err)
+	}
+	advance(server, 3100*time.Millisecond)
+
+	writer, err := rw.TryLock(ctx, "report", time.Second)
+	if err != nil {
+		t.Fatalf("Expected write lock after readers expired: %v", err)
+	}
+	if writer.Token == 0 {
+		t.Error("Expected write lock to carry a fencing token")
+	}
+	if _, err := rw.TryRLock(ctx, "report", time.Second); !errors.Is(err, ErrLockNotAcquired) {
+		t.Errorf("Expected read lock to wait for writer, got %v", err)
+	}
+
+	if err := writer.Unlock(); err != nil {
+		t.Fatalf("Failed to release write lock: %v", err)
+	}
+	if _, err := rw.TryRLock(ctx, "report", time.Second); err != nil {
+		t.Errorf("Expected read lock after writer released: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/qiaojinxia/distributed-service/framework/logger"
+	"go.uber.org/zap"
+)
+
+// Semaphore Redis计数信号量
+//
+// 每个键最多允许permits个持有者同时持有，用于限制对第三方接口等资源的并发访问。
+// 每次 Lock 占用一个许可，句柄 Unlock 时归还；持有者崩溃时许可随ttl过期自动归还。
+// 实现 DistributedLock 接口，每次获取都分配递增的令牌。
+type Semaphore struct {
+	client  *redis.Client
+	prefix  string
+	permits int
+	holders zsetHolders
+}
+
+// NewSemaphore 创建Redis计数信号量，permits小于1时按1处理
+func NewSemaphore(client *redis.Client, prefix string, permits int) *Semaphore {
+	if prefix == "" {
+		prefix = "semaphore:"
+	}
+	if permits < 1 {
+		permits = 1
+	}
+	return &Semaphore{
+		client:  client,
+		prefix:  prefix,
+		permits: permits,
+		holders: zsetHolders{client: client},
+	}
+}
+
+// Lock 获取一个许可（阻塞直到获取成功或上下文取消）
+func (s *Semaphore) Lock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
+	return s.LockWithRetry(ctx, key, ttl, 100*time.Millisecond, -1)
+}
+
+// TryLock 尝试获取一个许可（不阻塞），许可已用完时返回 ErrLockNotAcquired
+func (s *Semaphore) TryLock(ctx context.Context, key string, ttl time.Duration) (*Handle, error) {
+	value := generateLockValue()
+
+	// 使用Lua脚本确保原子性：清理过期许可后检查剩余数量
+	luaScript := luaNowMs + `
+		redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now)
+		if redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[3]) then
+			return 0
+		end
+		redis.call("ZADD", KEYS[1], now + tonumber(ARGV[2]), ARGV[1])
+	` + luaKeepZSetAlive + `
+		return redis.call("INCR", KEYS[2])
+	`
+
+	keys := []string{s.getHoldersKey(key), s.getTokenKey(key)}
+	token, err := s.client.Eval(ctx, luaScript, keys, value, ttlMilliseconds(ttl), s.permits).Int64()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire semaphore: %w", err)
+	}
+
+	if token == 0 {
+		return nil, ErrLockNotAcquired
+	}
+
+	handle := newHandle(key, value, token, ttl, s)
+
+	logger.Debug(ctx, "Semaphore acquired",
+		zap.String("key", key),
+		zap.String("value", value),
+		zap.Int("permits", s.permits),
+		zap.Int64("token", token),
+		zap.Duration("ttl", ttl))
+
+	return handle, nil
+}
+
+// LockWithRetry 带重试的获取许可
+func (s *Semaphore) LockWithRetry(ctx context.Context, key string, ttl time.Duration, retryInterval time.Duration, maxRetries int) (*Handle, error) {
+	return retryLock(ctx, retryInterval, maxRetries, func() (*Handle, error) {
+		return s.TryLock(ctx, key, ttl)
+	})
+}
+
+// StartAutoRenew 启动自动续期
+func (s *Semaphore) StartAutoRenew(handle *Handle, renewInterval time.Duration) {
+	handle.startAutoRenew(renewInterval)
+}
+
+// Available 当前剩余的许可数量
+func (s *Semaphore) Available(ctx context.Context, key string) (int, error) {
+	used, err := s.holders.count(ctx, s.getHoldersKey(key))
+	if err != nil {
+		return 0, err
+	}
+	if available := s.permits - int(used); available > 0 {
+		return available, nil
+	}
+	return 0, nil
+}
+
+// Permits 每个键的许可总数
+func (s *Semaphore) Permits() int {
+	return s.permits
+}
+
+// unlock 归还许可（内部方法）
+func (s *Semaphore) unlock(handle *Handle) error {
+	return s.holders.release(context.Background(), s.getHoldersKey(handle.Key), handle.Value)
+}
+
+// extend 延长许可的过期时间（内部方法）
+func (s *Semaphore) extend(handle *Handle, ttl time.Duration) error {
+	return s.holders.extend(context.Background(), s.getHoldersKey(handle.Key), handle.Value, ttl)
+}
+
+// getHoldersKey 获取许可持有者集合键名
+func (s *Semaphore) getHoldersKey(key string) string {
+	return s.prefix + key
+}
+
+// getTokenKey 获取令牌计数器键名
+func (s *Semaphore) getTokenKey(key string) string {
+	return tokenKey(s.prefix, key)
+}
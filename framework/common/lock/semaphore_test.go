@@ -0,0 +1,56 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSemaphore(t *testing.T) {
+	server, client := newTestRedis(t)
+	semaphore := NewSemaphore(client, "", 2)
+	ctx := context.Background()
+
+	first, err := semaphore.TryLock(ctx, "export", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire permit: %v", err)
+	}
+	second, err := semaphore.TryLock(ctx, "export", 500*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to acquire permit: %v", err)
+	}
+	if _, err := semaphore.TryLock(ctx, "export", time.Second); !errors.Is(err, ErrLockNotAcquired) {
+		t.Errorf("Expected permits to be exhausted, got %v", err)
+	}
+	if available, _ := semaphore.Available(ctx, "export"); available != 0 {
+		t.Errorf("Expected 0 available permits, got %d", available)
+	}
+
+	// 续期后的许可不会过期，未续期的许可按毫秒精度过期
+	if err := first.Extend(2 * time.Second); err != nil {
+		t.Fatalf("Failed to extend permit: %v", err)
+	}
+	advance(server, 600*time.Millisecond)
+	if available, _ := semaphore.Available(ctx, "export"); available != 1 {
+		t.Errorf("Expected expired permit to be returned, got %d available", available)
+	}
+	if err := second.Extend(time.Second); !errors.Is(err, ErrLockNotOwned) {
+		t.Errorf("Expected ErrLockNotOwned for expired permit, got %v", err)
+	}
+
+	third, err := semaphore.TryLock(ctx, "export", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire returned permit: %v", err)
+	}
+	if third.Token <= second.Token {
+		t.Errorf("Expected increasing tokens, got %d after %d", third.Token, second.Token)
+	}
+
+	if err := first.Unlock(); err != nil {
+		t.Fatalf("Failed to release permit: %v", err)
+	}
+	if available, _ := semaphore.Available(ctx, "export"); available != 1 {
+		t.Errorf("Expected 1 available permit, got %d", available)
+	}
+}
@@ -3,6 +3,7 @@ package component
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/qiaojinxia/distributed-service/framework/auth"
 	"github.com/qiaojinxia/distributed-service/framework/cache"
@@ -982,6 +983,11 @@ func (m *Manager) initCache(ctx context.Context) error {
 	}
 
 	if cacheConfig != nil && cacheConfig.Enabled {
+		// 内存缓存默认配置（淘汰策略、LFU衰减等），需在创建缓存实例前设置
+		if err := m.cacheService.SetMemoryDefaults(cacheConfig.Memory); err != nil {
+			return fmt.Errorf("invalid cache memory config: %w", err)
+		}
+
 		// 根据配置创建缓存实例
 		for name, instanceCfg := range cacheConfig.Caches {
 			if err := m.createCacheFromConfig(name, instanceCfg, cacheConfig); err != nil {
@@ -993,6 +999,11 @@ func (m *Manager) initCache(ctx context.Context) error {
 			logger.Info(ctx, "✅ Cache instance created", 
 				logger.String("name", name), 
 				logger.String("type", instanceCfg.Type))
+
+			// 在HTTP服务启动前完成预热，避免冷缓存击穿后端
+			if err := m.warmupCache(ctx, name, instanceCfg); err != nil {
+				return err
+			}
 		}
 	} else {
 		// 如果没有配置或配置未启用，创建默认的内存缓存实例
@@ -1044,6 +1055,19 @@ func (m *Manager) createCacheFromConfig(name string, instanceCfg config.CacheIns
 		// 创建Redis缓存
 		return m.cacheService.CreateRedisCache(name, keyPrefix)
 
+	case "redis_cluster", "redis_sentinel", "memcached":
+		// 创建Redis Cluster / Sentinel / Memcached缓存，连接参数来自Settings
+		settings := make(map[string]interface{}, len(instanceCfg.Settings)+1)
+		for k, v := range instanceCfg.Settings {
+			settings[k] = v
+		}
+		settings["key_prefix"] = keyPrefix
+		return m.cacheService.Manager.CreateCache(cache.Config{
+			Type:     cache.Type(instanceCfg.Type),
+			Name:     name,
+			Settings: settings,
+		})
+
 	case "hybrid":
 		// 创建混合缓存
 		l1Config := cache.Config{
@@ -1054,6 +1078,15 @@ func (m *Manager) createCacheFromConfig(name string, instanceCfg config.CacheIns
 				"default_ttl": "5m",
 			},
 		}
+		if _, ok := instanceCfg.Settings["snapshot_path"]; ok {
+			logger.Warn(context.Background(), "snapshot_path is ignored for hybrid caches, use l1_snapshot_path to opt in",
+				logger.String("name", name))
+		}
+		if snapshotPath, ok := instanceCfg.Settings["l1_snapshot_path"]; ok {
+			// L1快照需要显式开启：停机期间错过的失效不会反映到快照中，
+			// 恢复的条目在L1的剩余TTL内（最长 default_ttl）可能比L2旧
+			l1Config.Settings["snapshot_path"] = snapshotPath
+		}
 		return m.cacheService.CreateHybridCache(name, l1Config, keyPrefix, cache.SyncStrategyWriteThrough)
 
 	default:
@@ -1061,6 +1094,41 @@ func (m *Manager) createCacheFromConfig(name string, instanceCfg config.CacheIns
 	}
 }
 
+// warmupCache 按缓存实例 Settings 中的 warmup 配置预热，只有配置了 required 时失败才中止启动
+func (m *Manager) warmupCache(ctx context.Context, name string, instanceCfg config.CacheInstance) error {
+	warmupCfg, err := cache.ParseWarmupConfig(instanceCfg.Settings)
+	if err != nil {
+		return fmt.Errorf("cache %s: %w", name, err)
+	}
+	if warmupCfg == nil {
+		return nil
+	}
+
+	instance, err := m.cacheService.Manager.GetCache(name)
+	if err != nil {
+		return fmt.Errorf("cache %s: %w", name, err)
+	}
+
+	start := time.Now()
+	loaded, err := cache.Warmup(ctx, instance, *warmupCfg)
+	if err != nil {
+		if warmupCfg.Required {
+			return fmt.Errorf("failed to warm up cache %s: %w", name, err)
+		}
+		logger.Warn(ctx, "Cache warm-up failed",
+			logger.String("name", name),
+			logger.Int("loaded", loaded),
+			logger.Err(err))
+		return nil
+	}
+
+	logger.Info(ctx, "✅ Cache warmed up",
+		logger.String("name", name),
+		logger.Int("loaded", loaded),
+		logger.Duration("duration", time.Since(start)))
+	return nil
+}
+
 // initIDGen 初始化ID生成器
 func (m *Manager) initIDGen(ctx context.Context) error {
 	logger.Info(ctx, "🆔 Initializing ID generator...")
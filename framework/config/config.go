@@ -25,6 +25,7 @@ type Config struct {
 	Etcd          EtcdConfig          `mapstructure:"etcd"`
 	Cache         CacheConfig         `mapstructure:"cache"`
 	IDGen         IDGenConfig         `mapstructure:"idgen"`
+	Lock          LockConfig          `mapstructure:"lock"`
 }
 
 type ServerConfig struct {
@@ -285,11 +286,12 @@ type CacheConfig struct {
 	GlobalKeyPrefix string                  `mapstructure:"global_key_prefix"` // 全局键前缀
 	DefaultTTL      string                  `mapstructure:"default_ttl"`       // 默认过期时间
 	Caches          map[string]CacheInstance `mapstructure:"caches"`           // 预定义缓存实例
+	Memory          CacheMemoryConfig       `mapstructure:"memory"`            // 内存缓存默认配置，缓存实例的Settings可覆盖
 }
 
 // CacheInstance 缓存实例配置
 type CacheInstance struct {
-	Type      string                 `mapstructure:"type"`       // 缓存类型
+	Type      string                 `mapstructure:"type"`       // 缓存类型 (memory, redis, redis_cluster, redis_sentinel, memcached, hybrid)
 	KeyPrefix string                 `mapstructure:"key_prefix"` // 键前缀
 	TTL       string                 `mapstructure:"ttl"`        // 过期时间
 	Settings  map[string]interface{} `mapstructure:"settings"`   // 自定义设置
@@ -297,16 +299,18 @@ type CacheInstance struct {
 
 // CacheMemoryConfig 内存缓存配置
 type CacheMemoryConfig struct {
-	MaxSize         int    `mapstructure:"max_size"`         // 最大条目数
-	CleanupInterval string `mapstructure:"cleanup_interval"` // 清理间隔
-	EvictionPolicy  string `mapstructure:"eviction_policy"`  // 淘汰策略 (lru, lfu, fifo, random, ttl)
-	EnableMetrics   bool   `mapstructure:"enable_metrics"`   // 启用指标收集
-	EnableCallbacks bool   `mapstructure:"enable_callbacks"` // 启用回调函数
-	ShardCount      int    `mapstructure:"shard_count"`      // 分片数量，提高并发性能
-	PreAllocSize    int    `mapstructure:"pre_alloc_size"`   // 预分配大小
-	TTLVariance     string `mapstructure:"ttl_variance"`     // TTL变化范围
-	LFUDecayRate    string `mapstructure:"lfu_decay_rate"`   // LFU衰减率
-	LFUMinFreq      int64  `mapstructure:"lfu_min_freq"`     // LFU最小频率
+	MaxSize          int    `mapstructure:"max_size"`           // 最大条目数
+	MaxBytes         int64  `mapstructure:"max_bytes"`          // 最大字节数，大于0时按条目大小淘汰
+	CleanupInterval  string `mapstructure:"cleanup_interval"`   // 清理间隔
+	EvictionPolicy   string `mapstructure:"eviction_policy"`    // 淘汰策略 (lru, lfu, fifo, random, arc, tinylfu, ttl, simple)
+	EnableMetrics    bool   `mapstructure:"enable_metrics"`     // 启用指标收集
+	EnableCallbacks  bool   `mapstructure:"enable_callbacks"`   // 启用回调函数
+	ShardCount       int    `mapstructure:"shard_count"`        // 分片数量，提高并发性能
+	PreAllocSize     int    `mapstructure:"pre_alloc_size"`     // 预分配大小
+	TTLVariance      string `mapstructure:"ttl_variance"`       // TTL变化范围
+	LFUDecayRate     string `mapstructure:"lfu_decay_rate"`     // LFU衰减率
+	LFUMinFreq       int64  `mapstructure:"lfu_min_freq"`       // LFU最小频率
+	LFUDecayInterval string `mapstructure:"lfu_decay_interval"` // LFU衰减周期
 }
 
 // CacheRedisConfig Redis缓存配置
@@ -340,15 +344,44 @@ type CacheWriteBackConfig struct {
 	BatchSize int    `mapstructure:"batch_size"` // 批次大小
 }
 
+// LockConfig 分布式锁配置
+type LockConfig struct {
+	Backend string `mapstructure:"backend"` // 锁后端 (redis, etcd)，默认redis
+	Prefix  string `mapstructure:"prefix"`  // 锁键前缀，为空时使用各后端的默认前缀
+}
+
 // IDGenConfig ID生成器配置
 type IDGenConfig struct {
 	Enabled         bool                    `mapstructure:"enabled"`           // 是否启用ID生成器
-	Type            string                  `mapstructure:"type"`              // 生成器类型 (leaf, snowflake)
+	Type            string                  `mapstructure:"type"`              // 生成器类型 (leaf, snowflake, ksortable/ulid/uuidv7)
 	UseFramework    bool                    `mapstructure:"use_framework"`     // 是否使用框架数据库配置
 	DefaultStep     int32                   `mapstructure:"default_step"`      // 默认步长
 	Database        IDGenDatabaseConfig     `mapstructure:"database"`          // 数据库配置（不使用框架时）
 	Leaf            IDGenLeafConfig         `mapstructure:"leaf"`              // Leaf配置
+	Snowflake       IDGenSnowflakeConfig    `mapstructure:"snowflake"`         // Snowflake配置
+	KSortable       IDGenKSortableConfig    `mapstructure:"ksortable"`         // 128位有序ID配置
 	BizTags         map[string]IDGenBizTag  `mapstructure:"biz_tags"`          // 预定义业务标识
+	Codec           IDGenCodecConfig        `mapstructure:"codec"`             // 不透明ID编码配置
+	Server          IDGenServerConfig       `mapstructure:"server"`            // 对外服务配置
+}
+
+// IDGenServerConfig ID生成服务对外暴露配置，供非Go服务通过HTTP或gRPC获取ID
+type IDGenServerConfig struct {
+	HTTP       bool   `mapstructure:"http"`        // 在框架HTTP服务上注册REST接口
+	GRPC       bool   `mapstructure:"grpc"`        // 在框架gRPC服务上注册IDGenService
+	HTTPPrefix string `mapstructure:"http_prefix"` // REST接口路由前缀，默认 /idgen
+	Auth       bool   `mapstructure:"auth"`        // 所有接口要求JWT认证（需配置jwt）
+	Management bool   `mapstructure:"management"`  // 开放业务标识增删改接口，需同时开启auth，调用方须具有admin角色
+}
+
+// IDGenKSortableConfig 128位有序ID（ULID/UUIDv7）配置
+type IDGenKSortableConfig struct {
+	RegionID uint8 `mapstructure:"region_id"` // 写入每个ID的区域（数据中心）ID
+}
+
+// IDGenCodecConfig 不透明ID编码配置
+type IDGenCodecConfig struct {
+	Secret string `mapstructure:"secret"` // 编码密钥，各服务实例需保持一致
 }
 
 // IDGenDatabaseConfig ID生成器数据库配置
@@ -375,6 +408,23 @@ type IDGenLeafConfig struct {
 	MaxStepSize      int32  `mapstructure:"max_step_size"`     // 最大步长
 	MinStepSize      int32  `mapstructure:"min_step_size"`     // 最小步长
 	StepAdjustRatio  string `mapstructure:"step_adjust_ratio"` // 步长调整比例
+	Storage          string `mapstructure:"storage"`           // 号段存储 (database, redis, etcd)
+	KeyPrefix        string `mapstructure:"key_prefix"`        // Redis/etcd号段键前缀
+}
+
+// IDGenSnowflakeConfig Snowflake算法配置
+type IDGenSnowflakeConfig struct {
+	Epoch           string `mapstructure:"epoch"`             // 起始时间 (RFC3339或2006-01-02)
+	TimestampBits   uint8  `mapstructure:"timestamp_bits"`    // 时间戳位数
+	WorkerIDBits    uint8  `mapstructure:"worker_id_bits"`    // 机器ID位数
+	SequenceBits    uint8  `mapstructure:"sequence_bits"`     // 序列号位数
+	RegionBits      uint8  `mapstructure:"region_bits"`       // 区域（数据中心）ID位数
+	RegionID        int64  `mapstructure:"region_id"`         // 区域（数据中心）ID
+	WorkerID        int64  `mapstructure:"worker_id"`         // 静态机器ID
+	WorkerIDSource  string `mapstructure:"worker_id_source"`  // 机器ID来源 (static, redis, etcd)
+	KeyPrefix       string `mapstructure:"key_prefix"`        // 机器ID租约键前缀
+	LeaseTTL        string `mapstructure:"lease_ttl"`         // 机器ID租约有效期
+	MaxBackwardWait string `mapstructure:"max_backward_wait"` // 允许等待的最大时钟回拨时长
 }
 
 // IDGenBizTag 业务标识配置
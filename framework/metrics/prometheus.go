@@ -67,6 +67,41 @@ var (
 		},
 		[]string{"cache"},
 	)
+
+	// CacheTierHits cache hits per tier, hybrid caches report l1 and l2 separately
+	CacheTierHits = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_tier_hits_total",
+			Help: "Total number of cache hits per tier",
+		},
+		[]string{"cache", "tier"},
+	)
+
+	CacheTierMisses = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_tier_misses_total",
+			Help: "Total number of cache misses per tier",
+		},
+		[]string{"cache", "tier"},
+	)
+
+	// CacheOperationDuration cache operation latency, buckets from 50µs to ~3s
+	CacheOperationDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cache_operation_duration_seconds",
+			Help:    "Cache operation duration in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.00005, 2.5, 13),
+		},
+		[]string{"cache", "tier", "operation"},
+	)
+
+	CacheErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_errors_total",
+			Help: "Total number of failed cache operations",
+		},
+		[]string{"cache", "tier", "operation"},
+	)
 )
 
 // MeasureDatabaseQuery measures the execution time of a database operation
@@ -13,7 +13,7 @@ import (
 // JWTAuth creates a JWT authentication middleware
 func JWTAuth(jwtManager *auth.JWTManager) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx := c.MustGet("ctx").(context.Context)
+		ctx := requestContext(c)
 
 		// Get the Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -57,6 +57,7 @@ func JWTAuth(jwtManager *auth.JWTManager) gin.HandlerFunc {
 		// Add user info to context
 		c.Set("user_id", claims.UserID)
 		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
 
 		// Update context with user info
 		userCtx := context.WithValue(ctx, "user_id", claims.UserID)
@@ -76,7 +77,7 @@ func JWTAuth(jwtManager *auth.JWTManager) gin.HandlerFunc {
 // If token is provided, it validates it, but doesn't require authentication
 func OptionalJWTAuth(jwtManager *auth.JWTManager) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx := c.MustGet("ctx").(context.Context)
+		ctx := requestContext(c)
 
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
@@ -97,6 +98,7 @@ func OptionalJWTAuth(jwtManager *auth.JWTManager) gin.HandlerFunc {
 			// Token is valid, add user info to context
 			c.Set("user_id", claims.UserID)
 			c.Set("username", claims.Username)
+			c.Set("role", claims.Role)
 
 			userCtx := context.WithValue(ctx, "user_id", claims.UserID)
 			userCtx = context.WithValue(userCtx, "username", claims.Username)
@@ -106,3 +108,35 @@ func OptionalJWTAuth(jwtManager *auth.JWTManager) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequireRole creates a middleware that only lets through users authenticated
+// by JWTAuth whose role is one of roles
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		logger.Warn(requestContext(c), "Insufficient role",
+			logger.String("username", c.GetString("username")),
+			logger.String("role", role),
+		)
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		c.Abort()
+	}
+}
+
+// requestContext returns the context set by the tracing middleware, falling
+// back to the request context when the route is mounted without it
+func requestContext(c *gin.Context) context.Context {
+	if ctx, ok := c.Get("ctx"); ok {
+		if requestCtx, ok := ctx.(context.Context); ok {
+			return requestCtx
+		}
+	}
+	return c.Request.Context()
+}
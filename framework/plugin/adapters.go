@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/qiaojinxia/distributed-service/framework/config"
+	"github.com/qiaojinxia/distributed-service/framework/database"
 	"github.com/qiaojinxia/distributed-service/pkg/etcd"
 	"github.com/qiaojinxia/distributed-service/pkg/kafka"
 	"github.com/qiaojinxia/distributed-service/pkg/redis_cluster"
@@ -436,6 +437,45 @@ func (p *SchedulerPlugin) initialize(ctx context.Context, config Config) error {
 		}
 	})
 
+	// 设置分布式任务协调器（distributed_backend: redis, etcd）
+	switch backend := config.GetString("distributed_backend"); backend {
+	case "":
+	case "redis":
+		if database.RedisClient == nil {
+			return fmt.Errorf("framework redis not initialized")
+		}
+		scheduler.SetCoordinator(NewRedisTaskCoordinator(database.RedisClient, config.GetString("lock_prefix")))
+	case "etcd":
+		client := etcd.GetClient()
+		if client == nil {
+			return fmt.Errorf("etcd client not initialized")
+		}
+		scheduler.SetCoordinator(NewEtcdTaskCoordinator(client, config.GetString("lock_prefix")))
+	default:
+		return fmt.Errorf("unsupported distributed backend: %s", backend)
+	}
+
+	// 设置任务持久化存储（store: gorm, redis）
+	switch store := config.GetString("store"); store {
+	case "":
+	case "gorm":
+		if database.DB == nil {
+			return fmt.Errorf("framework database not initialized")
+		}
+		gormStore := NewGormTaskStore(database.DB)
+		if err := gormStore.CreateTable(ctx); err != nil {
+			return err
+		}
+		scheduler.SetStore(gormStore)
+	case "redis":
+		if database.RedisClient == nil {
+			return fmt.Errorf("framework redis not initialized")
+		}
+		scheduler.SetStore(NewRedisTaskStore(database.RedisClient, config.GetString("store_prefix")))
+	default:
+		return fmt.Errorf("unsupported task store: %s", store)
+	}
+
 	p.scheduler = scheduler
 	p.SetService(scheduler)
 
@@ -483,6 +523,16 @@ func (p *SchedulerPlugin) ScheduleTask(task *Task) error {
 	return p.scheduler.ScheduleTask(task)
 }
 
+// RegisterHandler 注册具名处理函数，用于恢复持久化的任务
+func (p *SchedulerPlugin) RegisterHandler(name string, handler TaskHandler) error {
+	scheduler, ok := p.scheduler.(*DefaultTaskScheduler)
+	if !ok {
+		return fmt.Errorf("scheduler not initialized")
+	}
+	scheduler.RegisterHandler(name, handler)
+	return nil
+}
+
 // LoggerPlugin Logger插件适配器
 type LoggerPlugin struct {
 	*BaseServicePlugin
@@ -0,0 +1,372 @@
+// 本文件中cron字段的位图表示、字段解析以及 CronSchedule.Next 的逐字段查找算法
+// 改编自 github.com/robfig/cron/v3 (parser.go, spec.go)，原许可证如下：
+//
+// Copyright (C) 2012 Rob Figueiredo
+// All Rights Reserved.
+//
+// MIT LICENSE
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package plugin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule 解析后的cron表达式
+//
+// 支持的格式:
+//   - 5段: 分 时 日 月 周
+//   - 6段: 秒 分 时 日 月 周
+//   - 预定义: @yearly(@annually) @monthly @weekly @daily(@midnight) @hourly
+//   - 固定间隔: @every <duration>，如 "@every 90s"
+//
+// 每个字段支持 *、?、数值、范围(a-b)、步长(*/n, a-b/n, a/n)以及逗号分隔的列表，
+// 月份和星期支持英文缩写(JAN-DEC, SUN-SAT)，星期中0和7均表示周日。
+// 表达式可以使用 "CRON_TZ=Asia/Shanghai " 或 "TZ=Asia/Shanghai " 前缀指定时区。
+type CronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+
+	every    time.Duration  // @every 间隔，非0时忽略其他字段
+	location *time.Location // 时区，为nil时使用计算起点的时区
+}
+
+// cronBounds 字段取值范围
+type cronBounds struct {
+	min, max uint
+	names    map[string]uint
+}
+
+// starBit 标记字段为通配（*或?），用于日和星期的匹配语义
+const starBit = 1 << 63
+
+var (
+	cronSeconds = cronBounds{0, 59, nil}
+	cronMinutes = cronBounds{0, 59, nil}
+	cronHours   = cronBounds{0, 23, nil}
+	cronDom     = cronBounds{1, 31, nil}
+	cronMonths  = cronBounds{1, 12, map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	cronDow = cronBounds{0, 7, map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronDescriptors 预定义表达式
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// cronSearchYears 查找下次运行时间的最大年数，超过则认为表达式永不触发
+const cronSearchYears = 5
+
+// ParseCron 解析cron表达式
+func ParseCron(expr string) (*CronSchedule, error) {
+	return ParseCronInLocation(expr, nil)
+}
+
+// ParseCronInLocation 解析cron表达式，并使用指定时区计算运行时间（表达式中的时区前缀优先）
+func ParseCronInLocation(expr string, loc *time.Location) (*CronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if spec == "" {
+		return nil, fmt.Errorf("empty cron expression")
+	}
+
+	// 解析时区前缀
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		i := strings.Index(spec, " ")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid cron expression %q: missing fields after time zone", expr)
+		}
+		name := spec[strings.Index(spec, "=")+1 : i]
+		zone, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", name, err)
+		}
+		loc = zone
+		spec = strings.TrimSpace(spec[i:])
+	}
+
+	schedule := &CronSchedule{location: loc}
+
+	if strings.HasPrefix(spec, "@") {
+		if strings.HasPrefix(spec, "@every ") {
+			interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+			if err != nil {
+				return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+			}
+			if interval <= 0 {
+				return nil, fmt.Errorf("invalid cron expression %q: interval must be positive", expr)
+			}
+			schedule.every = interval
+			return schedule, nil
+		}
+
+		descriptor, ok := cronDescriptors[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported cron descriptor: %s", spec)
+		}
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 or 6 fields, got %d", expr, len(fields))
+	}
+
+	var err error
+	parsers := []struct {
+		target *uint64
+		bounds cronBounds
+		name   string
+	}{
+		{&schedule.second, cronSeconds, "second"},
+		{&schedule.minute, cronMinutes, "minute"},
+		{&schedule.hour, cronHours, "hour"},
+		{&schedule.dom, cronDom, "day of month"},
+		{&schedule.month, cronMonths, "month"},
+		{&schedule.dow, cronDow, "day of week"},
+	}
+	for i, p := range parsers {
+		if *p.target, err = parseCronField(fields[i], p.bounds); err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %s field: %w", expr, p.name, err)
+		}
+	}
+
+	// 星期中的7与0同为周日
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = schedule.dow&^(1<<7) | 1
+	}
+
+	return schedule, nil
+}
+
+// parseCronField 解析单个字段，返回取值位图
+func parseCronField(field string, bounds cronBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		b, err := parseCronRange(part, bounds)
+		if err != nil {
+			return 0, err
+		}
+		bits |= b
+	}
+	return bits, nil
+}
+
+// parseCronRange 解析范围表达式: * ? n a-b */s a-b/s a/s
+func parseCronRange(expr string, bounds cronBounds) (uint64, error) {
+	rangeAndStep := strings.Split(expr, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, fmt.Errorf("too many slashes: %s", expr)
+	}
+
+	var start, end uint
+	var extra uint64
+	lowAndHigh := strings.Split(rangeAndStep[0], "-")
+
+	if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
+		if len(lowAndHigh) > 1 {
+			return 0, fmt.Errorf("invalid range: %s", expr)
+		}
+		start, end = bounds.min, bounds.max
+		if len(rangeAndStep) == 1 {
+			extra = starBit
+		}
+	} else {
+		var err error
+		if start, err = parseCronValue(lowAndHigh[0], bounds); err != nil {
+			return 0, err
+		}
+		switch len(lowAndHigh) {
+		case 1:
+			end = start
+			// a/s 表示从a开始到最大值
+			if len(rangeAndStep) == 2 {
+				end = bounds.max
+			}
+		case 2:
+			if end, err = parseCronValue(lowAndHigh[1], bounds); err != nil {
+				return 0, err
+			}
+		default:
+			return 0, fmt.Errorf("too many hyphens: %s", expr)
+		}
+	}
+
+	step := uint(1)
+	if len(rangeAndStep) == 2 {
+		s, err := strconv.ParseUint(rangeAndStep[1], 10, 0)
+		if err != nil || s == 0 {
+			return 0, fmt.Errorf("invalid step: %s", expr)
+		}
+		step = uint(s)
+	}
+
+	if start < bounds.min || end > bounds.max {
+		return 0, fmt.Errorf("value out of range [%d, %d]: %s", bounds.min, bounds.max, expr)
+	}
+	if start > end {
+		return 0, fmt.Errorf("range start %d is beyond end %d: %s", start, end, expr)
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << i
+	}
+	return bits | extra, nil
+}
+
+// parseCronValue 解析数值或名称
+func parseCronValue(value string, bounds cronBounds) (uint, error) {
+	if bounds.names != nil {
+		if v, ok := bounds.names[strings.ToLower(value)]; ok {
+			return v, nil
+		}
+	}
+	v, err := strconv.ParseUint(value, 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value: %s", value)
+	}
+	return uint(v), nil
+}
+
+// Location 返回调度使用的时区，nil表示使用计算起点的时区
+func (c *CronSchedule) Location() *time.Location {
+	return c.location
+}
+
+// Next 返回from之后的下一次运行时间，永不触发时返回零值
+//
+// 查找算法改编自 robfig/cron 的 SpecSchedule.Next（MIT许可证，见文件头部）。
+func (c *CronSchedule) Next(from time.Time) time.Time {
+	if c.every > 0 {
+		return from.Add(c.every)
+	}
+
+	loc := c.location
+	if loc == nil {
+		loc = from.Location()
+	}
+	origLoc := from.Location()
+
+	// 从下一整秒开始查找
+	t := from.In(loc).Add(time.Second - time.Duration(from.Nanosecond())*time.Nanosecond)
+	yearLimit := t.Year() + cronSearchYears
+
+	// 某个字段进位时，更低的字段需要从最小值开始
+	added := false
+
+WRAP:
+	for {
+		if t.Year() > yearLimit {
+			return time.Time{}
+		}
+
+		for 1<<uint(t.Month())&c.month == 0 {
+			if !added {
+				added = true
+				t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+			}
+			t = t.AddDate(0, 1, 0)
+			if t.Month() == time.January {
+				continue WRAP
+			}
+		}
+
+		for !c.dayMatches(t) {
+			if !added {
+				added = true
+				t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+			}
+			t = t.AddDate(0, 0, 1)
+			// 夏令时切换可能导致零点偏移，修正回当天零点
+			if t.Hour() != 0 {
+				if t.Hour() > 12 {
+					t = t.Add(time.Duration(24-t.Hour()) * time.Hour)
+				} else {
+					t = t.Add(time.Duration(-t.Hour()) * time.Hour)
+				}
+			}
+			if t.Day() == 1 {
+				continue WRAP
+			}
+		}
+
+		for 1<<uint(t.Hour())&c.hour == 0 {
+			if !added {
+				added = true
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+			}
+			t = t.Add(time.Hour)
+			if t.Hour() == 0 {
+				continue WRAP
+			}
+		}
+
+		for 1<<uint(t.Minute())&c.minute == 0 {
+			if !added {
+				added = true
+				t = t.Truncate(time.Minute)
+			}
+			t = t.Add(time.Minute)
+			if t.Minute() == 0 {
+				continue WRAP
+			}
+		}
+
+		for 1<<uint(t.Second())&c.second == 0 {
+			if !added {
+				added = true
+				t = t.Truncate(time.Second)
+			}
+			t = t.Add(time.Second)
+			if t.Second() == 0 {
+				continue WRAP
+			}
+		}
+
+		return t.In(origLoc)
+	}
+}
+
+// dayMatches 日和星期均有限定时满足其一即可，否则需同时满足
+func (c *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := 1<<uint(t.Day())&c.dom > 0
+	dowMatch := 1<<uint(t.Weekday())&c.dow > 0
+	if c.dom&starBit > 0 || c.dow&starBit > 0 {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,220 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/qiaojinxia/distributed-service/framework/common/lock"
+	"github.com/qiaojinxia/distributed-service/pkg/etcd"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// TaskCoordinator 分布式任务协调器，保证同一次调度在集群中只被一个实例执行
+type TaskCoordinator interface {
+	// Acquire 尝试占有key对应的一次调度，已被其他实例占有时返回false以及持有者信息
+	Acquire(ctx context.Context, key string, ttl time.Duration) (*TaskLockInfo, bool, error)
+
+	// InstanceID 当前实例标识
+	InstanceID() string
+}
+
+// TaskLockInfo 分布式任务锁持有者信息
+type TaskLockInfo struct {
+	Key         string    `json:"key"`
+	Holder      string    `json:"holder"`                 // 持有者实例标识
+	Owned       bool      `json:"owned"`                  // 是否由当前实例持有
+	ScheduledAt time.Time `json:"scheduled_at,omitempty"` // 对应的调度时间
+	AcquiredAt  time.Time `json:"acquired_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// MissedRunPolicy 错过调度时间（调度器阻塞、暂停或任务执行过久）时的补偿策略
+type MissedRunPolicy int
+
+const (
+	// MissedRunSkip 跳过错过的调度，等待下一次
+	MissedRunSkip MissedRunPolicy = iota
+	// MissedRunOnce 立即补跑一次（对应最近一次错过的调度）
+	MissedRunOnce
+	// MissedRunCatchUp 依次补跑每一次错过的调度，最多 MaxCatchUp 次
+	MissedRunCatchUp
+)
+
+func (p MissedRunPolicy) String() string {
+	switch p {
+	case MissedRunSkip:
+		return "skip"
+	case MissedRunOnce:
+		return "once"
+	case MissedRunCatchUp:
+		return "catch_up"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// defaultTaskLockTTL 默认调度锁有效期，需覆盖集群内各实例的时钟偏差
+	defaultTaskLockTTL = time.Minute
+	// defaultMaxCatchUp 默认最大补跑次数
+	defaultMaxCatchUp = 10
+	// missedRunTolerance 调度延迟超过该值才视为错过
+	missedRunTolerance = time.Second
+	// maxMissedRunScan 计算错过的调度时最多向后查找的次数
+	maxMissedRunScan = 10000
+)
+
+// DefaultInstanceID 默认实例标识（主机名:进程号）
+func DefaultInstanceID() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}
+
+// occurrenceKey 某次调度对应的锁键，同一任务同一调度时间在各实例上一致
+func occurrenceKey(taskID string, scheduledAt time.Time) string {
+	return fmt.Sprintf("task:%s:%d", taskID, scheduledAt.UnixMilli())
+}
+
+// RedisTaskCoordinator 基于 lock.RedisLock 的任务协调器
+//
+// 调度锁不在执行后主动释放，而是等待过期，避免时钟稍慢的实例在锁释放后重复执行同一次调度。
+type RedisTaskCoordinator struct {
+	client     *redis.Client
+	locker     *lock.RedisLock
+	prefix     string
+	instanceID string
+}
+
+// NewRedisTaskCoordinator 创建Redis任务协调器
+func NewRedisTaskCoordinator(client *redis.Client, prefix string) *RedisTaskCoordinator {
+	if prefix == "" {
+		prefix = "scheduler:"
+	}
+	return &RedisTaskCoordinator{
+		client:     client,
+		locker:     lock.NewRedisLock(client, prefix+"lock:"),
+		prefix:     prefix,
+		instanceID: DefaultInstanceID(),
+	}
+}
+
+// Acquire 尝试占有一次调度
+func (c *RedisTaskCoordinator) Acquire(ctx context.Context, key string, ttl time.Duration) (*TaskLockInfo, bool, error) {
+	holderKey := c.prefix + "holder:" + key
+
+	handle, err := c.locker.TryLock(ctx, key, ttl)
+	if err != nil {
+		if !errors.Is(err, lock.ErrLockNotAcquired) {
+			return nil, false, err
+		}
+
+		// 读取持有者信息
+		info := &TaskLockInfo{Key: key}
+		data, err := c.client.Get(ctx, holderKey).Bytes()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, false, fmt.Errorf("failed to get lock holder: %w", err)
+		}
+		if len(data) > 0 {
+			_ = json.Unmarshal(data, info)
+		}
+		return info, false, nil
+	}
+
+	info := &TaskLockInfo{
+		Key:        key,
+		Holder:     c.instanceID,
+		AcquiredAt: handle.CreatedAt,
+		ExpiresAt:  handle.CreatedAt.Add(ttl),
+	}
+	if data, err := json.Marshal(info); err == nil {
+		if err := c.client.Set(ctx, holderKey, data, ttl).Err(); err != nil {
+			return nil, false, fmt.Errorf("failed to record lock holder: %w", err)
+		}
+	}
+	return info, true, nil
+}
+
+// InstanceID 当前实例标识
+func (c *RedisTaskCoordinator) InstanceID() string {
+	return c.instanceID
+}
+
+// EtcdTaskCoordinator 基于etcd租约的任务协调器
+type EtcdTaskCoordinator struct {
+	client     *etcd.Client
+	prefix     string
+	instanceID string
+}
+
+// NewEtcdTaskCoordinator 创建etcd任务协调器
+func NewEtcdTaskCoordinator(client *etcd.Client, prefix string) *EtcdTaskCoordinator {
+	if prefix == "" {
+		prefix = "/scheduler/"
+	}
+	return &EtcdTaskCoordinator{
+		client:     client,
+		prefix:     prefix,
+		instanceID: DefaultInstanceID(),
+	}
+}
+
+// Acquire 申请租约并通过事务创建调度键，键已存在时返回当前持有者
+func (c *EtcdTaskCoordinator) Acquire(ctx context.Context, key string, ttl time.Duration) (*TaskLockInfo, bool, error) {
+	seconds := int64(ttl.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	lease, err := c.client.GrantLease(ctx, seconds)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	info := &TaskLockInfo{
+		Key:        key,
+		Holder:     c.instanceID,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(time.Duration(seconds) * time.Second),
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		_ = c.client.RevokeLease(context.Background(), lease.ID)
+		return nil, false, fmt.Errorf("failed to marshal lock holder: %w", err)
+	}
+
+	fullKey := c.prefix + key
+	resp, err := c.client.Transaction(ctx,
+		[]clientv3.Cmp{clientv3.Compare(clientv3.CreateRevision(fullKey), "=", 0)},
+		[]clientv3.Op{clientv3.OpPut(fullKey, string(data), clientv3.WithLease(lease.ID))},
+		[]clientv3.Op{clientv3.OpGet(fullKey)},
+	)
+	if err != nil {
+		_ = c.client.RevokeLease(context.Background(), lease.ID)
+		return nil, false, fmt.Errorf("failed to acquire task lock: %w", err)
+	}
+	if resp.Succeeded {
+		return info, true, nil
+	}
+
+	// 已被其他实例占有，释放本次申请的租约
+	_ = c.client.RevokeLease(context.Background(), lease.ID)
+
+	holder := &TaskLockInfo{Key: key}
+	if len(resp.Responses) > 0 {
+		if kvs := resp.Responses[0].GetResponseRange().GetKvs(); len(kvs) > 0 {
+			_ = json.Unmarshal(kvs[0].Value, holder)
+		}
+	}
+	return holder, false, nil
+}
+
+// InstanceID 当前实例标识
+func (c *EtcdTaskCoordinator) InstanceID() string {
+	return c.instanceID
+}
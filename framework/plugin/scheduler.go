@@ -2,8 +2,11 @@ package plugin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -36,9 +39,23 @@ type Task struct {
 	Description string                 `json:"description"`
 	Schedule    *Schedule              `json:"schedule"`
 	Handler     TaskHandler            `json:"-"`
+	HandlerName string                 `json:"handler_name,omitempty"` // 已注册处理函数的名称，用于重启后恢复任务
 	Status      TaskStatus             `json:"status"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 
+	// 分布式执行
+	Distributed bool          `json:"distributed"`           // 是否在集群中单实例执行
+	LockTTL     time.Duration `json:"lock_ttl,omitempty"`    // 调度锁有效期
+	LockHolder  *TaskLockInfo `json:"lock_holder,omitempty"` // 最近一次调度的锁持有者
+
+	// 执行策略
+	Retry             *RetryPolicy          `json:"retry,omitempty"`         // 重试策略
+	Timeout           time.Duration         `json:"timeout,omitempty"`       // 单次执行超时
+	OverlapPolicy     OverlapPolicy         `json:"overlap_policy"`          // 执行重叠策略
+	HistoryLimit      int                   `json:"history_limit,omitempty"` // 保留的执行记录数
+	History           []TaskRun             `json:"history,omitempty"`       // 最近的执行记录
+	DeadLetterHandler TaskDeadLetterHandler `json:"-"`                       // 重试耗尽后的处理函数
+
 	// 执行统计
 	CreatedAt    time.Time `json:"created_at"`
 	LastRunAt    time.Time `json:"last_run_at,omitempty"`
@@ -49,16 +66,25 @@ type Task struct {
 	// 内部状态
 	cancelFunc context.CancelFunc
 	mu         sync.RWMutex
+	running    int32          // 执行中及排队中的调度数
+	queue      chan time.Time // 排队策略下等待执行的调度
+	inflight   sync.WaitGroup
+	removed    int32      // 已取消并从存储删除，不再持久化
+	persistMu  sync.Mutex // 串行化该任务的存储写入和删除，避免删除后被进行中的保存写回
 }
 
 // Schedule 调度配置
 type Schedule struct {
 	Type     ScheduleType  `json:"type"`
-	Cron     string        `json:"cron,omitempty"`     // cron表达式
-	Interval time.Duration `json:"interval,omitempty"` // 间隔时间
-	Delay    time.Duration `json:"delay,omitempty"`    // 延迟时间
-	RunOnce  bool          `json:"run_once,omitempty"` // 是否只执行一次
-	MaxRuns  int64         `json:"max_runs,omitempty"` // 最大执行次数
+	Cron     string        `json:"cron,omitempty"`      // cron表达式
+	TimeZone string        `json:"time_zone,omitempty"` // cron时区，如 Asia/Shanghai，默认本地时区
+	Interval time.Duration `json:"interval,omitempty"`  // 间隔时间
+	Delay    time.Duration `json:"delay,omitempty"`     // 延迟时间
+	RunOnce  bool          `json:"run_once,omitempty"`  // 是否只执行一次
+	MaxRuns  int64         `json:"max_runs,omitempty"`  // 最大执行次数
+
+	MissedRunPolicy MissedRunPolicy `json:"missed_run_policy"`      // 错过调度时的补偿策略
+	MaxCatchUp      int             `json:"max_catch_up,omitempty"` // 最大补跑次数
 }
 
 // TaskStatus 任务状态
@@ -128,6 +154,9 @@ const (
 	TaskEventCanceled
 	TaskEventPaused
 	TaskEventResumed
+	TaskEventSkipped
+	TaskEventRetrying
+	TaskEventDeadLetter
 )
 
 func (t TaskEventType) String() string {
@@ -146,6 +175,12 @@ func (t TaskEventType) String() string {
 		return "paused"
 	case TaskEventResumed:
 		return "resumed"
+	case TaskEventSkipped:
+		return "skipped"
+	case TaskEventRetrying:
+		return "retrying"
+	case TaskEventDeadLetter:
+		return "dead_letter"
 	default:
 		return "unknown"
 	}
@@ -160,12 +195,26 @@ type DefaultTaskScheduler struct {
 	ctx          context.Context
 	cancel       context.CancelFunc
 	logger       Logger
+	coordinator  TaskCoordinator
+	instanceID   string
+	store        TaskStore
+	handlers     map[string]TaskHandler
+	restored     map[string]*restoredTask // 已从存储加载、尚未重新调度的任务
+}
+
+// restoredTask 从存储加载的任务记录及其执行记录
+type restoredTask struct {
+	record *TaskRecord
+	runs   []TaskRun
 }
 
 // NewDefaultTaskScheduler 创建默认任务调度器
 func NewDefaultTaskScheduler() *DefaultTaskScheduler {
 	return &DefaultTaskScheduler{
-		tasks: make(map[string]*Task),
+		tasks:      make(map[string]*Task),
+		instanceID: DefaultInstanceID(),
+		handlers:   make(map[string]TaskHandler),
+		restored:   make(map[string]*restoredTask),
 	}
 }
 
@@ -174,18 +223,76 @@ func (s *DefaultTaskScheduler) SetLogger(logger Logger) {
 	s.logger = logger
 }
 
-// Start 启动调度器
-func (s *DefaultTaskScheduler) Start(ctx context.Context) error {
+// SetCoordinator 设置分布式任务协调器，Distributed任务依赖它保证集群内单实例执行
+func (s *DefaultTaskScheduler) SetCoordinator(coordinator TaskCoordinator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.coordinator = coordinator
+	if coordinator != nil {
+		s.instanceID = coordinator.InstanceID()
+	}
+}
+
+// SetStore 设置任务持久化存储，需在Start之前调用
+func (s *DefaultTaskScheduler) SetStore(store TaskStore) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.store = store
+}
+
+// RegisterHandler 注册具名处理函数，存储中 HandlerName 匹配的任务会在启动时自动恢复
+func (s *DefaultTaskScheduler) RegisterHandler(name string, handler TaskHandler) {
+	s.mu.Lock()
+	s.handlers[name] = handler
+
+	// 调度器已运行时，立即恢复使用该处理函数的任务
+	var restored []*Task
+	if s.running {
+		restored = s.restoreTasksLocked()
+	}
+	s.mu.Unlock()
+
+	for _, task := range restored {
+		s.saveTask(task)
+	}
+}
 
+// Start 启动调度器
+func (s *DefaultTaskScheduler) Start(ctx context.Context) error {
+	if s.IsRunning() {
+		return fmt.Errorf("scheduler is already running")
+	}
+
+	// 在持有s.mu之前从存储加载任务，避免存储I/O阻塞调度器
+	var loaded map[string]*restoredTask
+	if store := s.taskStore(); store != nil {
+		var err error
+		if loaded, err = s.loadTasks(ctx, store); err != nil {
+			return fmt.Errorf("failed to load tasks from store: %w", err)
+		}
+	}
+
+	s.mu.Lock()
 	if s.running {
+		s.mu.Unlock()
 		return fmt.Errorf("scheduler is already running")
 	}
 
 	s.ctx, s.cancel = context.WithCancel(ctx)
 	s.running = true
 
+	for id, restored := range loaded {
+		if _, exists := s.tasks[id]; !exists {
+			s.restored[id] = restored
+		}
+	}
+	restored := s.restoreTasksLocked()
+	s.mu.Unlock()
+
+	for _, task := range restored {
+		s.saveTask(task)
+	}
+
 	if s.logger != nil {
 		s.logger.Info("Task scheduler started")
 	}
@@ -204,9 +311,11 @@ func (s *DefaultTaskScheduler) Stop(ctx context.Context) error {
 
 	// 取消所有任务
 	for _, task := range s.tasks {
+		task.mu.RLock()
 		if task.cancelFunc != nil {
 			task.cancelFunc()
 		}
+		task.mu.RUnlock()
 	}
 
 	if s.cancel != nil {
@@ -240,11 +349,25 @@ func (s *DefaultTaskScheduler) ScheduleTask(task *Task) error {
 	}
 
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if !s.running {
+		s.mu.Unlock()
 		return fmt.Errorf("scheduler is not running")
 	}
+	err := s.scheduleTaskLocked(task)
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	s.saveTask(task)
+	return nil
+}
+
+// scheduleTaskLocked 调度任务（调用方需持有s.mu），不做持久化，调用方释放s.mu后调用 saveTask
+func (s *DefaultTaskScheduler) scheduleTaskLocked(task *Task) error {
+	if task.Distributed && s.coordinator == nil {
+		return fmt.Errorf("distributed task '%s' requires a task coordinator", task.ID)
+	}
 
 	// 检查任务是否已存在
 	if _, exists := s.tasks[task.ID]; exists {
@@ -255,11 +378,31 @@ func (s *DefaultTaskScheduler) ScheduleTask(task *Task) error {
 	task.Status = TaskStatusPending
 	task.CreatedAt = time.Now()
 
+	// 恢复持久化的运行状态，任务定义以本次调度为准
+	restored := s.restored[task.ID]
+	delete(s.restored, task.ID)
+	var record *TaskRecord
+	if restored != nil {
+		record = restored.record
+		applyRecord(task, restored)
+	}
+
+	// 已完成的任务不再运行
+	if task.Status == TaskStatusCompleted {
+		s.tasks[task.ID] = task
+		return nil
+	}
+
 	// 计算下次运行时间
 	nextRun, err := s.calculateNextRun(task)
 	if err != nil {
 		return fmt.Errorf("failed to calculate next run time: %w", err)
 	}
+	// 停机期间错过的调度按补偿策略处理
+	if record != nil && !record.NextRunAt.IsZero() && record.NextRunAt.Before(nextRun) &&
+		task.Schedule.Type != ScheduleTypeOnce && task.Schedule.MissedRunPolicy != MissedRunSkip {
+		nextRun = record.NextRunAt
+	}
 	task.NextRunAt = nextRun
 
 	// 注册任务
@@ -286,10 +429,9 @@ func (s *DefaultTaskScheduler) ScheduleTask(task *Task) error {
 // CancelTask 取消任务
 func (s *DefaultTaskScheduler) CancelTask(taskID string) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	task, exists := s.tasks[taskID]
 	if !exists {
+		s.mu.Unlock()
 		return fmt.Errorf("task with ID '%s' not found", taskID)
 	}
 
@@ -299,8 +441,22 @@ func (s *DefaultTaskScheduler) CancelTask(taskID string) error {
 	}
 	task.Status = TaskStatusCanceled
 	task.mu.Unlock()
+	atomic.StoreInt32(&task.removed, 1)
 
 	delete(s.tasks, taskID)
+	store := s.store
+	s.mu.Unlock()
+
+	if store != nil {
+		// 等待进行中的保存完成后再删除，之后的保存会看到removed标记而跳过
+		task.persistMu.Lock()
+		ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+		if err := store.DeleteTask(ctx, taskID); err != nil && s.logger != nil {
+			s.logger.Error("Failed to delete task from store", "task", taskID, "error", err)
+		}
+		cancel()
+		task.persistMu.Unlock()
+	}
 
 	// 发送事件
 	s.sendEvent(&TaskEvent{
@@ -328,10 +484,11 @@ func (s *DefaultTaskScheduler) PauseTask(taskID string) error {
 	}
 
 	task.mu.Lock()
-	if task.Status == TaskStatusRunning {
+	if task.Status == TaskStatusRunning || task.Status == TaskStatusPending {
 		task.Status = TaskStatusPaused
 	}
 	task.mu.Unlock()
+	s.saveTask(task)
 
 	// 发送事件
 	s.sendEvent(&TaskEvent{
@@ -359,6 +516,7 @@ func (s *DefaultTaskScheduler) ResumeTask(taskID string) error {
 		task.Status = TaskStatusPending
 	}
 	task.mu.Unlock()
+	s.saveTask(task)
 
 	// 发送事件
 	s.sendEvent(&TaskEvent{
@@ -378,8 +536,7 @@ func (s *DefaultTaskScheduler) GetTask(taskID string) *Task {
 
 	if task, exists := s.tasks[taskID]; exists {
 		// 返回副本
-		taskCopy := *task
-		return &taskCopy
+		return task.snapshot()
 	}
 	return nil
 }
@@ -391,8 +548,7 @@ func (s *DefaultTaskScheduler) GetAllTasks() map[string]*Task {
 
 	result := make(map[string]*Task)
 	for id, task := range s.tasks {
-		taskCopy := *task
-		result[id] = &taskCopy
+		result[id] = task.snapshot()
 	}
 	return result
 }
@@ -404,12 +560,9 @@ func (s *DefaultTaskScheduler) GetTasksByStatus(status TaskStatus) []*Task {
 
 	var result []*Task
 	for _, task := range s.tasks {
-		task.mu.RLock()
-		if task.Status == status {
-			taskCopy := *task
-			result = append(result, &taskCopy)
+		if taskCopy := task.snapshot(); taskCopy.Status == status {
+			result = append(result, taskCopy)
 		}
-		task.mu.RUnlock()
 	}
 	return result
 }
@@ -424,13 +577,26 @@ func (s *DefaultTaskScheduler) SetEventHandler(handler TaskEventHandler) {
 // runTask 运行任务
 func (s *DefaultTaskScheduler) runTask(task *Task) {
 	taskCtx, taskCancel := context.WithCancel(s.ctx)
+	task.mu.Lock()
 	task.cancelFunc = taskCancel
+	task.mu.Unlock()
+
+	if task.OverlapPolicy == OverlapQueue {
+		task.queue = make(chan time.Time, overlapQueueSize)
+		go s.runQueue(taskCtx, task)
+	}
+
+	catchUps := 0
 
 	for {
+		task.mu.RLock()
+		scheduledAt := task.NextRunAt
+		task.mu.RUnlock()
+
 		select {
 		case <-taskCtx.Done():
 			return
-		case <-time.After(time.Until(task.NextRunAt)):
+		case <-time.After(time.Until(scheduledAt)):
 			task.mu.RLock()
 			if task.Status == TaskStatusPaused {
 				task.mu.RUnlock()
@@ -440,18 +606,32 @@ func (s *DefaultTaskScheduler) runTask(task *Task) {
 			task.mu.RUnlock()
 
 			// 执行任务
-			s.executeTask(taskCtx, task)
+			s.dispatch(taskCtx, task, scheduledAt)
+
+			// 有次数限制的任务等待执行结束后再判断是否继续
+			if task.Schedule.RunOnce || task.Schedule.MaxRuns > 0 {
+				if !waitInflight(taskCtx, task) {
+					return
+				}
+			}
 
 			// 计算下次运行时间
+			task.mu.RLock()
+			runCount := task.RunCount
+			task.mu.RUnlock()
 			if task.Schedule.RunOnce ||
-				(task.Schedule.MaxRuns > 0 && task.RunCount >= task.Schedule.MaxRuns) {
+				(task.Schedule.MaxRuns > 0 && runCount >= task.Schedule.MaxRuns) {
 				task.mu.Lock()
 				task.Status = TaskStatusCompleted
 				task.mu.Unlock()
+				s.saveTask(task)
 				return
 			}
 
-			nextRun, err := s.calculateNextRun(task)
+			nextRun, err := s.calculateNextRunAfter(task, scheduledAt)
+			if err == nil {
+				nextRun, catchUps, err = s.applyMissedRunPolicy(task, nextRun, catchUps)
+			}
 			if err != nil {
 				if s.logger != nil {
 					s.logger.Error("Failed to calculate next run", "task", task.ID, "error", err)
@@ -459,21 +639,249 @@ func (s *DefaultTaskScheduler) runTask(task *Task) {
 				task.mu.Lock()
 				task.Status = TaskStatusFailed
 				task.mu.Unlock()
+				s.saveTask(task)
 				return
 			}
 
 			task.mu.Lock()
 			task.NextRunAt = nextRun
 			task.mu.Unlock()
+			s.saveTask(task)
+		}
+	}
+}
+
+// dispatch 按重叠策略分发一次调度
+func (s *DefaultTaskScheduler) dispatch(ctx context.Context, task *Task, scheduledAt time.Time) {
+	switch task.OverlapPolicy {
+	case OverlapQueue:
+		atomic.AddInt32(&task.running, 1)
+		task.inflight.Add(1)
+		select {
+		case task.queue <- scheduledAt:
+		default:
+			atomic.AddInt32(&task.running, -1)
+			task.inflight.Done()
+			s.skipOccurrence(task, scheduledAt, "overlap queue is full", nil)
+		}
+		return
+
+	case OverlapSkip:
+		if atomic.LoadInt32(&task.running) > 0 {
+			s.skipOccurrence(task, scheduledAt, "previous run still in progress", nil)
+			return
+		}
+	}
+
+	atomic.AddInt32(&task.running, 1)
+	task.inflight.Add(1)
+	go func() {
+		defer task.inflight.Done()
+		defer atomic.AddInt32(&task.running, -1)
+		s.runOccurrence(ctx, task, scheduledAt)
+	}()
+}
+
+// runQueue 排队策略下依次执行等待中的调度
+func (s *DefaultTaskScheduler) runQueue(ctx context.Context, task *Task) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case scheduledAt := <-task.queue:
+			s.runOccurrence(ctx, task, scheduledAt)
+			atomic.AddInt32(&task.running, -1)
+			task.inflight.Done()
+		}
+	}
+}
+
+// waitInflight 等待执行中的调度结束，任务被取消时返回false
+func waitInflight(ctx context.Context, task *Task) bool {
+	done := make(chan struct{})
+	go func() {
+		task.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// runOccurrence 执行一次调度，分布式任务需先在集群中占有该次调度
+func (s *DefaultTaskScheduler) runOccurrence(ctx context.Context, task *Task, scheduledAt time.Time) {
+	if task.Distributed {
+		acquired, err := s.acquireOccurrence(ctx, task, scheduledAt)
+		if err != nil {
+			task.mu.Lock()
+			task.FailureCount++
+			task.mu.Unlock()
+
+			s.recordRun(task, TaskRun{
+				ScheduledAt: scheduledAt,
+				Status:      TaskRunFailed,
+				Error:       err.Error(),
+				Instance:    s.instanceID,
+			})
+
+			s.sendEvent(&TaskEvent{
+				Type:      TaskEventFailed,
+				TaskID:    task.ID,
+				TaskName:  task.Name,
+				Timestamp: time.Now(),
+				Error:     err,
+			})
+
+			if s.logger != nil {
+				s.logger.Error("Failed to acquire task lock", "task", task.ID, "error", err)
+			}
+			return
+		}
+
+		if !acquired {
+			task.mu.RLock()
+			holder := task.LockHolder
+			task.mu.RUnlock()
+
+			s.skipOccurrence(task, scheduledAt, "held by "+holder.Holder, map[string]interface{}{"lock_holder": holder})
+			return
+		}
+	}
+
+	s.executeTask(ctx, task, scheduledAt)
+}
+
+// skipOccurrence 记录被跳过的调度
+func (s *DefaultTaskScheduler) skipOccurrence(task *Task, scheduledAt time.Time, reason string, data map[string]interface{}) {
+	s.recordRun(task, TaskRun{
+		ScheduledAt: scheduledAt,
+		Status:      TaskRunSkipped,
+		Error:       reason,
+		Instance:    s.instanceID,
+	})
+
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+	data["reason"] = reason
+	data["scheduled_at"] = scheduledAt
+
+	s.sendEvent(&TaskEvent{
+		Type:      TaskEventSkipped,
+		TaskID:    task.ID,
+		TaskName:  task.Name,
+		Timestamp: time.Now(),
+		Data:      data,
+	})
+
+	if s.logger != nil {
+		s.logger.Debug("Task occurrence skipped", "task", task.ID, "reason", reason)
+	}
+}
+
+// acquireOccurrence 通过协调器占有一次调度，并记录锁持有者
+func (s *DefaultTaskScheduler) acquireOccurrence(ctx context.Context, task *Task, scheduledAt time.Time) (bool, error) {
+	s.mu.RLock()
+	coordinator := s.coordinator
+	s.mu.RUnlock()
+
+	if coordinator == nil {
+		return false, fmt.Errorf("task coordinator not set")
+	}
+
+	ttl := task.LockTTL
+	if ttl <= 0 {
+		ttl = defaultTaskLockTTL
+	}
+
+	key := occurrenceKey(task.ID, scheduledAt)
+	info, acquired, err := coordinator.Acquire(ctx, key, ttl)
+	if err != nil {
+		return false, err
+	}
+	holder := TaskLockInfo{Key: key}
+	if info != nil {
+		holder = *info
+	}
+	holder.ScheduledAt = scheduledAt
+	holder.Owned = acquired && holder.Holder == coordinator.InstanceID()
+
+	task.mu.Lock()
+	task.LockHolder = &holder
+	task.mu.Unlock()
+
+	return acquired, nil
+}
+
+// applyMissedRunPolicy 下次运行时间已过期时按补偿策略调整，返回新的运行时间和连续补跑次数
+func (s *DefaultTaskScheduler) applyMissedRunPolicy(task *Task, nextRun time.Time, catchUps int) (time.Time, int, error) {
+	now := time.Now()
+	if now.Sub(nextRun) <= missedRunTolerance {
+		return nextRun, 0, nil
+	}
+
+	maxCatchUp := task.Schedule.MaxCatchUp
+	if maxCatchUp <= 0 {
+		maxCatchUp = defaultMaxCatchUp
+	}
+
+	switch task.Schedule.MissedRunPolicy {
+	case MissedRunCatchUp:
+		if catchUps < maxCatchUp {
+			return nextRun, catchUps + 1, nil
+		}
+	case MissedRunOnce:
+		// 补跑最近一次错过的调度，使各实例得到一致的调度时间
+		if catchUps == 0 {
+			latest := nextRun
+			for i := 0; i < maxMissedRunScan; i++ {
+				candidate, err := s.calculateNextRunAfter(task, latest)
+				if err != nil || candidate.After(now) {
+					break
+				}
+				latest = candidate
+			}
+			return latest, 1, nil
+		}
+	}
+
+	if s.logger != nil {
+		s.logger.Warn("Skipping missed task runs", "task", task.ID, "missed_since", nextRun)
+	}
+
+	// 跳过错过的调度，从当前时间重新计算
+	for i := 0; i < maxMissedRunScan && !nextRun.After(now); i++ {
+		next, err := s.calculateNextRunAfter(task, nextRun)
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+		nextRun = next
+	}
+	if !nextRun.After(now) {
+		next, err := s.calculateNextRunAfter(task, now)
+		if err != nil {
+			return time.Time{}, 0, err
 		}
+		nextRun = next
 	}
+	return nextRun, 0, nil
 }
 
-// executeTask 执行任务
-func (s *DefaultTaskScheduler) executeTask(ctx context.Context, task *Task) {
+// executeTask 执行任务，失败时按重试策略重试
+func (s *DefaultTaskScheduler) executeTask(ctx context.Context, task *Task, scheduledAt time.Time) {
+	run := TaskRun{
+		ScheduledAt: scheduledAt,
+		StartedAt:   time.Now(),
+		Instance:    s.instanceID,
+	}
+
 	task.mu.Lock()
 	task.Status = TaskStatusRunning
-	task.LastRunAt = time.Now()
+	task.LastRunAt = run.StartedAt
 	task.RunCount++
 	task.mu.Unlock()
 
@@ -485,47 +893,307 @@ func (s *DefaultTaskScheduler) executeTask(ctx context.Context, task *Task) {
 		Timestamp: time.Now(),
 	})
 
-	// 执行任务处理器
-	err := task.Handler(ctx, task)
+	maxAttempts := 1
+	if task.Retry != nil && task.Retry.MaxAttempts > 1 {
+		maxAttempts = task.Retry.MaxAttempts
+	}
 
-	if err != nil {
-		task.mu.Lock()
-		task.Status = TaskStatusPending
-		task.FailureCount++
-		task.mu.Unlock()
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		run.Attempts = attempt
 
-		// 发送失败事件
+		// 执行任务处理器
+		err = s.invokeHandler(ctx, task)
+		if err == nil || attempt == maxAttempts || ctx.Err() != nil {
+			break
+		}
+
+		backoff := task.Retry.Backoff(attempt)
+
+		// 发送重试事件
 		s.sendEvent(&TaskEvent{
-			Type:      TaskEventFailed,
+			Type:      TaskEventRetrying,
 			TaskID:    task.ID,
 			TaskName:  task.Name,
 			Timestamp: time.Now(),
-			Error:     err,
+			Data: map[string]interface{}{
+				"attempt":      attempt,
+				"max_attempts": maxAttempts,
+				"backoff":      backoff.String(),
+			},
+			Error: err,
 		})
 
 		if s.logger != nil {
-			s.logger.Error("Task execution failed", "task", task.ID, "error", err)
+			s.logger.Warn("Task execution failed, retrying", "task", task.ID, "attempt", attempt, "backoff", backoff, "error", err)
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+		if ctx.Err() != nil {
+			break
 		}
-	} else {
-		task.mu.Lock()
+	}
+
+	run.FinishedAt = time.Now()
+	run.Duration = run.FinishedAt.Sub(run.StartedAt)
+	run.Status = runStatusOf(err)
+	if err != nil {
+		run.Error = err.Error()
+	}
+	s.recordRun(task, run)
+
+	task.mu.Lock()
+	if atomic.LoadInt32(&task.running) <= 1 && task.Status == TaskStatusRunning {
 		task.Status = TaskStatusPending
-		task.mu.Unlock()
+	}
+	if err != nil {
+		task.FailureCount++
+	}
+	task.mu.Unlock()
+	s.saveTask(task)
 
-		// 发送完成事件
+	if err != nil {
+		// 发送失败事件
 		s.sendEvent(&TaskEvent{
-			Type:      TaskEventCompleted,
+			Type:      TaskEventFailed,
 			TaskID:    task.ID,
 			TaskName:  task.Name,
 			Timestamp: time.Now(),
+			Error:     err,
 		})
 
 		if s.logger != nil {
-			s.logger.Debug("Task completed", "task", task.ID)
+			s.logger.Error("Task execution failed", "task", task.ID, "attempts", run.Attempts, "error", err)
+		}
+
+		// 配置了重试且次数耗尽才进入死信处理，调度器停止或任务取消导致的失败不算
+		if maxAttempts > 1 && run.Attempts == maxAttempts &&
+			ctx.Err() == nil && !errors.Is(err, context.Canceled) {
+			s.sendEvent(&TaskEvent{
+				Type:      TaskEventDeadLetter,
+				TaskID:    task.ID,
+				TaskName:  task.Name,
+				Timestamp: time.Now(),
+				Data:      map[string]interface{}{"run": run},
+				Error:     err,
+			})
+
+			if task.DeadLetterHandler != nil {
+				s.handleDeadLetter(task, &run)
+			}
+		}
+		return
+	}
+
+	// 发送完成事件
+	s.sendEvent(&TaskEvent{
+		Type:      TaskEventCompleted,
+		TaskID:    task.ID,
+		TaskName:  task.Name,
+		Timestamp: time.Now(),
+	})
+
+	if s.logger != nil {
+		s.logger.Debug("Task completed", "task", task.ID)
+	}
+}
+
+// invokeHandler 调用任务处理函数，处理超时和panic
+//
+// 超时后调度器不再等待处理函数返回，处理函数应响应ctx取消以尽快退出。
+func (s *DefaultTaskScheduler) invokeHandler(ctx context.Context, task *Task) error {
+	if task.Handler == nil {
+		return fmt.Errorf("task handler is nil")
+	}
+
+	if task.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, task.Timeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- &TaskPanicError{Value: r, Stack: debug.Stack()}
+			}
+		}()
+		done <- task.Handler(ctx, task)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w after %s", ErrTaskTimeout, task.Timeout)
+		}
+		return ctx.Err()
+	}
+}
+
+// handleDeadLetter 调用死信处理函数
+//
+// 处理函数使用独立的带超时上下文，不受本次执行的超时或取消影响。
+func (s *DefaultTaskScheduler) handleDeadLetter(task *Task, run *TaskRun) {
+	ctx, cancel := context.WithTimeout(context.Background(), deadLetterTimeout)
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil && s.logger != nil {
+			s.logger.Error("Task dead letter handler panicked", "task", task.ID, "panic", r)
+		}
+	}()
+	task.DeadLetterHandler(ctx, task, run)
+}
+
+// recordRun 记录执行结果，仅保留最近 HistoryLimit 条
+func (s *DefaultTaskScheduler) recordRun(task *Task, run TaskRun) {
+	limit := task.HistoryLimit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+
+	task.mu.Lock()
+	task.History = append(task.History, run)
+	if len(task.History) > limit {
+		task.History = append([]TaskRun(nil), task.History[len(task.History)-limit:]...)
+	}
+	task.mu.Unlock()
+
+	store := s.taskStore()
+	if store == nil {
+		return
+	}
+
+	task.persistMu.Lock()
+	defer task.persistMu.Unlock()
+	if atomic.LoadInt32(&task.removed) == 1 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	if err := store.AppendRun(ctx, task.ID, run, limit); err != nil && s.logger != nil {
+		s.logger.Error("Failed to append task run to store", "task", task.ID, "error", err)
+	}
+}
+
+// taskStore 返回任务存储（调用方不能持有s.mu）
+func (s *DefaultTaskScheduler) taskStore() TaskStore {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.store
+}
+
+// saveTask 持久化任务状态，失败只记录日志（调用方不能持有s.mu，避免存储I/O阻塞调度器）
+func (s *DefaultTaskScheduler) saveTask(task *Task) {
+	store := s.taskStore()
+	if store == nil {
+		return
+	}
+
+	// 检查removed和写入需在persistMu内完成，否则可能写回已被 CancelTask 删除的任务
+	task.persistMu.Lock()
+	defer task.persistMu.Unlock()
+	if atomic.LoadInt32(&task.removed) == 1 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	if err := store.SaveTask(ctx, newTaskRecord(task)); err != nil && s.logger != nil {
+		s.logger.Error("Failed to save task to store", "task", task.ID, "error", err)
+	}
+}
+
+// loadTasks 从存储加载任务及其执行记录（调用方不能持有s.mu）
+func (s *DefaultTaskScheduler) loadTasks(ctx context.Context, store TaskStore) (map[string]*restoredTask, error) {
+	records, err := store.LoadTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := make(map[string]*restoredTask, len(records))
+	for _, record := range records {
+		restored := &restoredTask{record: record}
+		loaded[record.ID] = restored
+
+		limit := record.HistoryLimit
+		if limit <= 0 {
+			limit = defaultHistoryLimit
+		}
+		runCtx, cancel := context.WithTimeout(ctx, storeTimeout)
+		restored.runs, err = store.LoadRuns(runCtx, record.ID, limit)
+		cancel()
+		if err != nil && s.logger != nil {
+			s.logger.Error("Failed to load task runs from store", "task", record.ID, "error", err)
+		}
+	}
+
+	if s.logger != nil {
+		s.logger.Info("Tasks loaded from store", "count", len(records))
+	}
+	return loaded, nil
+}
+
+// restoreTasksLocked 重新调度已注册处理函数的持久化任务（调用方需持有s.mu），
+// 返回需要在释放s.mu后持久化的任务
+func (s *DefaultTaskScheduler) restoreTasksLocked() []*Task {
+	var tasks []*Task
+	for id, restored := range s.restored {
+		record := restored.record
+		handler, ok := s.handlers[record.HandlerName]
+		if record.HandlerName == "" || !ok {
+			continue
+		}
+
+		task := newTaskFromRecord(record, handler)
+		if err := s.scheduleTaskLocked(task); err != nil {
+			if s.logger != nil {
+				s.logger.Error("Failed to restore task", "task", id, "error", err)
+			}
+			continue
+		}
+		if task.Status != TaskStatusCompleted {
+			tasks = append(tasks, task)
 		}
 	}
+	return tasks
+}
+
+// applyRecord 将持久化的运行状态和执行记录应用到任务，不访问存储（调用方持有s.mu）
+func applyRecord(task *Task, restored *restoredTask) {
+	record := restored.record
+	if !record.CreatedAt.IsZero() {
+		task.CreatedAt = record.CreatedAt
+	}
+	task.LastRunAt = record.LastRunAt
+	task.RunCount = record.RunCount
+	task.FailureCount = record.FailureCount
+
+	switch record.Status {
+	case TaskStatusPaused, TaskStatusCompleted:
+		task.Status = record.Status
+	}
+
+	limit := task.HistoryLimit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	runs := restored.runs
+	if len(runs) > limit {
+		runs = runs[len(runs)-limit:]
+	}
+	task.History = append([]TaskRun(nil), runs...)
 }
 
-// calculateNextRun 计算下次运行时间
+// calculateNextRun 计算首次运行时间
 func (s *DefaultTaskScheduler) calculateNextRun(task *Task) (time.Time, error) {
 	now := time.Now()
 
@@ -538,35 +1206,106 @@ func (s *DefaultTaskScheduler) calculateNextRun(task *Task) (time.Time, error) {
 
 	case ScheduleTypeInterval:
 		if task.RunCount == 0 {
+			if task.Distributed && task.Schedule.Interval > 0 {
+				return alignInterval(now.Add(task.Schedule.Delay), task.Schedule.Interval), nil
+			}
 			return now.Add(task.Schedule.Delay), nil
 		}
-		return now.Add(task.Schedule.Interval), nil
+		return s.calculateNextRunAfter(task, now)
 
 	case ScheduleTypeCron:
-		// 简单的cron解析（这里可以集成第三方cron库）
-		return s.parseCron(task.Schedule.Cron, now)
+		return s.parseCron(task.Schedule, now)
 
 	default:
 		return time.Time{}, fmt.Errorf("unsupported schedule type")
 	}
 }
 
-// parseCron 解析cron表达式（简化版本）
-func (s *DefaultTaskScheduler) parseCron(cronExpr string, from time.Time) (time.Time, error) {
-	// 这里是一个简化的实现，实际项目中建议使用专业的cron库如github.com/robfig/cron
-	switch cronExpr {
-	case "@every 1m":
-		return from.Add(time.Minute), nil
-	case "@every 5m":
-		return from.Add(5 * time.Minute), nil
-	case "@every 1h":
-		return from.Add(time.Hour), nil
-	case "@daily":
-		next := from.Add(24 * time.Hour)
-		return time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, next.Location()), nil
+// calculateNextRunAfter 计算某次调度之后的下一次运行时间
+func (s *DefaultTaskScheduler) calculateNextRunAfter(task *Task, scheduledAt time.Time) (time.Time, error) {
+	switch task.Schedule.Type {
+	case ScheduleTypeOnce:
+		return time.Time{}, fmt.Errorf("one-time task already executed")
+
+	case ScheduleTypeInterval:
+		if task.Schedule.Interval <= 0 {
+			return time.Time{}, fmt.Errorf("interval must be positive")
+		}
+		// 分布式任务按时间对齐，保证各实例的调度时间一致
+		if task.Distributed {
+			return alignInterval(scheduledAt, task.Schedule.Interval), nil
+		}
+		return time.Now().Add(task.Schedule.Interval), nil
+
+	case ScheduleTypeCron:
+		return s.parseCron(task.Schedule, scheduledAt)
+
 	default:
-		return time.Time{}, fmt.Errorf("unsupported cron expression: %s", cronExpr)
+		return time.Time{}, fmt.Errorf("unsupported schedule type")
+	}
+}
+
+// alignInterval 返回t之后第一个interval整数倍的时间点
+func alignInterval(t time.Time, interval time.Duration) time.Time {
+	return t.Truncate(interval).Add(interval)
+}
+
+// parseCron 根据cron表达式计算下次运行时间
+func (s *DefaultTaskScheduler) parseCron(schedule *Schedule, from time.Time) (time.Time, error) {
+	var loc *time.Location
+	if schedule.TimeZone != "" {
+		zone, err := time.LoadLocation(schedule.TimeZone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time zone %q: %w", schedule.TimeZone, err)
+		}
+		loc = zone
+	}
+
+	cronSchedule, err := ParseCronInLocation(schedule.Cron, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next := cronSchedule.Next(from)
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q never fires", schedule.Cron)
+	}
+	return next, nil
+}
+
+// snapshot 复制任务当前状态
+func (t *Task) snapshot() *Task {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	taskCopy := &Task{
+		ID:                t.ID,
+		Name:              t.Name,
+		Description:       t.Description,
+		Schedule:          t.Schedule,
+		Handler:           t.Handler,
+		HandlerName:       t.HandlerName,
+		Status:            t.Status,
+		Metadata:          t.Metadata,
+		Distributed:       t.Distributed,
+		OverlapPolicy:     t.OverlapPolicy,
+		DeadLetterHandler: t.DeadLetterHandler,
+		LockTTL:           t.LockTTL,
+		Retry:             t.Retry,
+		Timeout:           t.Timeout,
+		HistoryLimit:      t.HistoryLimit,
+		History:           append([]TaskRun(nil), t.History...),
+		CreatedAt:         t.CreatedAt,
+		LastRunAt:         t.LastRunAt,
+		NextRunAt:         t.NextRunAt,
+		RunCount:          t.RunCount,
+		FailureCount:      t.FailureCount,
+	}
+	if t.LockHolder != nil {
+		holder := *t.LockHolder
+		taskCopy.LockHolder = &holder
 	}
+	return taskCopy
 }
 
 // sendEvent 发送事件
@@ -605,13 +1344,25 @@ func (b *TaskBuilder) Handler(handler TaskHandler) *TaskBuilder {
 	return b
 }
 
-// Cron 设置cron调度
+// HandlerName 设置处理函数名称，配合 RegisterHandler 在重启后自动恢复任务
+func (b *TaskBuilder) HandlerName(name string) *TaskBuilder {
+	b.task.HandlerName = name
+	return b
+}
+
+// Cron 设置cron调度，支持5段/6段表达式、@hourly等预定义表达式以及 @every <duration>
 func (b *TaskBuilder) Cron(cronExpr string) *TaskBuilder {
 	b.task.Schedule.Type = ScheduleTypeCron
 	b.task.Schedule.Cron = cronExpr
 	return b
 }
 
+// TimeZone 设置cron调度使用的时区
+func (b *TaskBuilder) TimeZone(name string) *TaskBuilder {
+	b.task.Schedule.TimeZone = name
+	return b
+}
+
 // Interval 设置间隔调度
 func (b *TaskBuilder) Interval(interval time.Duration) *TaskBuilder {
 	b.task.Schedule.Type = ScheduleTypeInterval
@@ -639,6 +1390,55 @@ func (b *TaskBuilder) MaxRuns(max int64) *TaskBuilder {
 	return b
 }
 
+// Distributed 开启集群单实例执行，同一次调度只会在一个实例上运行
+func (b *TaskBuilder) Distributed() *TaskBuilder {
+	b.task.Distributed = true
+	return b
+}
+
+// LockTTL 设置分布式调度锁有效期
+func (b *TaskBuilder) LockTTL(ttl time.Duration) *TaskBuilder {
+	b.task.LockTTL = ttl
+	return b
+}
+
+// MissedRun 设置错过调度时的补偿策略，maxCatchUp为最大补跑次数（0使用默认值）
+func (b *TaskBuilder) MissedRun(policy MissedRunPolicy, maxCatchUp int) *TaskBuilder {
+	b.task.Schedule.MissedRunPolicy = policy
+	b.task.Schedule.MaxCatchUp = maxCatchUp
+	return b
+}
+
+// Retry 设置重试策略
+func (b *TaskBuilder) Retry(policy *RetryPolicy) *TaskBuilder {
+	b.task.Retry = policy
+	return b
+}
+
+// Timeout 设置单次执行超时
+func (b *TaskBuilder) Timeout(timeout time.Duration) *TaskBuilder {
+	b.task.Timeout = timeout
+	return b
+}
+
+// Overlap 设置执行重叠策略
+func (b *TaskBuilder) Overlap(policy OverlapPolicy) *TaskBuilder {
+	b.task.OverlapPolicy = policy
+	return b
+}
+
+// HistoryLimit 设置保留的执行记录数
+func (b *TaskBuilder) HistoryLimit(limit int) *TaskBuilder {
+	b.task.HistoryLimit = limit
+	return b
+}
+
+// OnDeadLetter 设置重试耗尽后的处理函数
+func (b *TaskBuilder) OnDeadLetter(handler TaskDeadLetterHandler) *TaskBuilder {
+	b.task.DeadLetterHandler = handler
+	return b
+}
+
 // Metadata 设置元数据
 func (b *TaskBuilder) Metadata(key string, value interface{}) *TaskBuilder {
 	b.task.Metadata[key] = value
@@ -0,0 +1,294 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// memoryCoordinator 测试用的内存协调器，多个调度器共享同一个锁表模拟集群
+type memoryCoordinator struct {
+	locks      *sync.Map
+	instanceID string
+}
+
+func (c *memoryCoordinator) Acquire(ctx context.Context, key string, ttl time.Duration) (*TaskLockInfo, bool, error) {
+	info := &TaskLockInfo{Key: key, Holder: c.instanceID, AcquiredAt: time.Now(), ExpiresAt: time.Now().Add(ttl)}
+	actual, loaded := c.locks.LoadOrStore(key, info)
+	return actual.(*TaskLockInfo), !loaded, nil
+}
+
+func (c *memoryCoordinator) InstanceID() string {
+	return c.instanceID
+}
+
+func TestDefaultTaskScheduler_DistributedSingleton(t *testing.T) {
+	locks := &sync.Map{}
+	var runs int64
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	schedulers := make([]*DefaultTaskScheduler, 3)
+	for i := range schedulers {
+		scheduler := NewDefaultTaskScheduler()
+		scheduler.SetCoordinator(&memoryCoordinator{locks: locks, instanceID: string(rune('a' + i))})
+		if err := scheduler.Start(ctx); err != nil {
+			t.Fatalf("Failed to start scheduler: %v", err)
+		}
+		defer scheduler.Stop(ctx)
+
+		task := NewTaskBuilder("singleton", "singleton").
+			Interval(200 * time.Millisecond).
+			Distributed().
+			Handler(func(ctx context.Context, task *Task) error {
+				atomic.AddInt64(&runs, 1)
+				return nil
+			}).
+			Build()
+		if err := scheduler.ScheduleTask(task); err != nil {
+			t.Fatalf("Failed to schedule task: %v", err)
+		}
+		schedulers[i] = scheduler
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+
+	occurrences := 0
+	locks.Range(func(key, value interface{}) bool {
+		occurrences++
+		return true
+	})
+	if occurrences == 0 {
+		t.Fatal("Expected task to run at least once")
+	}
+	if got := atomic.LoadInt64(&runs); got != int64(occurrences) {
+		t.Errorf("Expected %d runs (one per occurrence), got %d", occurrences, got)
+	}
+
+	holder := schedulers[0].GetTask("singleton").LockHolder
+	if holder == nil || holder.Holder == "" {
+		t.Errorf("Expected lock holder info, got %+v", holder)
+	}
+}
+
+func TestDefaultTaskScheduler_DistributedRequiresCoordinator(t *testing.T) {
+	scheduler := NewDefaultTaskScheduler()
+	if err := scheduler.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start scheduler: %v", err)
+	}
+	defer scheduler.Stop(context.Background())
+
+	task := NewTaskBuilder("distributed", "distributed").
+		Interval(time.Second).
+		Distributed().
+		Handler(func(ctx context.Context, task *Task) error { return nil }).
+		Build()
+	if err := scheduler.ScheduleTask(task); err == nil {
+		t.Error("Expected error when scheduling distributed task without coordinator")
+	}
+}
+
+func TestDefaultTaskScheduler_MissedRunPolicy(t *testing.T) {
+	scheduler := NewDefaultTaskScheduler()
+	now := time.Now()
+	missed := now.Add(-5 * time.Minute).Truncate(time.Minute)
+
+	task := NewTaskBuilder("cron", "cron").Cron("* * * * *").Build()
+
+	next, _, err := scheduler.applyMissedRunPolicy(task, missed, 0)
+	if err != nil || !next.After(now) {
+		t.Errorf("skip: expected next run after now, got %v (err=%v)", next, err)
+	}
+
+	task.Schedule.MissedRunPolicy = MissedRunOnce
+	next, _, err = scheduler.applyMissedRunPolicy(task, missed, 0)
+	if err != nil || next.After(now) || now.Sub(next) > time.Minute {
+		t.Errorf("once: expected latest missed run, got %v (err=%v)", next, err)
+	}
+
+	task.Schedule.MissedRunPolicy = MissedRunCatchUp
+	task.Schedule.MaxCatchUp = 2
+	next, catchUps, _ := scheduler.applyMissedRunPolicy(task, missed, 0)
+	if !next.Equal(missed) || catchUps != 1 {
+		t.Errorf("catch up: expected %v with 1 catch-up, got %v with %d", missed, next, catchUps)
+	}
+	next, _, _ = scheduler.applyMissedRunPolicy(task, missed, 2)
+	if !next.After(now) {
+		t.Errorf("catch up: expected skip after reaching limit, got %v", next)
+	}
+}
+
+func TestDefaultTaskScheduler_RetryAndDeadLetter(t *testing.T) {
+	scheduler := NewDefaultTaskScheduler()
+	if err := scheduler.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start scheduler: %v", err)
+	}
+	defer scheduler.Stop(context.Background())
+
+	var attempts int64
+	deadLetters := make(chan *TaskRun, 1)
+	events := make(chan TaskEventType, 32)
+	scheduler.SetEventHandler(func(event *TaskEvent) {
+		events <- event.Type
+	})
+
+	task := NewTaskBuilder("retry", "retry").
+		Once(0).
+		Retry(&RetryPolicy{MaxAttempts: 3, InitialBackoff: 10 * time.Millisecond, Multiplier: 2}).
+		Handler(func(ctx context.Context, task *Task) error {
+			if atomic.AddInt64(&attempts, 1) == 2 {
+				panic("boom")
+			}
+			return errors.New("failed")
+		}).
+		OnDeadLetter(func(ctx context.Context, task *Task, run *TaskRun) {
+			if ctx.Err() != nil {
+				t.Errorf("Expected live dead letter context, got %v", ctx.Err())
+			}
+			deadLetters <- run
+		}).
+		Build()
+	if err := scheduler.ScheduleTask(task); err != nil {
+		t.Fatalf("Failed to schedule task: %v", err)
+	}
+
+	select {
+	case run := <-deadLetters:
+		if run.Attempts != 3 || run.Status != TaskRunFailed {
+			t.Errorf("Expected 3 failed attempts, got %d (%s)", run.Attempts, run.Status)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected dead letter handler to be called")
+	}
+
+	deadLetterEvent := false
+	timeout := time.After(time.Second)
+	for !deadLetterEvent {
+		select {
+		case eventType := <-events:
+			deadLetterEvent = eventType == TaskEventDeadLetter
+		case <-timeout:
+			t.Fatal("Expected dead letter event")
+		}
+	}
+
+	history := scheduler.GetTask("retry").History
+	if len(history) != 1 || history[0].Attempts != 3 {
+		t.Errorf("Expected one history record with 3 attempts, got %+v", history)
+	}
+}
+
+func TestDefaultTaskScheduler_NoDeadLetter(t *testing.T) {
+	scheduler := NewDefaultTaskScheduler()
+	if err := scheduler.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start scheduler: %v", err)
+	}
+	defer scheduler.Stop(context.Background())
+
+	deadLetters := make(chan string, 2)
+	events := make(chan *TaskEvent, 32)
+	scheduler.SetEventHandler(func(event *TaskEvent) {
+		events <- event
+	})
+	onDeadLetter := func(ctx context.Context, task *Task, run *TaskRun) {
+		deadLetters <- task.ID
+	}
+
+	// 未配置重试策略，失败不进入死信
+	noRetry := NewTaskBuilder("no-retry", "no-retry").
+		Once(0).
+		Handler(func(ctx context.Context, task *Task) error {
+			return errors.New("failed")
+		}).
+		OnDeadLetter(onDeadLetter).
+		Build()
+
+	// 重试中途任务被取消，不进入死信
+	started := make(chan struct{}, 1)
+	canceled := NewTaskBuilder("canceled", "canceled").
+		Once(0).
+		Retry(&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Second}).
+		Handler(func(ctx context.Context, task *Task) error {
+			started <- struct{}{}
+			<-ctx.Done()
+			return ctx.Err()
+		}).
+		OnDeadLetter(onDeadLetter).
+		Build()
+
+	for _, task := range []*Task{noRetry, canceled} {
+		if err := scheduler.ScheduleTask(task); err != nil {
+			t.Fatalf("Failed to schedule task: %v", err)
+		}
+	}
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("Expected canceled task to start")
+	}
+	if err := scheduler.CancelTask("canceled"); err != nil {
+		t.Fatalf("Failed to cancel task: %v", err)
+	}
+
+	failed := map[string]bool{}
+	timeout := time.After(2 * time.Second)
+	for len(failed) < 2 {
+		select {
+		case event := <-events:
+			switch event.Type {
+			case TaskEventFailed:
+				failed[event.TaskID] = true
+			case TaskEventDeadLetter:
+				t.Errorf("Expected no dead letter event for %s", event.TaskID)
+			}
+		case <-timeout:
+			t.Fatalf("Expected both tasks to fail, got %v", failed)
+		}
+	}
+
+	select {
+	case id := <-deadLetters:
+		t.Errorf("Expected no dead letter handler call, got %s", id)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestDefaultTaskScheduler_TimeoutAndOverlapSkip(t *testing.T) {
+	scheduler := NewDefaultTaskScheduler()
+	if err := scheduler.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start scheduler: %v", err)
+	}
+	defer scheduler.Stop(context.Background())
+
+	task := NewTaskBuilder("slow", "slow").
+		Interval(50 * time.Millisecond).
+		Timeout(120 * time.Millisecond).
+		Handler(func(ctx context.Context, task *Task) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}).
+		Build()
+	if err := scheduler.ScheduleTask(task); err != nil {
+		t.Fatalf("Failed to schedule task: %v", err)
+	}
+
+	time.Sleep(400 * time.Millisecond)
+
+	var timeouts, skipped int
+	for _, run := range scheduler.GetTask("slow").History {
+		switch run.Status {
+		case TaskRunTimeout:
+			timeouts++
+		case TaskRunSkipped:
+			skipped++
+		}
+	}
+	if timeouts == 0 || skipped == 0 {
+		t.Errorf("Expected timeout and skipped runs, got %d timeouts and %d skipped", timeouts, skipped)
+	}
+}
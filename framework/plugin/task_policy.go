@@ -0,0 +1,144 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy 任务重试策略（指数退避 + 随机抖动）
+type RetryPolicy struct {
+	MaxAttempts    int           `json:"max_attempts"`    // 最大尝试次数（包含首次执行）
+	InitialBackoff time.Duration `json:"initial_backoff"` // 首次重试等待时间
+	MaxBackoff     time.Duration `json:"max_backoff"`     // 最大等待时间
+	Multiplier     float64       `json:"multiplier"`      // 退避倍数
+	Jitter         float64       `json:"jitter"`          // 抖动比例 [0, 1]
+}
+
+// DefaultRetryPolicy 默认重试策略
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		Multiplier:     2.0,
+		Jitter:         0.2,
+	}
+}
+
+// Backoff 计算第attempt次失败后的等待时间
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		backoff *= 1 + jitter*(2*rand.Float64()-1)
+	}
+
+	return time.Duration(backoff)
+}
+
+// OverlapPolicy 上一次执行尚未结束时新调度的处理策略
+type OverlapPolicy int
+
+const (
+	// OverlapSkip 跳过本次调度
+	OverlapSkip OverlapPolicy = iota
+	// OverlapQueue 排队等待上一次执行结束
+	OverlapQueue
+	// OverlapAllow 允许并发执行
+	OverlapAllow
+)
+
+func (p OverlapPolicy) String() string {
+	switch p {
+	case OverlapSkip:
+		return "skip"
+	case OverlapQueue:
+		return "queue"
+	case OverlapAllow:
+		return "allow"
+	default:
+		return "unknown"
+	}
+}
+
+// TaskRunStatus 单次执行结果
+type TaskRunStatus string
+
+const (
+	TaskRunSucceeded TaskRunStatus = "succeeded"
+	TaskRunFailed    TaskRunStatus = "failed"
+	TaskRunTimeout   TaskRunStatus = "timeout"
+	TaskRunPanicked  TaskRunStatus = "panic"
+	TaskRunSkipped   TaskRunStatus = "skipped"
+)
+
+// TaskRun 任务执行记录
+type TaskRun struct {
+	ScheduledAt time.Time     `json:"scheduled_at"`
+	StartedAt   time.Time     `json:"started_at,omitempty"`
+	FinishedAt  time.Time     `json:"finished_at,omitempty"`
+	Duration    time.Duration `json:"duration"`
+	Attempts    int           `json:"attempts"`
+	Status      TaskRunStatus `json:"status"`
+	Error       string        `json:"error,omitempty"`
+	Instance    string        `json:"instance,omitempty"` // 执行实例
+}
+
+// TaskDeadLetterHandler 任务重试耗尽后的处理函数
+type TaskDeadLetterHandler func(ctx context.Context, task *Task, run *TaskRun)
+
+// ErrTaskTimeout 任务执行超时
+var ErrTaskTimeout = errors.New("task execution timed out")
+
+// TaskPanicError 任务处理函数发生panic
+type TaskPanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *TaskPanicError) Error() string {
+	return fmt.Sprintf("task panicked: %v", e.Value)
+}
+
+const (
+	// defaultHistoryLimit 默认保留的执行记录数
+	defaultHistoryLimit = 20
+	// overlapQueueSize 排队策略下最多等待执行的调度数
+	overlapQueueSize = 100
+	// storeTimeout 单次存储操作超时
+	storeTimeout = 5 * time.Second
+	// deadLetterTimeout 死信处理函数的执行超时
+	deadLetterTimeout = 30 * time.Second
+)
+
+// runStatusOf 根据错误判断执行结果
+func runStatusOf(err error) TaskRunStatus {
+	var panicErr *TaskPanicError
+	switch {
+	case err == nil:
+		return TaskRunSucceeded
+	case errors.Is(err, ErrTaskTimeout):
+		return TaskRunTimeout
+	case errors.As(err, &panicErr):
+		return TaskRunPanicked
+	default:
+		return TaskRunFailed
+	}
+}
@@ -0,0 +1,108 @@
+package plugin
+
+import (
+	"context"
+	"time"
+)
+
+// TaskStore 任务持久化存储接口
+//
+// 存储的是任务定义和运行状态，处理函数无法持久化，重启后需通过 ScheduleTask
+// 或 RegisterHandler 重新提供。
+type TaskStore interface {
+	// SaveTask 保存任务（存在则覆盖）
+	SaveTask(ctx context.Context, record *TaskRecord) error
+
+	// LoadTasks 加载所有任务
+	LoadTasks(ctx context.Context) ([]*TaskRecord, error)
+
+	// DeleteTask 删除任务及其执行记录
+	DeleteTask(ctx context.Context, taskID string) error
+
+	// AppendRun 追加执行记录，只保留最近limit条
+	AppendRun(ctx context.Context, taskID string, run TaskRun, limit int) error
+
+	// LoadRuns 加载最近limit条执行记录（按时间升序）
+	LoadRuns(ctx context.Context, taskID string, limit int) ([]TaskRun, error)
+}
+
+// TaskRecord 任务持久化记录
+type TaskRecord struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	HandlerName string                 `json:"handler_name,omitempty"`
+	Schedule    *Schedule              `json:"schedule"`
+	Status      TaskStatus             `json:"status"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+
+	Distributed   bool          `json:"distributed"`
+	LockTTL       time.Duration `json:"lock_ttl,omitempty"`
+	Retry         *RetryPolicy  `json:"retry,omitempty"`
+	Timeout       time.Duration `json:"timeout,omitempty"`
+	OverlapPolicy OverlapPolicy `json:"overlap_policy"`
+	HistoryLimit  int           `json:"history_limit,omitempty"`
+
+	CreatedAt    time.Time `json:"created_at"`
+	LastRunAt    time.Time `json:"last_run_at,omitempty"`
+	NextRunAt    time.Time `json:"next_run_at,omitempty"`
+	RunCount     int64     `json:"run_count"`
+	FailureCount int64     `json:"failure_count"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// newTaskRecord 根据任务当前状态生成持久化记录
+func newTaskRecord(task *Task) *TaskRecord {
+	task.mu.RLock()
+	defer task.mu.RUnlock()
+
+	return &TaskRecord{
+		ID:            task.ID,
+		Name:          task.Name,
+		Description:   task.Description,
+		HandlerName:   task.HandlerName,
+		Schedule:      task.Schedule,
+		Status:        task.Status,
+		Metadata:      task.Metadata,
+		Distributed:   task.Distributed,
+		LockTTL:       task.LockTTL,
+		Retry:         task.Retry,
+		Timeout:       task.Timeout,
+		OverlapPolicy: task.OverlapPolicy,
+		HistoryLimit:  task.HistoryLimit,
+		CreatedAt:     task.CreatedAt,
+		LastRunAt:     task.LastRunAt,
+		NextRunAt:     task.NextRunAt,
+		RunCount:      task.RunCount,
+		FailureCount:  task.FailureCount,
+		UpdatedAt:     time.Now(),
+	}
+}
+
+// newTaskFromRecord 根据持久化记录重建任务定义
+func newTaskFromRecord(record *TaskRecord, handler TaskHandler) *Task {
+	schedule := record.Schedule
+	if schedule == nil {
+		schedule = &Schedule{}
+	}
+	metadata := record.Metadata
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+
+	return &Task{
+		ID:            record.ID,
+		Name:          record.Name,
+		Description:   record.Description,
+		HandlerName:   record.HandlerName,
+		Handler:       handler,
+		Schedule:      schedule,
+		Metadata:      metadata,
+		Distributed:   record.Distributed,
+		LockTTL:       record.LockTTL,
+		Retry:         record.Retry,
+		Timeout:       record.Timeout,
+		OverlapPolicy: record.OverlapPolicy,
+		HistoryLimit:  record.HistoryLimit,
+	}
+}
@@ -0,0 +1,192 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TaskModel 任务表模型
+type TaskModel struct {
+	ID        string    `gorm:"primaryKey;column:id;type:varchar(128);not null;comment:任务ID" json:"id"`
+	Name      string    `gorm:"column:name;type:varchar(256);comment:任务名称" json:"name"`
+	Status    int       `gorm:"column:status;type:int;not null;default:0;comment:任务状态" json:"status"`
+	Data      string    `gorm:"column:data;type:text;comment:任务定义及状态(JSON)" json:"data"`
+	UpdatedAt time.Time `gorm:"column:updated_at;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (TaskModel) TableName() string {
+	return "scheduler_tasks"
+}
+
+// TaskRunModel 任务执行记录表模型
+type TaskRunModel struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	TaskID      string    `gorm:"column:task_id;type:varchar(128);not null;index:idx_scheduler_task_runs_task_id;comment:任务ID" json:"task_id"`
+	ScheduledAt time.Time `gorm:"column:scheduled_at;comment:调度时间" json:"scheduled_at"`
+	StartedAt   time.Time `gorm:"column:started_at;comment:开始时间" json:"started_at"`
+	FinishedAt  time.Time `gorm:"column:finished_at;comment:结束时间" json:"finished_at"`
+	DurationMs  int64     `gorm:"column:duration_ms;comment:耗时(毫秒)" json:"duration_ms"`
+	Attempts    int       `gorm:"column:attempts;comment:尝试次数" json:"attempts"`
+	Status      string    `gorm:"column:status;type:varchar(32);comment:执行结果" json:"status"`
+	Error       string    `gorm:"column:error;type:text;comment:错误信息" json:"error"`
+	Instance    string    `gorm:"column:instance;type:varchar(256);comment:执行实例" json:"instance"`
+}
+
+// TableName 指定表名
+func (TaskRunModel) TableName() string {
+	return "scheduler_task_runs"
+}
+
+// GormTaskStore 基于GORM的任务存储（支持SQLite/MySQL/PostgreSQL）
+type GormTaskStore struct {
+	db *gorm.DB
+}
+
+// NewGormTaskStore 创建GORM任务存储
+func NewGormTaskStore(db *gorm.DB) *GormTaskStore {
+	return &GormTaskStore{db: db}
+}
+
+// CreateTable 创建任务表和执行记录表（如果不存在）
+func (s *GormTaskStore) CreateTable(ctx context.Context) error {
+	if err := s.db.WithContext(ctx).AutoMigrate(&TaskModel{}, &TaskRunModel{}); err != nil {
+		return fmt.Errorf("failed to create scheduler tables: %w", err)
+	}
+	return nil
+}
+
+// SaveTask 保存任务
+func (s *GormTaskStore) SaveTask(ctx context.Context, record *TaskRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task record: %w", err)
+	}
+
+	model := &TaskModel{
+		ID:        record.ID,
+		Name:      record.Name,
+		Status:    int(record.Status),
+		Data:      string(data),
+		UpdatedAt: record.UpdatedAt,
+	}
+
+	err = s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "status", "data", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		return fmt.Errorf("failed to save task %s: %w", record.ID, err)
+	}
+	return nil
+}
+
+// LoadTasks 加载所有任务
+func (s *GormTaskStore) LoadTasks(ctx context.Context) ([]*TaskRecord, error) {
+	var models []*TaskModel
+	if err := s.db.WithContext(ctx).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	records := make([]*TaskRecord, 0, len(models))
+	for _, model := range models {
+		record := &TaskRecord{}
+		if err := json.Unmarshal([]byte(model.Data), record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal task %s: %w", model.ID, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// DeleteTask 删除任务及其执行记录
+func (s *GormTaskStore) DeleteTask(ctx context.Context, taskID string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).Delete(&TaskRunModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete task runs: %w", err)
+		}
+		if err := tx.Where("id = ?", taskID).Delete(&TaskModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete task: %w", err)
+		}
+		return nil
+	})
+}
+
+// AppendRun 追加执行记录，并删除超出limit的旧记录
+func (s *GormTaskStore) AppendRun(ctx context.Context, taskID string, run TaskRun, limit int) error {
+	model := &TaskRunModel{
+		TaskID:      taskID,
+		ScheduledAt: run.ScheduledAt,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+		DurationMs:  run.Duration.Milliseconds(),
+		Attempts:    run.Attempts,
+		Status:      string(run.Status),
+		Error:       run.Error,
+		Instance:    run.Instance,
+	}
+	if err := s.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to append task run: %w", err)
+	}
+
+	if limit <= 0 {
+		return nil
+	}
+
+	// 找到需要保留的最旧一条记录，删除更早的记录
+	var oldest TaskRunModel
+	err := s.db.WithContext(ctx).
+		Where("task_id = ?", taskID).
+		Order("id DESC").
+		Offset(limit - 1).
+		Limit(1).
+		Take(&oldest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to trim task runs: %w", err)
+	}
+
+	if err := s.db.WithContext(ctx).
+		Where("task_id = ? AND id < ?", taskID, oldest.ID).
+		Delete(&TaskRunModel{}).Error; err != nil {
+		return fmt.Errorf("failed to trim task runs: %w", err)
+	}
+	return nil
+}
+
+// LoadRuns 加载最近limit条执行记录
+func (s *GormTaskStore) LoadRuns(ctx context.Context, taskID string, limit int) ([]TaskRun, error) {
+	query := s.db.WithContext(ctx).Where("task_id = ?", taskID).Order("id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var models []*TaskRunModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to load task runs: %w", err)
+	}
+
+	runs := make([]TaskRun, len(models))
+	for i, model := range models {
+		// 倒序查询，按时间升序返回
+		runs[len(models)-1-i] = TaskRun{
+			ScheduledAt: model.ScheduledAt,
+			StartedAt:   model.StartedAt,
+			FinishedAt:  model.FinishedAt,
+			Duration:    time.Duration(model.DurationMs) * time.Millisecond,
+			Attempts:    model.Attempts,
+			Status:      TaskRunStatus(model.Status),
+			Error:       model.Error,
+			Instance:    model.Instance,
+		}
+	}
+	return runs, nil
+}
@@ -0,0 +1,121 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisTaskStore 基于Redis的任务存储
+//
+// 任务保存在 {prefix}tasks 哈希中，执行记录保存在 {prefix}runs:{taskID} 列表中（新记录在前）。
+type RedisTaskStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisTaskStore 创建Redis任务存储
+func NewRedisTaskStore(client *redis.Client, prefix string) *RedisTaskStore {
+	if prefix == "" {
+		prefix = "scheduler:"
+	}
+	return &RedisTaskStore{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// SaveTask 保存任务
+func (s *RedisTaskStore) SaveTask(ctx context.Context, record *TaskRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task record: %w", err)
+	}
+	if err := s.client.HSet(ctx, s.tasksKey(), record.ID, data).Err(); err != nil {
+		return fmt.Errorf("failed to save task %s: %w", record.ID, err)
+	}
+	return nil
+}
+
+// LoadTasks 加载所有任务
+func (s *RedisTaskStore) LoadTasks(ctx context.Context) ([]*TaskRecord, error) {
+	values, err := s.client.HGetAll(ctx, s.tasksKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	records := make([]*TaskRecord, 0, len(values))
+	for id, value := range values {
+		record := &TaskRecord{}
+		if err := json.Unmarshal([]byte(value), record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal task %s: %w", id, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// DeleteTask 删除任务及其执行记录
+func (s *RedisTaskStore) DeleteTask(ctx context.Context, taskID string) error {
+	pipe := s.client.TxPipeline()
+	pipe.HDel(ctx, s.tasksKey(), taskID)
+	pipe.Del(ctx, s.runsKey(taskID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete task %s: %w", taskID, err)
+	}
+	return nil
+}
+
+// AppendRun 追加执行记录
+func (s *RedisTaskStore) AppendRun(ctx context.Context, taskID string, run TaskRun, limit int) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task run: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.LPush(ctx, s.runsKey(taskID), data)
+	if limit > 0 {
+		pipe.LTrim(ctx, s.runsKey(taskID), 0, int64(limit-1))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to append task run: %w", err)
+	}
+	return nil
+}
+
+// LoadRuns 加载最近limit条执行记录
+func (s *RedisTaskStore) LoadRuns(ctx context.Context, taskID string, limit int) ([]TaskRun, error) {
+	stop := int64(-1)
+	if limit > 0 {
+		stop = int64(limit - 1)
+	}
+
+	values, err := s.client.LRange(ctx, s.runsKey(taskID), 0, stop).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task runs: %w", err)
+	}
+
+	runs := make([]TaskRun, 0, len(values))
+	// 列表中新记录在前，按时间升序返回
+	for i := len(values) - 1; i >= 0; i-- {
+		var run TaskRun
+		if err := json.Unmarshal([]byte(values[i]), &run); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal task run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// tasksKey 任务哈希键
+func (s *RedisTaskStore) tasksKey() string {
+	return s.prefix + "tasks"
+}
+
+// runsKey 执行记录列表键
+func (s *RedisTaskStore) runsKey(taskID string) string {
+	return s.prefix + "runs:" + taskID
+}
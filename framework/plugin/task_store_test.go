@@ -0,0 +1,301 @@
+package plugin
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupTaskStore(t *testing.T) *GormTaskStore {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	// 内存数据库每个连接独立，限制为单连接
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get sql.DB: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	store := NewGormTaskStore(db)
+	if err := store.CreateTable(context.Background()); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	return store
+}
+
+func TestDefaultTaskScheduler_RestoreFromStore(t *testing.T) {
+	store := setupTaskStore(t)
+	ctx := context.Background()
+	var runs int64
+	handler := func(ctx context.Context, task *Task) error {
+		atomic.AddInt64(&runs, 1)
+		return nil
+	}
+
+	// 第一个调度器运行并暂停任务
+	first := NewDefaultTaskScheduler()
+	first.SetStore(store)
+	if err := first.Start(ctx); err != nil {
+		t.Fatalf("Failed to start scheduler: %v", err)
+	}
+	task := NewTaskBuilder("tick", "tick").
+		Interval(50 * time.Millisecond).
+		HandlerName("tick").
+		Handler(handler).
+		HistoryLimit(2).
+		Build()
+	if err := first.ScheduleTask(task); err != nil {
+		t.Fatalf("Failed to schedule task: %v", err)
+	}
+
+	time.Sleep(220 * time.Millisecond)
+	if err := first.PauseTask("tick"); err != nil {
+		t.Fatalf("Failed to pause task: %v", err)
+	}
+	if err := first.Stop(ctx); err != nil {
+		t.Fatalf("Failed to stop scheduler: %v", err)
+	}
+	runCount := first.GetTask("tick").RunCount
+	if runCount == 0 {
+		t.Fatal("Expected task to run before restart")
+	}
+
+	// 重启后通过具名处理函数恢复
+	second := NewDefaultTaskScheduler()
+	second.SetStore(store)
+	second.RegisterHandler("tick", handler)
+	if err := second.Start(ctx); err != nil {
+		t.Fatalf("Failed to start scheduler: %v", err)
+	}
+	defer second.Stop(ctx)
+
+	restored := second.GetTask("tick")
+	if restored == nil {
+		t.Fatal("Expected task to be restored")
+	}
+	if restored.RunCount != runCount || restored.Status != TaskStatusPaused {
+		t.Errorf("Expected run count %d and paused status, got %d and %s", runCount, restored.RunCount, restored.Status)
+	}
+	if len(restored.History) != 2 {
+		t.Errorf("Expected 2 history records, got %d", len(restored.History))
+	}
+
+	if err := second.CancelTask("tick"); err != nil {
+		t.Fatalf("Failed to cancel task: %v", err)
+	}
+	records, err := store.LoadTasks(ctx)
+	if err != nil || len(records) != 0 {
+		t.Errorf("Expected cancelled task to be deleted, got %d records (err=%v)", len(records), err)
+	}
+}
+
+func TestRedisTaskStore(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	store := NewRedisTaskStore(client, "")
+	ctx := context.Background()
+
+	record := &TaskRecord{
+		ID:       "report",
+		Name:     "report",
+		Schedule: &Schedule{Type: ScheduleTypeInterval, Interval: time.Minute},
+		Status:   TaskStatusPaused,
+		RunCount: 3,
+	}
+	if err := store.SaveTask(ctx, record); err != nil {
+		t.Fatalf("Failed to save task: %v", err)
+	}
+	records, err := store.LoadTasks(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load tasks: %v", err)
+	}
+	if len(records) != 1 || records[0].Status != TaskStatusPaused || records[0].RunCount != 3 ||
+		records[0].Schedule.Interval != time.Minute {
+		t.Errorf("Unexpected task records: %+v", records)
+	}
+
+	// 只保留最近2条，按时间升序返回
+	for i := 1; i <= 3; i++ {
+		if err := store.AppendRun(ctx, "report", TaskRun{Attempts: i}, 2); err != nil {
+			t.Fatalf("Failed to append run: %v", err)
+		}
+	}
+	runs, err := store.LoadRuns(ctx, "report", 10)
+	if err != nil {
+		t.Fatalf("Failed to load runs: %v", err)
+	}
+	if len(runs) != 2 || runs[0].Attempts != 2 || runs[1].Attempts != 3 {
+		t.Errorf("Expected runs [2 3], got %+v", runs)
+	}
+
+	if err := store.DeleteTask(ctx, "report"); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+	records, _ = store.LoadTasks(ctx)
+	runs, _ = store.LoadRuns(ctx, "report", 10)
+	if len(records) != 0 || len(runs) != 0 {
+		t.Errorf("Expected task and runs to be deleted, got %d records and %d runs", len(records), len(runs))
+	}
+}
+
+// blockingTaskStore 在对应通道关闭前阻塞存储操作的任务存储，通道为nil时不阻塞
+type blockingTaskStore struct {
+	TaskStore
+	load    chan struct{}
+	save    chan struct{}
+	delete  chan struct{}
+	loading chan struct{} // 非nil时每次LoadTasks开始前发送通知
+	saving  chan struct{} // 非nil时每次SaveTask开始前发送通知
+}
+
+func wait(ch chan struct{}) {
+	if ch != nil {
+		<-ch
+	}
+}
+
+func (s *blockingTaskStore) LoadTasks(ctx context.Context) ([]*TaskRecord, error) {
+	if s.loading != nil {
+		s.loading <- struct{}{}
+	}
+	wait(s.load)
+	return s.TaskStore.LoadTasks(ctx)
+}
+
+func (s *blockingTaskStore) SaveTask(ctx context.Context, record *TaskRecord) error {
+	if s.saving != nil {
+		s.saving <- struct{}{}
+	}
+	wait(s.save)
+	return s.TaskStore.SaveTask(ctx, record)
+}
+
+func (s *blockingTaskStore) DeleteTask(ctx context.Context, taskID string) error {
+	wait(s.delete)
+	return s.TaskStore.DeleteTask(ctx, taskID)
+}
+
+// accessibleWithin 检查存储阻塞期间调度器仍可访问
+func accessibleWithin(scheduler *DefaultTaskScheduler, check func() bool) bool {
+	accessible := make(chan struct{})
+	go func() {
+		for !check() {
+			time.Sleep(10 * time.Millisecond)
+		}
+		close(accessible)
+	}()
+	select {
+	case <-accessible:
+		return true
+	case <-time.After(time.Second):
+		return false
+	}
+}
+
+func TestDefaultTaskScheduler_StoreIOOutsideLock(t *testing.T) {
+	release := make(chan struct{})
+	store := &blockingTaskStore{
+		TaskStore: setupTaskStore(t),
+		load:      release,
+		save:      release,
+		delete:    release,
+		loading:   make(chan struct{}, 1),
+	}
+	scheduler := NewDefaultTaskScheduler()
+	scheduler.SetStore(store)
+	ctx := context.Background()
+
+	started := make(chan error, 1)
+	go func() {
+		started <- scheduler.Start(ctx)
+	}()
+	<-store.loading
+	if !accessibleWithin(scheduler, func() bool { return !scheduler.IsRunning() }) {
+		close(release)
+		t.Fatal("Expected scheduler to stay accessible while loading tasks")
+	}
+
+	task := NewTaskBuilder("slow", "slow").
+		Interval(time.Hour).
+		Handler(func(ctx context.Context, task *Task) error { return nil }).
+		Build()
+	close(release)
+	if err := <-started; err != nil {
+		t.Fatalf("Failed to start scheduler: %v", err)
+	}
+	defer scheduler.Stop(ctx)
+
+	store.save = make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- scheduler.ScheduleTask(task)
+	}()
+	if !accessibleWithin(scheduler, func() bool { return scheduler.GetTask("slow") != nil }) {
+		close(store.save)
+		t.Fatal("Expected scheduler to stay accessible while the store is blocked")
+	}
+
+	close(store.save)
+	if err := <-done; err != nil {
+		t.Fatalf("Failed to schedule task: %v", err)
+	}
+	if err := scheduler.CancelTask("slow"); err != nil {
+		t.Fatalf("Failed to cancel task: %v", err)
+	}
+}
+
+func TestDefaultTaskScheduler_CancelDuringSave(t *testing.T) {
+	store := &blockingTaskStore{
+		TaskStore: setupTaskStore(t),
+		save:      make(chan struct{}),
+		saving:    make(chan struct{}, 1),
+	}
+	scheduler := NewDefaultTaskScheduler()
+	scheduler.SetStore(store)
+	ctx := context.Background()
+	if err := scheduler.Start(ctx); err != nil {
+		t.Fatalf("Failed to start scheduler: %v", err)
+	}
+	defer scheduler.Stop(ctx)
+
+	task := NewTaskBuilder("race", "race").
+		Interval(time.Hour).
+		Handler(func(ctx context.Context, task *Task) error { return nil }).
+		Build()
+	scheduled := make(chan error, 1)
+	go func() {
+		scheduled <- scheduler.ScheduleTask(task)
+	}()
+	<-store.saving
+
+	// 保存进行中时取消，删除必须在保存之后生效
+	canceled := make(chan error, 1)
+	go func() {
+		canceled <- scheduler.CancelTask("race")
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(store.save)
+
+	if err := <-scheduled; err != nil {
+		t.Fatalf("Failed to schedule task: %v", err)
+	}
+	if err := <-canceled; err != nil {
+		t.Fatalf("Failed to cancel task: %v", err)
+	}
+	records, err := store.LoadTasks(ctx)
+	if err != nil || len(records) != 0 {
+		t.Errorf("Expected canceled task to stay deleted, got %d records (err=%v)", len(records), err)
+	}
+}
@@ -84,6 +84,19 @@ func TraceCache(ctx context.Context, operation, key string, hit bool) {
 	)
 }
 
+// TraceCacheSystem 追踪缓存操作的辅助函数，system为缓存类型（memory、redis等），key为空时不记录键名
+func TraceCacheSystem(ctx context.Context, system, operation, key string, hit bool) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("cache.operation", operation),
+		attribute.Bool("cache.hit", hit),
+		attribute.String("cache.system", system),
+	)
+	if key != "" {
+		span.SetAttributes(attribute.String("cache.key", key))
+	}
+}
+
 // TraceMessageQueue 追踪消息队列操作的辅助函数
 func TraceMessageQueue(ctx context.Context, operation, queue string, messageCount int) {
 	span := trace.SpanFromContext(ctx)
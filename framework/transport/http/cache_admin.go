@@ -0,0 +1,274 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/qiaojinxia/distributed-service/framework/auth"
+	"github.com/qiaojinxia/distributed-service/framework/cache"
+	"github.com/qiaojinxia/distributed-service/framework/logger"
+	"github.com/qiaojinxia/distributed-service/framework/middleware"
+)
+
+// AdminRole 允许访问管理接口的角色
+const AdminRole = "admin"
+
+// sensitiveSettings 返回配置时隐藏的设置项（按小写子串匹配）
+var sensitiveSettings = []string{"password", "secret", "token"}
+
+// CacheInfo 缓存概要信息
+type CacheInfo struct {
+	Name     string                 `json:"name"`
+	Type     cache.Type             `json:"type,omitempty"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+	Stats    interface{}            `json:"stats,omitempty"`
+}
+
+// InvalidateRequest 按前缀失效请求
+type InvalidateRequest struct {
+	Prefix string `json:"prefix" binding:"required"`
+}
+
+// CacheAdmin 缓存管理接口，用于查看和失效缓存
+type CacheAdmin struct {
+	manager *cache.Manager
+}
+
+// NewCacheAdmin 创建缓存管理接口
+func NewCacheAdmin(manager *cache.Manager) *CacheAdmin {
+	return &CacheAdmin{manager: manager}
+}
+
+// CacheAdminRoutes 返回受JWT和管理员角色保护的缓存管理路由，
+// 通过 Server.AddRoutes 挂载，例如 server.AddRoutes("/admin/cache", CacheAdminRoutes(manager, jwtManager))
+func CacheAdminRoutes(manager *cache.Manager, jwtManager *auth.JWTManager) func(*gin.RouterGroup) {
+	admin := NewCacheAdmin(manager)
+	return func(group *gin.RouterGroup) {
+		group.Use(middleware.JWTAuth(jwtManager), middleware.RequireRole(AdminRole))
+		admin.Register(group)
+	}
+}
+
+// Register 在路由组上注册管理接口，不附加任何认证中间件
+func (a *CacheAdmin) Register(group *gin.RouterGroup) {
+	group.GET("", a.list)
+	group.GET("/:name", a.inspect)
+	group.GET("/:name/keys/*key", a.getKey)
+	group.DELETE("/:name/keys/*key", a.deleteKey)
+	group.POST("/:name/invalidate", a.invalidate)
+	group.POST("/:name/flush", a.flush)
+}
+
+// list 列出所有缓存及其统计信息
+func (a *CacheAdmin) list(c *gin.Context) {
+	names := a.manager.ListCaches()
+	sort.Strings(names)
+
+	caches := make([]CacheInfo, 0, len(names))
+	for _, name := range names {
+		if info, ok := a.info(name); ok {
+			caches = append(caches, info)
+		}
+	}
+	Success(c, caches)
+}
+
+// inspect 查看单个缓存的配置和统计信息
+func (a *CacheAdmin) inspect(c *gin.Context) {
+	info, ok := a.info(c.Param("name"))
+	if !ok {
+		NotFound(c, "cache not found")
+		return
+	}
+	Success(c, info)
+}
+
+// getKey 读取单个键
+func (a *CacheAdmin) getKey(c *gin.Context) {
+	target, key, ok := a.target(c)
+	if !ok {
+		return
+	}
+
+	value, err := target.Get(c.Request.Context(), key)
+	if errors.Is(err, cache.ErrKeyNotFound) {
+		NotFound(c, "key not found")
+		return
+	}
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+	Success(c, gin.H{"key": key, "value": value})
+}
+
+// deleteKey 删除单个键
+func (a *CacheAdmin) deleteKey(c *gin.Context) {
+	target, key, ok := a.target(c)
+	if !ok {
+		return
+	}
+
+	if err := target.Delete(c.Request.Context(), key); err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+	a.audit(c, "delete", logger.String("key", key))
+	Success(c, gin.H{"key": key, "deleted": true})
+}
+
+// invalidate 删除以指定前缀开头的所有键
+func (a *CacheAdmin) invalidate(c *gin.Context) {
+	target, ok := a.cache(c)
+	if !ok {
+		return
+	}
+
+	var req InvalidateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "prefix is required")
+		return
+	}
+
+	prefixCache, ok := cache.Unwrap(target).(cache.PrefixCache)
+	if !ok {
+		Error(c, http.StatusNotImplemented, cache.ErrTagsNotSupported.Error())
+		return
+	}
+	if err := prefixCache.DeletePrefix(c.Request.Context(), req.Prefix); err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+	a.audit(c, "invalidate", logger.String("prefix", req.Prefix))
+	Success(c, gin.H{"prefix": req.Prefix, "invalidated": true})
+}
+
+// flush 立即写回混合缓存中待写回的数据
+func (a *CacheAdmin) flush(c *gin.Context) {
+	target, ok := a.cache(c)
+	if !ok {
+		return
+	}
+
+	hybrid, ok := cache.Unwrap(target).(*cache.HybridCache)
+	if !ok {
+		BadRequest(c, "cache is not a hybrid cache")
+		return
+	}
+	if err := hybrid.Flush(c.Request.Context()); err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+	a.audit(c, "flush")
+	Success(c, gin.H{"pending": hybrid.GetStats().WriteBackPending})
+}
+
+// info 汇总缓存的配置和统计信息
+func (a *CacheAdmin) info(name string) (CacheInfo, bool) {
+	target, err := a.manager.GetCache(name)
+	if err != nil {
+		return CacheInfo{}, false
+	}
+
+	info := CacheInfo{Name: name}
+	if config, err := a.manager.GetConfig(name); err == nil {
+		info.Type = config.Type
+		info.Settings = redactSettings(config.Settings)
+	}
+
+	switch stats := cache.Unwrap(target).(type) {
+	case *cache.HybridCache:
+		info.Stats = stats.GetStats()
+	case cache.StatsCache:
+		info.Stats = stats.GetStats()
+	}
+	return info, true
+}
+
+// cache 按路径参数查找缓存，不存在时直接写入404响应
+func (a *CacheAdmin) cache(c *gin.Context) (cache.Cache, bool) {
+	target, err := a.manager.GetCache(c.Param("name"))
+	if err != nil {
+		NotFound(c, "cache not found")
+		return nil, false
+	}
+	return target, true
+}
+
+// target 查找缓存并解析路径中的键
+func (a *CacheAdmin) target(c *gin.Context) (cache.Cache, string, bool) {
+	target, ok := a.cache(c)
+	if !ok {
+		return nil, "", false
+	}
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if key == "" {
+		BadRequest(c, "key is required")
+		return nil, "", false
+	}
+	return target, key, true
+}
+
+// audit 记录修改操作及操作人
+func (a *CacheAdmin) audit(c *gin.Context, action string, fields ...logger.Field) {
+	fields = append([]logger.Field{
+		logger.String("cache", c.Param("name")),
+		logger.String("action", action),
+		logger.String("username", c.GetString("username")),
+	}, fields...)
+	logger.Info(c.Request.Context(), "Cache admin operation", fields...)
+}
+
+// redactSettings 复制设置并隐藏敏感项，递归处理嵌套的设置（如混合缓存的 l1_config、l2_config）
+func redactSettings(settings map[string]interface{}) map[string]interface{} {
+	if len(settings) == 0 {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		redacted[key] = redactValue(value)
+		for _, sensitive := range sensitiveSettings {
+			if strings.Contains(strings.ToLower(key), sensitive) {
+				redacted[key] = "******"
+				break
+			}
+		}
+	}
+	return redacted
+}
+
+// redactValue 复制嵌套的设置值并隐藏其中的敏感项
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return redactSettings(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = redactValue(item)
+		}
+		return items
+	case []map[string]interface{}:
+		items := make([]map[string]interface{}, len(v))
+		for i, item := range v {
+			items[i] = redactSettings(item)
+		}
+		return items
+	case cache.Config:
+		v.Settings = redactSettings(v.Settings)
+		return v
+	case *cache.Config:
+		if v == nil {
+			return v
+		}
+		config := *v
+		config.Settings = redactSettings(v.Settings)
+		return config
+	default:
+		return value
+	}
+}
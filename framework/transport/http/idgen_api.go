@@ -0,0 +1,219 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/qiaojinxia/distributed-service/framework/auth"
+	"github.com/qiaojinxia/distributed-service/framework/common/idgen"
+	"github.com/qiaojinxia/distributed-service/framework/middleware"
+)
+
+// BizTagRequest 创建业务标识请求
+type BizTagRequest struct {
+	BizTag      string `json:"biz_tag" binding:"required"`
+	Step        int32  `json:"step"`
+	Description string `json:"description"`
+}
+
+// StepRequest 更新步长或申请号段请求
+type StepRequest struct {
+	Step int32 `json:"step"`
+}
+
+// IDGenAPI ID生成服务的REST接口，与 idgen.GRPCServer 提供相同的操作，供非Go服务调用
+type IDGenAPI struct {
+	service *idgen.FrameworkIDGenService
+}
+
+// NewIDGenAPI 创建ID生成服务REST接口
+func NewIDGenAPI(service *idgen.FrameworkIDGenService) *IDGenAPI {
+	return &IDGenAPI{service: service}
+}
+
+// IDGenRouteOptions ID生成服务路由选项
+type IDGenRouteOptions struct {
+	JWTManager  *auth.JWTManager  // 非nil时所有接口要求JWT认证
+	Management  bool              // 注册业务标识增删改接口，要求设置JWTManager且调用方具有admin角色
+	Middlewares []gin.HandlerFunc // 附加到所有接口的中间件
+}
+
+// IDGenRoutes 返回ID生成服务路由，通过 Server.AddRoutes 挂载，
+// 例如 server.AddRoutes("/idgen", IDGenRoutes(service, IDGenRouteOptions{JWTManager: jwtManager}))。
+// 未设置JWTManager时不注册业务标识增删改接口。
+func IDGenRoutes(service *idgen.FrameworkIDGenService, opts IDGenRouteOptions) func(*gin.RouterGroup) {
+	api := NewIDGenAPI(service)
+	return func(group *gin.RouterGroup) {
+		group.Use(opts.Middlewares...)
+		if opts.JWTManager != nil {
+			group.Use(middleware.JWTAuth(opts.JWTManager))
+		}
+		api.Register(group)
+
+		if opts.Management && opts.JWTManager != nil {
+			api.RegisterManagement(group.Group("", middleware.RequireRole(AdminRole)))
+		}
+	}
+}
+
+// Register 在路由组上注册发号和查询接口，不附加任何认证中间件
+func (a *IDGenAPI) Register(group *gin.RouterGroup) {
+	group.GET("/metrics", a.metrics)
+	group.GET("/tags", a.listBizTags)
+	group.POST("/tags/:biz_tag/next", a.nextID)
+	group.POST("/tags/:biz_tag/batch", a.batchNextID)
+	group.POST("/tags/:biz_tag/segment", a.nextSegment)
+	group.GET("/tags/:biz_tag/status", a.bufferStatus)
+	group.GET("/tags/:biz_tag/metrics", a.bizTagMetrics)
+}
+
+// RegisterManagement 在路由组上注册业务标识增删改接口，不附加任何认证中间件，调用方需自行限制访问
+func (a *IDGenAPI) RegisterManagement(group *gin.RouterGroup) {
+	group.POST("/tags", a.createBizTag)
+	group.DELETE("/tags/:biz_tag", a.deleteBizTag)
+	group.PUT("/tags/:biz_tag/step", a.updateStep)
+}
+
+// nextID 获取单个ID
+func (a *IDGenAPI) nextID(c *gin.Context) {
+	id, err := a.service.NextID(c.Request.Context(), c.Param("biz_tag"))
+	if err != nil {
+		a.error(c, err)
+		return
+	}
+	// ID超过JavaScript安全整数范围，同时返回字符串形式
+	Success(c, gin.H{"id": id, "id_str": strconv.FormatInt(id, 10)})
+}
+
+// batchNextID 批量获取ID，数量由查询参数count指定
+func (a *IDGenAPI) batchNextID(c *gin.Context) {
+	count, err := strconv.Atoi(c.DefaultQuery("count", "1"))
+	if err != nil || count <= 0 || count > idgen.MaxBatchCount {
+		BadRequest(c, "count must be in [1, "+strconv.Itoa(idgen.MaxBatchCount)+"]")
+		return
+	}
+
+	ids, err := a.service.BatchNextID(c.Request.Context(), c.Param("biz_tag"), count)
+	if err != nil {
+		a.error(c, err)
+		return
+	}
+	idStrs := make([]string, len(ids))
+	for i, id := range ids {
+		idStrs[i] = strconv.FormatInt(id, 10)
+	}
+	Success(c, gin.H{"ids": ids, "ids_str": idStrs})
+}
+
+// nextSegment 申请号段，调用方在本地发号
+func (a *IDGenAPI) nextSegment(c *gin.Context) {
+	var req StepRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			BadRequest(c, err.Error())
+			return
+		}
+	}
+
+	segment, err := a.service.AllocSegment(c.Request.Context(), c.Param("biz_tag"), req.Step)
+	if err != nil {
+		a.error(c, err)
+		return
+	}
+	Success(c, gin.H{
+		"min":     segment.Min,
+		"max":     segment.Max,
+		"min_str": strconv.FormatInt(segment.Min, 10),
+		"max_str": strconv.FormatInt(segment.Max, 10),
+		"step":    segment.Step,
+	})
+}
+
+// listBizTags 列出业务标识
+func (a *IDGenAPI) listBizTags(c *gin.Context) {
+	leafAllocs, err := a.service.ListBizTags(c.Request.Context())
+	if err != nil {
+		a.error(c, err)
+		return
+	}
+	if leafAllocs == nil {
+		leafAllocs = []*idgen.LeafAlloc{}
+	}
+	Success(c, leafAllocs)
+}
+
+// createBizTag 创建业务标识
+func (a *IDGenAPI) createBizTag(c *gin.Context) {
+	var req BizTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, err.Error())
+		return
+	}
+
+	if err := a.service.CreateBizTag(c.Request.Context(), req.BizTag, req.Step, req.Description); err != nil {
+		a.error(c, err)
+		return
+	}
+	Success(c, gin.H{"biz_tag": req.BizTag})
+}
+
+// updateStep 更新步长
+func (a *IDGenAPI) updateStep(c *gin.Context) {
+	var req StepRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Step <= 0 {
+		BadRequest(c, "a positive step is required")
+		return
+	}
+
+	bizTag := c.Param("biz_tag")
+	if err := a.service.UpdateStep(c.Request.Context(), bizTag, req.Step); err != nil {
+		a.error(c, err)
+		return
+	}
+	Success(c, gin.H{"biz_tag": bizTag, "step": req.Step})
+}
+
+// deleteBizTag 删除业务标识，之后再次请求会从0重新创建，可能发出已使用过的ID
+func (a *IDGenAPI) deleteBizTag(c *gin.Context) {
+	bizTag := c.Param("biz_tag")
+	if err := a.service.DeleteBizTag(c.Request.Context(), bizTag); err != nil {
+		a.error(c, err)
+		return
+	}
+	Success(c, gin.H{"biz_tag": bizTag, "deleted": true})
+}
+
+// bufferStatus 获取服务端缓冲区状态
+func (a *IDGenAPI) bufferStatus(c *gin.Context) {
+	Success(c, a.service.GetBufferStatus(c.Param("biz_tag")))
+}
+
+// bizTagMetrics 获取单个业务标识的指标
+func (a *IDGenAPI) bizTagMetrics(c *gin.Context) {
+	Success(c, a.service.GetMetrics(c.Param("biz_tag")))
+}
+
+// metrics 获取所有业务标识的指标
+func (a *IDGenAPI) metrics(c *gin.Context) {
+	metrics := make(map[string]interface{})
+	for bizTag := range a.service.GetAllMetrics() {
+		metrics[bizTag] = a.service.GetMetrics(bizTag)
+	}
+	Success(c, metrics)
+}
+
+// error 将生成器错误转换为HTTP状态码
+func (a *IDGenAPI) error(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, idgen.ErrBizTagNotFound):
+		NotFound(c, err.Error())
+	case errors.Is(err, idgen.ErrBizTagExists):
+		Error(c, http.StatusConflict, err.Error())
+	case errors.Is(err, idgen.ErrNotSupported):
+		Error(c, http.StatusNotImplemented, err.Error())
+	default:
+		InternalError(c, err.Error())
+	}
+}
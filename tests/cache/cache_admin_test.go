@@ -0,0 +1,147 @@
+package cache_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/qiaojinxia/distributed-service/framework/auth"
+	"github.com/qiaojinxia/distributed-service/framework/cache"
+	httptransport "github.com/qiaojinxia/distributed-service/framework/transport/http"
+)
+
+func TestCacheAdminAPI(t *testing.T) {
+	ctx := context.Background()
+	gin.SetMode(gin.TestMode)
+
+	manager := cache.NewManager()
+	manager.RegisterBuilder(cache.TypeMemory, &cache.MemoryBuilder{})
+	manager.RegisterBuilder(cache.TypeHybrid, cache.NewHybridBuilder(manager))
+	if err := manager.CreateCache(cache.Config{Name: "users", Type: cache.TypeMemory, Settings: map[string]interface{}{
+		"max_size": 100,
+		"password": "secret",
+	}}); err != nil {
+		t.Fatalf("缓存创建失败: %v", err)
+	}
+	if err := manager.CreateCache(cache.Config{Name: "orders", Type: cache.TypeHybrid, Settings: map[string]interface{}{
+		"l1_config": map[string]interface{}{"type": "memory", "name": "orders-l1"},
+		"l2_config": map[string]interface{}{"type": "memory", "name": "orders-l2", "settings": map[string]interface{}{
+			"nodes": []interface{}{map[string]interface{}{"addr": "127.0.0.1:6379", "auth_token": "token"}},
+		}},
+		"sync_strategy":       "write_back",
+		"write_back_interval": int64(time.Hour),
+	}}); err != nil {
+		t.Fatalf("混合缓存创建失败: %v", err)
+	}
+	defer manager.Close()
+
+	users, _ := manager.GetCache("users")
+	_ = users.Set(ctx, "user:1", "alice", time.Minute)
+	_ = users.Set(ctx, "user:2", "bob", time.Minute)
+	_ = users.Set(ctx, "session/1", "s", time.Minute)
+
+	jwtManager := auth.NewJWTManager("test-secret", "test")
+	engine := gin.New()
+	httptransport.CacheAdminRoutes(manager, jwtManager)(engine.Group("/admin/cache"))
+
+	adminToken, _ := jwtManager.GenerateTokenWithRole(ctx, 1, "ops", "admin")
+	userToken, _ := jwtManager.GenerateToken(ctx, 2, "guest")
+
+	do := func(method, path, token, body string) (int, map[string]interface{}) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, req)
+
+		var resp map[string]interface{}
+		_ = json.Unmarshal(recorder.Body.Bytes(), &resp)
+		return recorder.Code, resp
+	}
+
+	t.Run("Auth", func(t *testing.T) {
+		if code, _ := do(http.MethodGet, "/admin/cache", "", ""); code != http.StatusUnauthorized {
+			t.Errorf("未携带令牌应返回401, 得到 %d", code)
+		}
+		if code, _ := do(http.MethodGet, "/admin/cache", userToken, ""); code != http.StatusForbidden {
+			t.Errorf("非管理员应返回403, 得到 %d", code)
+		}
+	})
+
+	t.Run("Inspect", func(t *testing.T) {
+		code, resp := do(http.MethodGet, "/admin/cache", adminToken, "")
+		if code != http.StatusOK {
+			t.Fatalf("列出缓存失败: %d %v", code, resp)
+		}
+		if caches, _ := resp["data"].([]interface{}); len(caches) != 3 {
+			t.Errorf("应列出users、orders及其L2, 得到 %v", resp["data"])
+		}
+
+		code, resp = do(http.MethodGet, "/admin/cache/users", adminToken, "")
+		data, _ := resp["data"].(map[string]interface{})
+		settings, _ := data["settings"].(map[string]interface{})
+		if code != http.StatusOK || data["type"] != "memory" || settings["password"] != "******" {
+			t.Errorf("缓存详情错误或未隐藏敏感配置: %d %v", code, resp)
+		}
+		// 嵌套设置中的敏感项同样需要隐藏
+		_, resp = do(http.MethodGet, "/admin/cache/orders", adminToken, "")
+		if body, _ := json.Marshal(resp); strings.Contains(string(body), `"token"`) || !strings.Contains(string(body), "127.0.0.1:6379") {
+			t.Errorf("混合缓存嵌套配置未隐藏敏感项: %s", body)
+		}
+		if code, _ := do(http.MethodGet, "/admin/cache/missing", adminToken, ""); code != http.StatusNotFound {
+			t.Errorf("不存在的缓存应返回404, 得到 %d", code)
+		}
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		code, resp := do(http.MethodGet, "/admin/cache/users/keys/session/1", adminToken, "")
+		if data, _ := resp["data"].(map[string]interface{}); code != http.StatusOK || data["value"] != "s" {
+			t.Errorf("读取含斜杠的键失败: %d %v", code, resp)
+		}
+		if code, _ := do(http.MethodDelete, "/admin/cache/users/keys/session/1", adminToken, ""); code != http.StatusOK {
+			t.Errorf("删除键失败: %d", code)
+		}
+		if code, _ := do(http.MethodGet, "/admin/cache/users/keys/session/1", adminToken, ""); code != http.StatusNotFound {
+			t.Errorf("删除后应返回404, 得到 %d", code)
+		}
+	})
+
+	t.Run("InvalidatePrefix", func(t *testing.T) {
+		if code, _ := do(http.MethodPost, "/admin/cache/users/invalidate", adminToken, `{}`); code != http.StatusBadRequest {
+			t.Errorf("缺少前缀应返回400, 得到 %d", code)
+		}
+		if code, resp := do(http.MethodPost, "/admin/cache/users/invalidate", adminToken, `{"prefix":"user:"}`); code != http.StatusOK {
+			t.Fatalf("前缀失效失败: %d %v", code, resp)
+		}
+		if exists, _ := users.Exists(ctx, "user:1"); exists {
+			t.Error("前缀失效后键不应存在")
+		}
+	})
+
+	t.Run("Flush", func(t *testing.T) {
+		orders, _ := manager.GetCache("orders")
+		l2, _ := manager.GetCache("orders-l2")
+		_ = orders.Set(ctx, "order:1", "v1", time.Minute)
+		if exists, _ := l2.Exists(ctx, "order:1"); exists {
+			t.Fatal("写回前L2不应有数据")
+		}
+
+		code, resp := do(http.MethodPost, "/admin/cache/orders/flush", adminToken, "")
+		if data, _ := resp["data"].(map[string]interface{}); code != http.StatusOK || data["pending"] != float64(0) {
+			t.Fatalf("写回失败: %d %v", code, resp)
+		}
+		if exists, _ := l2.Exists(ctx, "order:1"); !exists {
+			t.Error("写回后L2应有数据")
+		}
+		if code, _ := do(http.MethodPost, "/admin/cache/users/flush", adminToken, ""); code != http.StatusBadRequest {
+			t.Errorf("非混合缓存应返回400, 得到 %d", code)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package cache_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/cache"
+)
+
+// 运行: go test ./tests/cache -run ^$ -bench MemoryCache -cpu 1,2,4,8
+// 分片缓存的吞吐应随 GOMAXPROCS 增长，单锁缓存基本持平
+
+const benchKeySpace = 1 << 14
+
+func benchmarkCache(b *testing.B, c cache.Cache) {
+	ctx := context.Background()
+	keys := make([]string, benchKeySpace)
+	for i := range keys {
+		keys[i] = "session:" + strconv.Itoa(i)
+		c.Set(ctx, keys[i], i, 0)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := keys[i&(benchKeySpace-1)]
+			// 读写比 3:1
+			if i&3 == 0 {
+				c.Set(ctx, key, i, 0)
+			} else {
+				c.Get(ctx, key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkMemoryCache(b *testing.B) {
+	for _, policy := range []cache.EvictionPolicy{cache.EvictionPolicyLRU, cache.EvictionPolicyTTL, cache.EvictionPolicyTinyLFU} {
+		b.Run(string(policy), func(b *testing.B) {
+			c, err := cache.NewMemoryCache(cache.MemoryConfig{
+				MaxSize:        benchKeySpace,
+				DefaultTTL:     time.Hour,
+				EvictionPolicy: policy,
+			})
+			if err != nil {
+				b.Fatalf("缓存创建失败: %v", err)
+			}
+			benchmarkCache(b, c)
+		})
+	}
+}
+
+func BenchmarkShardedMemoryCache(b *testing.B) {
+	for _, policy := range []cache.EvictionPolicy{cache.EvictionPolicyLRU, cache.EvictionPolicyTTL, cache.EvictionPolicyTinyLFU} {
+		b.Run(string(policy), func(b *testing.B) {
+			c, err := cache.NewShardedMemoryCache(cache.MemoryConfig{
+				MaxSize:        benchKeySpace,
+				DefaultTTL:     time.Hour,
+				EvictionPolicy: policy,
+				ShardCount:     32,
+			})
+			if err != nil {
+				b.Fatalf("缓存创建失败: %v", err)
+			}
+			benchmarkCache(b, c)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package cache_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/cache"
+)
+
+// fakeMemcached 进程内的Memcached文本协议替身，支持get/set/delete/flush_all
+type fakeMemcached struct {
+	listener net.Listener
+	mu       sync.Mutex
+	items    map[string][]byte
+	exptimes map[string]int64
+}
+
+func newFakeMemcached(t *testing.T) *fakeMemcached {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败: %v", err)
+	}
+	server := &fakeMemcached{
+		listener: listener,
+		items:    make(map[string][]byte),
+		exptimes: make(map[string]int64),
+	}
+	go server.serve()
+	t.Cleanup(func() { _ = listener.Close() })
+	return server
+}
+
+func (f *fakeMemcached) addr() string {
+	return f.listener.Addr().String()
+}
+
+func (f *fakeMemcached) len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.items)
+}
+
+func (f *fakeMemcached) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeMemcached) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		f.mu.Lock()
+		switch fields[0] {
+		case "get":
+			for _, key := range fields[1:] {
+				if data, ok := f.items[key]; ok {
+					fmt.Fprintf(w, "VALUE %s 0 %d\r\n%s\r\n", key, len(data), data)
+				}
+			}
+			w.WriteString("END\r\n")
+		case "set":
+			size, _ := strconv.Atoi(fields[4])
+			data := make([]byte, size+2)
+			if _, err := io.ReadFull(r, data); err != nil {
+				f.mu.Unlock()
+				return
+			}
+			if strings.Contains(string(data), "desync") {
+				// 模拟服务端把数据块当作命令处理，多出一行ERROR响应
+				w.WriteString("CLIENT_ERROR bad data chunk\r\nERROR\r\n")
+				break
+			}
+			f.items[fields[1]] = data[:size]
+			f.exptimes[fields[1]], _ = strconv.ParseInt(fields[3], 10, 64)
+			w.WriteString("STORED\r\n")
+		case "delete":
+			if _, ok := f.items[fields[1]]; ok {
+				delete(f.items, fields[1])
+				w.WriteString("DELETED\r\n")
+			} else {
+				w.WriteString("NOT_FOUND\r\n")
+			}
+		case "flush_all":
+			f.items = make(map[string][]byte)
+			w.WriteString("OK\r\n")
+		default:
+			w.WriteString("ERROR\r\n")
+		}
+		f.mu.Unlock()
+
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func TestMemcachedCache(t *testing.T) {
+	ctx := context.Background()
+	nodeA := newFakeMemcached(t)
+	nodeB := newFakeMemcached(t)
+
+	c, err := cache.NewMemcachedCache(cache.MemcachedConfig{
+		Servers:   []string{nodeA.addr(), nodeB.addr()},
+		KeyPrefix: "app",
+	})
+	if err != nil {
+		t.Fatalf("Memcached缓存创建失败: %v", err)
+	}
+	defer c.Close()
+
+	t.Run("Basic", func(t *testing.T) {
+		if err := c.Set(ctx, "user:1", "alice", 1500*time.Millisecond); err != nil {
+			t.Fatalf("设置失败: %v", err)
+		}
+		if value, err := c.Get(ctx, "user:1"); err != nil || value != "alice" {
+			t.Errorf("应读到alice, 得到 %v, %v", value, err)
+		}
+		if exists, _ := c.Exists(ctx, "user:1"); !exists {
+			t.Error("键应存在")
+		}
+		if err := c.Delete(ctx, "user:1"); err != nil {
+			t.Fatalf("删除失败: %v", err)
+		}
+		if _, err := c.Get(ctx, "user:1"); !errors.Is(err, cache.ErrKeyNotFound) {
+			t.Errorf("删除后应返回ErrKeyNotFound, 得到 %v", err)
+		}
+		if err := c.Set(ctx, "bad key", "v", time.Minute); !errors.Is(err, cache.ErrInvalidKey) {
+			t.Errorf("含空格的键应返回ErrInvalidKey, 得到 %v", err)
+		}
+	})
+
+	t.Run("ConsistentHashing", func(t *testing.T) {
+		values := make(map[string]interface{})
+		keys := make([]string, 0, 200)
+		for i := 0; i < 200; i++ {
+			key := fmt.Sprintf("item:%d", i)
+			values[key] = i
+			keys = append(keys, key)
+		}
+		if err := c.MSet(ctx, values, time.Minute); err != nil {
+			t.Fatalf("批量设置失败: %v", err)
+		}
+		if nodeA.len() == 0 || nodeB.len() == 0 {
+			t.Errorf("键应分布到所有节点: %d / %d", nodeA.len(), nodeB.len())
+		}
+
+		result, err := c.MGet(ctx, append(keys, "missing"))
+		if err != nil {
+			t.Fatalf("批量获取失败: %v", err)
+		}
+		if len(result) != 200 || result["item:42"] != "42" {
+			t.Errorf("批量获取结果错误: %d 个, item:42=%v", len(result), result["item:42"])
+		}
+
+		// 同一份节点列表在另一个实例中得到相同的分布
+		other, _ := cache.NewMemcachedCache(cache.MemcachedConfig{
+			Servers:   []string{nodeB.addr(), nodeA.addr()},
+			KeyPrefix: "app",
+		})
+		defer other.Close()
+		if value, _ := other.Get(ctx, "item:7"); value != "7" {
+			t.Errorf("节点顺序不同的实例应读到相同的值, 得到 %v", value)
+		}
+
+		if err := c.MDelete(ctx, keys); err != nil {
+			t.Fatalf("批量删除失败: %v", err)
+		}
+		if nodeA.len()+nodeB.len() != 0 {
+			t.Errorf("批量删除后节点应为空: %d / %d", nodeA.len(), nodeB.len())
+		}
+		if stats := c.GetStats(); stats.Deletes < 200 || stats.Misses == 0 {
+			t.Errorf("统计错误: %+v", stats)
+		}
+	})
+
+	t.Run("ProtocolError", func(t *testing.T) {
+		single, err := cache.NewMemcachedCache(cache.MemcachedConfig{Servers: []string{nodeA.addr()}})
+		if err != nil {
+			t.Fatalf("Memcached缓存创建失败: %v", err)
+		}
+		defer single.Close()
+
+		if err := single.Set(ctx, "bad", "desync", time.Minute); err == nil {
+			t.Fatal("CLIENT_ERROR应返回错误")
+		}
+		// 出错的连接不能放回连接池，否则残留的响应会被下一个请求读到
+		if err := single.Set(ctx, "good", "ok", time.Minute); err != nil {
+			t.Errorf("CLIENT_ERROR后的请求不应受影响: %v", err)
+		}
+		if value, err := single.Get(ctx, "good"); err != nil || value != "ok" {
+			t.Errorf("应读到ok, 得到 %v, %v", value, err)
+		}
+	})
+
+	t.Run("HybridL2", func(t *testing.T) {
+		manager := cache.NewManager()
+		manager.RegisterBuilder(cache.TypeMemory, &cache.MemoryBuilder{})
+		manager.RegisterBuilder(cache.TypeMemcached, &cache.MemcachedBuilder{})
+
+		hybrid, err := cache.NewHybridCache(cache.HybridConfig{
+			L1Config: cache.Config{Type: cache.TypeMemory, Name: "l1"},
+			L2Config: cache.Config{Type: cache.TypeMemcached, Name: "l2", Settings: map[string]interface{}{
+				"servers":    []string{nodeA.addr(), nodeB.addr()},
+				"key_prefix": "hybrid",
+				"timeout":    "500ms",
+			}},
+			SyncStrategy: cache.SyncStrategyWriteThrough,
+		}, manager)
+		if err != nil {
+			t.Fatalf("混合缓存创建失败: %v", err)
+		}
+		defer hybrid.Close()
+
+		if err := hybrid.Set(ctx, "product:1", "book", time.Minute); err != nil {
+			t.Fatalf("设置失败: %v", err)
+		}
+		l2, _ := cache.NewMemcachedCache(cache.MemcachedConfig{
+			Servers:   []string{nodeA.addr(), nodeB.addr()},
+			KeyPrefix: "hybrid",
+		})
+		defer l2.Close()
+		if value, _ := l2.Get(ctx, "product:1"); value != "book" {
+			t.Errorf("写透后L2应有数据, 得到 %v", value)
+		}
+	})
+}
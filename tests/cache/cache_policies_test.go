@@ -2,10 +2,13 @@ package cache_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/qiaojinxia/distributed-service/framework/cache"
+	"github.com/qiaojinxia/distributed-service/framework/config"
 )
 
 func TestCachePolicies(t *testing.T) {
@@ -27,6 +30,22 @@ func TestCachePolicies(t *testing.T) {
 		t.Log("🔧 Simple策略测试")
 		testSimple(ctx, t)
 	})
+
+	t.Run("LFUPolicy", func(t *testing.T) {
+		t.Log("📊 LFU策略测试")
+		testLFU(ctx, t)
+	})
+
+	t.Run("FIFOPolicy", func(t *testing.T) {
+		t.Log("📥 FIFO策略测试")
+		testFIFO(ctx, t)
+	})
+
+	t.Run("ScanResistance", func(t *testing.T) {
+		t.Log("🛡️ ARC/TinyLFU抗扫描测试")
+		testScanResistance(ctx, t, cache.EvictionPolicyARC)
+		testScanResistance(ctx, t, cache.EvictionPolicyTinyLFU)
+	})
 }
 
 func testLRU(ctx context.Context, t *testing.T) {
@@ -180,3 +199,228 @@ func testSimple(ctx context.Context, t *testing.T) {
 	}
 	t.Log("✅ Simple TTL过期正常")
 }
+
+func testLFU(ctx context.Context, t *testing.T) {
+	config := cache.MemoryConfig{
+		MaxSize:          3,
+		DefaultTTL:       time.Minute,
+		EvictionPolicy:   cache.EvictionPolicyLFU,
+		LFUDecayRate:     0.5,
+		LFUDecayInterval: time.Millisecond * 200,
+	}
+
+	lfuCache, err := cache.NewMemoryCache(config)
+	if err != nil {
+		t.Fatalf("LFU缓存创建失败: %v", err)
+	}
+
+	lfuCache.Set(ctx, "key1", "value1", 0)
+	lfuCache.Set(ctx, "key2", "value2", 0)
+	lfuCache.Set(ctx, "key3", "value3", 0)
+
+	// key1、key3被多次访问，key2访问最少
+	for i := 0; i < 3; i++ {
+		lfuCache.Get(ctx, "key1")
+		lfuCache.Get(ctx, "key3")
+	}
+	lfuCache.Set(ctx, "key4", "value4", 0)
+
+	if exists, _ := lfuCache.Exists(ctx, "key2"); exists {
+		t.Error("key2应该被淘汰（访问频率最低）")
+	}
+	if exists, _ := lfuCache.Exists(ctx, "key1"); !exists {
+		t.Error("key1应该存在（访问频率高）")
+	}
+	t.Log("✅ LFU淘汰机制工作正常")
+
+	// 衰减后历史热点的频率降到下限，按最久未访问淘汰
+	time.Sleep(time.Millisecond * 800)
+	lfuCache.Get(ctx, "key4")
+	lfuCache.Set(ctx, "key5", "value5", 0)
+	if exists, _ := lfuCache.Exists(ctx, "key1"); exists {
+		t.Error("key1应该在频率衰减后被淘汰")
+	}
+	t.Log("✅ LFU频率衰减正常")
+}
+
+func testFIFO(ctx context.Context, t *testing.T) {
+	config := cache.MemoryConfig{
+		MaxSize:        2,
+		DefaultTTL:     time.Minute,
+		EvictionPolicy: cache.EvictionPolicyFIFO,
+	}
+
+	fifoCache, err := cache.NewMemoryCache(config)
+	if err != nil {
+		t.Fatalf("FIFO缓存创建失败: %v", err)
+	}
+
+	fifoCache.Set(ctx, "key1", "value1", 0)
+	fifoCache.Set(ctx, "key2", "value2", 0)
+	fifoCache.Get(ctx, "key1")
+	fifoCache.Set(ctx, "key3", "value3", 0)
+
+	if exists, _ := fifoCache.Exists(ctx, "key1"); exists {
+		t.Error("key1应该被淘汰（最先写入，与访问无关）")
+	}
+	if stats := fifoCache.GetStats(); stats.Evictions != 1 {
+		t.Errorf("淘汰次数应为1, 得到 %d", stats.Evictions)
+	}
+	t.Log("✅ FIFO淘汰机制工作正常")
+}
+
+func testScanResistance(ctx context.Context, t *testing.T, policy cache.EvictionPolicy) {
+	config := cache.MemoryConfig{
+		MaxSize:        100,
+		DefaultTTL:     time.Minute,
+		EvictionPolicy: policy,
+	}
+
+	memCache, err := cache.NewMemoryCache(config)
+	if err != nil {
+		t.Fatalf("%s缓存创建失败: %v", policy, err)
+	}
+
+	// 热点数据被反复访问
+	for round := 0; round < 5; round++ {
+		for i := 0; i < 50; i++ {
+			key := fmt.Sprintf("hot:%d", i)
+			if _, err := memCache.Get(ctx, key); err != nil {
+				memCache.Set(ctx, key, i, 0)
+			}
+		}
+	}
+
+	// 一次性扫描大量冷数据
+	for i := 0; i < 1000; i++ {
+		memCache.Set(ctx, fmt.Sprintf("scan:%d", i), i, 0)
+	}
+
+	hits := 0
+	for i := 0; i < 50; i++ {
+		if exists, _ := memCache.Exists(ctx, fmt.Sprintf("hot:%d", i)); exists {
+			hits++
+		}
+	}
+	if hits < 45 {
+		t.Errorf("%s: 扫描后热点数据应基本保留, 仅剩 %d/50", policy, hits)
+	}
+	t.Logf("✅ %s扫描后保留热点 %d/50", policy, hits)
+}
+
+func TestShardedMemoryCache(t *testing.T) {
+	ctx := context.Background()
+
+	shardedCache, err := cache.NewShardedMemoryCache(cache.MemoryConfig{
+		MaxSize:        64,
+		DefaultTTL:     time.Minute,
+		EvictionPolicy: cache.EvictionPolicyLRU,
+		ShardCount:     8,
+	})
+	if err != nil {
+		t.Fatalf("分片缓存创建失败: %v", err)
+	}
+
+	for i := 0; i < 32; i++ {
+		shardedCache.Set(ctx, fmt.Sprintf("key%d", i), i, 0)
+	}
+	for i := 0; i < 32; i++ {
+		value, err := shardedCache.Get(ctx, fmt.Sprintf("key%d", i))
+		if err != nil || value != i {
+			t.Errorf("key%d 应为 %d, 得到 %v (err=%v)", i, i, value, err)
+		}
+	}
+
+	if stats := shardedCache.GetStats(); stats.Sets != 32 || stats.Hits != 32 {
+		t.Errorf("统计应汇总所有分片, 得到 sets=%d hits=%d", stats.Sets, stats.Hits)
+	}
+
+	shardedCache.Clear(ctx)
+	if exists, _ := shardedCache.Exists(ctx, "key0"); exists {
+		t.Error("Clear后key0不应存在")
+	}
+	t.Log("✅ 分片缓存工作正常")
+}
+
+func TestMemoryCacheMaxBytes(t *testing.T) {
+	ctx := context.Background()
+
+	bytesCache, err := cache.NewMemoryCache(cache.MemoryConfig{
+		MaxBytes:       100,
+		DefaultTTL:     time.Minute,
+		EvictionPolicy: cache.EvictionPolicyLRU,
+		Sizer: func(key string, value interface{}) int64 {
+			return int64(len(value.([]byte)))
+		},
+	})
+	if err != nil {
+		t.Fatalf("按字节数限制的缓存创建失败: %v", err)
+	}
+
+	blob := make([]byte, 40)
+	bytesCache.Set(ctx, "blob1", blob, 0)
+	bytesCache.Set(ctx, "blob2", blob, 0)
+	bytesCache.Get(ctx, "blob1")
+	if stats := bytesCache.GetStats(); stats.Bytes != 80 {
+		t.Errorf("当前字节数应为80, 得到 %d", stats.Bytes)
+	}
+
+	// 超出100字节，淘汰最久未使用的blob2
+	bytesCache.Set(ctx, "blob3", blob, 0)
+	if exists, _ := bytesCache.Exists(ctx, "blob2"); exists {
+		t.Error("blob2应该被淘汰（超出字节数上限）")
+	}
+	if stats := bytesCache.GetStats(); stats.Bytes != 80 || stats.Evictions != 1 {
+		t.Errorf("期望80字节、1次淘汰, 得到 %d 字节、%d 次淘汰", stats.Bytes, stats.Evictions)
+	}
+
+	// 单个条目超过上限直接拒绝
+	if err := bytesCache.Set(ctx, "huge", make([]byte, 200), 0); !errors.Is(err, cache.ErrEntryTooLarge) {
+		t.Errorf("超大条目应返回ErrEntryTooLarge, 得到 %v", err)
+	}
+
+	bytesCache.Delete(ctx, "blob1")
+	if stats := bytesCache.GetStats(); stats.Bytes != 40 {
+		t.Errorf("删除后字节数应为40, 得到 %d", stats.Bytes)
+	}
+	t.Log("✅ 按字节数淘汰工作正常")
+}
+
+func TestMemoryDefaultsFromFramework(t *testing.T) {
+	ctx := context.Background()
+
+	service := cache.NewFrameworkCacheService()
+	if err := service.SetMemoryDefaults(config.CacheMemoryConfig{LFUDecayRate: "2"}); err == nil {
+		t.Error("超出[0, 1]的衰减率应返回错误")
+	}
+	if err := service.SetMemoryDefaults(config.CacheMemoryConfig{MaxSize: 2, EvictionPolicy: "fifo"}); err != nil {
+		t.Fatalf("设置内存缓存默认配置失败: %v", err)
+	}
+
+	// 未设置的字段使用默认配置，Settings中的字段覆盖默认配置
+	if err := service.Manager.CreateCache(cache.Config{Type: cache.TypeMemory, Name: "defaults"}); err != nil {
+		t.Fatalf("内存缓存创建失败: %v", err)
+	}
+	if err := service.Manager.CreateCache(cache.Config{
+		Type:     cache.TypeMemory,
+		Name:     "override",
+		Settings: map[string]interface{}{"max_size": 3},
+	}); err != nil {
+		t.Fatalf("内存缓存创建失败: %v", err)
+	}
+	defer service.Close()
+
+	for name, capacity := range map[string]int{"defaults": 2, "override": 3} {
+		c, _ := service.GetCache(name)
+		for i := 1; i <= 4; i++ {
+			c.Set(ctx, fmt.Sprintf("key%d", i), i, 0)
+			c.Get(ctx, "key1")
+		}
+		if exists, _ := c.Exists(ctx, "key1"); exists {
+			t.Errorf("%s: key1应该被FIFO淘汰", name)
+		}
+		if stats := service.GetStats()[name]; stats.Evictions != int64(4-capacity) {
+			t.Errorf("%s: 淘汰次数应为%d, 得到 %d", name, 4-capacity, stats.Evictions)
+		}
+	}
+}
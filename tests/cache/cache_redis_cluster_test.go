@@ -0,0 +1,93 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/cache"
+)
+
+func TestRedisClusterSlot(t *testing.T) {
+	cases := map[string]int{
+		"123456789":            12739,
+		"foo":                  12182,
+		"{user1000}.following": cache.RedisClusterSlot("user1000"),
+		"foo{{bar}}zap":        cache.RedisClusterSlot("{bar"),
+	}
+	for key, want := range cases {
+		if got := cache.RedisClusterSlot(key); got != want {
+			t.Errorf("键 %s 的槽位应为 %d, 得到 %d", key, want, got)
+		}
+	}
+	if cache.RedisClusterSlot("{user1000}.following") != cache.RedisClusterSlot("{user1000}.followers") {
+		t.Error("相同哈希标签的键应在同一槽位")
+	}
+}
+
+func TestRedisTopologyBuilders(t *testing.T) {
+	if _, err := cache.NewRedisClusterBuilder(nil).Build(cache.Config{Name: "c"}); err == nil {
+		t.Error("未注入客户端且未配置addrs时应返回错误")
+	}
+	if _, err := cache.NewRedisSentinelBuilder(nil).Build(cache.Config{
+		Name:     "s",
+		Settings: map[string]interface{}{"sentinel_addrs": []string{"127.0.0.1:26379"}},
+	}); err == nil {
+		t.Error("未配置master_name时应返回错误")
+	}
+
+	// 客户端延迟连接，构建时不访问Redis；时长支持配置文件中的字符串形式
+	clusterCache, err := cache.NewRedisClusterBuilder(nil).Build(cache.Config{
+		Name: "c",
+		Settings: map[string]interface{}{
+			"addrs":        []interface{}{"127.0.0.1:7000", "127.0.0.1:7001"},
+			"key_prefix":   "app",
+			"dial_timeout": "5s",
+			"read_timeout": 500 * time.Millisecond,
+		},
+	})
+	if err != nil {
+		t.Fatalf("集群缓存构建失败: %v", err)
+	}
+	if err := clusterCache.Close(); err != nil {
+		t.Errorf("关闭集群缓存失败: %v", err)
+	}
+
+	sentinelCache, err := cache.NewRedisSentinelBuilder(nil).Build(cache.Config{
+		Name: "s",
+		Settings: map[string]interface{}{
+			"master_name":    "mymaster",
+			"sentinel_addrs": []string{"127.0.0.1:26379"},
+			"dial_timeout":   "5s",
+			"write_timeout":  "1s",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Sentinel缓存构建失败: %v", err)
+	}
+	if err := sentinelCache.Close(); err != nil {
+		t.Errorf("关闭Sentinel缓存失败: %v", err)
+	}
+
+	if _, err := cache.NewRedisClusterBuilder(nil).Build(cache.Config{
+		Name:     "c",
+		Settings: map[string]interface{}{"addrs": []string{"127.0.0.1:7000"}, "dial_timeout": "five seconds"},
+	}); err == nil {
+		t.Error("非法的时长字符串应返回错误")
+	}
+
+	memoryCache, err := (&cache.MemoryBuilder{}).Build(cache.Config{
+		Name: "m",
+		Settings: map[string]interface{}{
+			"max_size":           100,
+			"default_ttl":        "1h",
+			"cleanup_interval":   "10m",
+			"eviction_policy":    "lfu",
+			"lfu_decay_rate":     0.5,
+			"lfu_decay_interval": "30s",
+		},
+	})
+	if err != nil {
+		t.Fatalf("内存缓存构建失败: %v", err)
+	}
+	_ = memoryCache.Close()
+}
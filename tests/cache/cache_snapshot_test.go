@@ -0,0 +1,187 @@
+package cache_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/cache"
+)
+
+type snapshotProfile struct {
+	Name string
+	Age  int
+}
+
+func init() {
+	gob.Register(snapshotProfile{})
+}
+
+func TestMemoryCacheSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		for _, policy := range []cache.EvictionPolicy{cache.EvictionPolicyLRU, cache.EvictionPolicyTTL, cache.EvictionPolicyLFU} {
+			source, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100, EvictionPolicy: policy})
+			_ = source.Set(ctx, "name", "alice", time.Hour)
+			_ = source.Set(ctx, "profile", snapshotProfile{Name: "bob", Age: 30}, time.Hour)
+			_ = source.Set(ctx, "unsupported", make(chan int), time.Hour)
+			_ = source.SetWithTags(ctx, "order:1", 42, time.Hour, "orders")
+
+			var buf bytes.Buffer
+			stats, err := source.WriteSnapshot(&buf)
+			if err != nil {
+				t.Fatalf("%s: 导出快照失败: %v", policy, err)
+			}
+			if stats.Entries != 3 || stats.Skipped != 1 {
+				t.Errorf("%s: 导出统计错误: %+v", policy, stats)
+			}
+
+			target, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100, EvictionPolicy: policy})
+			if _, err := target.ReadSnapshot(&buf); err != nil {
+				t.Fatalf("%s: 恢复快照失败: %v", policy, err)
+			}
+			if value, _ := target.Get(ctx, "name"); value != "alice" {
+				t.Errorf("%s: 应恢复字符串值, 得到 %v", policy, value)
+			}
+			if value, _ := target.Get(ctx, "profile"); value != (snapshotProfile{Name: "bob", Age: 30}) {
+				t.Errorf("%s: 应恢复注册过的结构体类型, 得到 %#v", policy, value)
+			}
+			if keys, _ := target.TagKeys(ctx, "orders"); len(keys) != 1 || keys[0] != "order:1" {
+				t.Errorf("%s: 应恢复标签, 得到 %v", policy, keys)
+			}
+		}
+	})
+
+	t.Run("RemainingTTL", func(t *testing.T) {
+		source, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100, EvictionPolicy: cache.EvictionPolicyTTL})
+		_ = source.Set(ctx, "short", "v", 100*time.Millisecond)
+		_ = source.Set(ctx, "long", "v", time.Hour)
+
+		var buf bytes.Buffer
+		_, _ = source.WriteSnapshot(&buf)
+		target, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100, EvictionPolicy: cache.EvictionPolicyTTL})
+		_, _ = target.ReadSnapshot(&buf)
+
+		time.Sleep(150 * time.Millisecond)
+		if _, err := target.Get(ctx, "short"); !errors.Is(err, cache.ErrKeyNotFound) {
+			t.Errorf("恢复后应保留剩余过期时间, 得到 %v", err)
+		}
+		if exists, _ := target.Exists(ctx, "long"); !exists {
+			t.Error("未过期的键应保留")
+		}
+	})
+
+	t.Run("LRUOrder", func(t *testing.T) {
+		source, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 3})
+		_ = source.Set(ctx, "a", 1, 0)
+		_ = source.Set(ctx, "b", 2, 0)
+		_ = source.Set(ctx, "c", 3, 0)
+		_, _ = source.Get(ctx, "a") // b 成为最久未使用
+
+		var buf bytes.Buffer
+		_, _ = source.WriteSnapshot(&buf)
+		target, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 3})
+		_, _ = target.ReadSnapshot(&buf)
+		_ = target.Set(ctx, "d", 4, 0)
+
+		if exists, _ := target.Exists(ctx, "b"); exists {
+			t.Error("恢复后应保持访问顺序, b应被淘汰")
+		}
+		if exists, _ := target.Exists(ctx, "a"); !exists {
+			t.Error("a应保留")
+		}
+	})
+
+	t.Run("FileOnClose", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "users.snapshot")
+		manager := cache.NewManager()
+		manager.RegisterBuilder(cache.TypeMemory, &cache.MemoryBuilder{})
+		settings := map[string]interface{}{"shard_count": 4, "snapshot_path": path}
+		_ = manager.CreateCache(cache.Config{Name: "users", Type: cache.TypeMemory, Settings: settings})
+		users, _ := manager.GetCache("users")
+		for i, key := range []string{"u1", "u2", "u3"} {
+			_ = users.Set(ctx, key, i, time.Hour)
+		}
+		if err := manager.Close(); err != nil {
+			t.Fatalf("关闭时导出快照失败: %v", err)
+		}
+
+		// 重启后分片数不同也能恢复
+		restarted, err := cache.NewMemoryCache(cache.MemoryConfig{SnapshotPath: path})
+		if err != nil {
+			t.Fatalf("缓存创建失败: %v", err)
+		}
+		if restarted.Len() != 3 {
+			t.Errorf("启动时应从快照恢复3个条目, 得到 %d", restarted.Len())
+		}
+		if value, _ := restarted.Get(ctx, "u2"); value != 1 {
+			t.Errorf("恢复的值错误: %v", value)
+		}
+
+		missing, err := cache.NewMemoryCache(cache.MemoryConfig{SnapshotPath: filepath.Join(t.TempDir(), "none")})
+		if err != nil || missing.Len() != 0 {
+			t.Errorf("快照文件不存在时应从空缓存开始: %v", err)
+		}
+	})
+}
+
+func TestCacheWarmup(t *testing.T) {
+	ctx := context.Background()
+
+	var batches [][]string
+	cache.RegisterWarmupLoader("test-products", func(ctx context.Context, keys []string) (map[string]interface{}, error) {
+		batches = append(batches, keys)
+		values := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			if key != "missing" {
+				values[key] = "product-" + key
+			}
+		}
+		return values, nil
+	})
+
+	config, err := cache.ParseWarmupConfig(map[string]interface{}{
+		"max_size": 100,
+		"warmup": map[string]interface{}{
+			"loader":     "test-products",
+			"keys":       []interface{}{"p1", "p2", "p3", "missing"},
+			"batch_size": 3,
+			"ttl":        "1h",
+		},
+	})
+	if err != nil || config == nil {
+		t.Fatalf("解析预热配置失败: %v", err)
+	}
+	if config.TTL != time.Hour {
+		t.Errorf("预热TTL应解析为1h, 得到 %v", config.TTL)
+	}
+	if _, err := cache.ParseWarmupConfig(map[string]interface{}{
+		"warmup": map[string]interface{}{"loader": "test-products", "timeout": "soon"},
+	}); err == nil {
+		t.Error("无效的超时时间应返回错误")
+	}
+	if none, err := cache.ParseWarmupConfig(map[string]interface{}{"max_size": 100}); none != nil || err != nil {
+		t.Errorf("未配置预热时应返回nil, 得到 %v, %v", none, err)
+	}
+
+	products, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100})
+	loaded, err := cache.Warmup(ctx, products, *config)
+	if err != nil {
+		t.Fatalf("预热失败: %v", err)
+	}
+	if loaded != 3 || len(batches) != 2 {
+		t.Errorf("应分2批加载3个条目, 得到 %d 个, %d 批", loaded, len(batches))
+	}
+	if value, _ := products.Get(ctx, "p3"); value != "product-p3" {
+		t.Errorf("预热后应命中, 得到 %v", value)
+	}
+
+	if _, err := cache.Warmup(ctx, products, cache.WarmupConfig{Loader: "unknown"}); err == nil {
+		t.Error("未注册的加载函数应返回错误")
+	}
+}
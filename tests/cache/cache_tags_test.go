@@ -0,0 +1,179 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/qiaojinxia/distributed-service/framework/cache"
+)
+
+func TestCacheTagInvalidation(t *testing.T) {
+	ctx := context.Background()
+
+	policies := []cache.EvictionPolicy{cache.EvictionPolicyLRU, cache.EvictionPolicySimple, cache.EvictionPolicyLFU}
+	for _, policy := range policies {
+		t.Run(string(policy), func(t *testing.T) {
+			c, err := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100, EvictionPolicy: policy})
+			if err != nil {
+				t.Fatalf("内存缓存创建失败: %v", err)
+			}
+
+			_ = c.SetWithTags(ctx, "user:42:profile", "p", time.Minute, "user:42")
+			_ = c.SetWithTags(ctx, "user:42:orders", "o", time.Minute, "user:42", "tenant:acme")
+			_ = c.SetWithTags(ctx, "user:7:profile", "p", time.Minute, "tenant:acme")
+			_ = c.Set(ctx, "config:site", "s", time.Minute)
+
+			if err := c.InvalidateTag(ctx, "user:42"); err != nil {
+				t.Fatalf("标签失效失败: %v", err)
+			}
+			assertKeys(t, c, map[string]bool{
+				"user:42:profile": false,
+				"user:42:orders":  false,
+				"user:7:profile":  true,
+				"config:site":     true,
+			})
+			if keys, _ := c.TagKeys(ctx, "tenant:acme"); len(keys) != 1 || keys[0] != "user:7:profile" {
+				t.Errorf("删除后的键应从其他标签中移除: %v", keys)
+			}
+
+			if err := c.DeletePrefix(ctx, "user:"); err != nil {
+				t.Fatalf("前缀删除失败: %v", err)
+			}
+			assertKeys(t, c, map[string]bool{"user:7:profile": false, "config:site": true})
+
+			// 重新写入时替换原有标签，普通Set清除标签
+			_ = c.SetWithTags(ctx, "item:1", "v", time.Minute, "old")
+			_ = c.SetWithTags(ctx, "item:1", "v", time.Minute, "new")
+			_ = c.SetWithTags(ctx, "item:2", "v", time.Minute, "old")
+			_ = c.Set(ctx, "item:2", "v", time.Minute)
+			if keys, _ := c.TagKeys(ctx, "old"); len(keys) != 0 {
+				t.Errorf("重新写入后旧标签不应再关联键: %v", keys)
+			}
+			_ = c.InvalidateTag(ctx, "old")
+			assertKeys(t, c, map[string]bool{"item:1": true, "item:2": true})
+			if keys, _ := c.TagKeys(ctx, "new"); len(keys) != 1 || keys[0] != "item:1" {
+				t.Errorf("应关联新标签: %v", keys)
+			}
+		})
+	}
+
+	t.Run("Sharded", func(t *testing.T) {
+		c, err := cache.NewShardedMemoryCache(cache.MemoryConfig{MaxSize: 100, ShardCount: 4})
+		if err != nil {
+			t.Fatalf("分片缓存创建失败: %v", err)
+		}
+		for _, key := range []string{"a", "b", "c", "d", "e"} {
+			_ = c.SetWithTags(ctx, key, key, time.Minute, "letters")
+		}
+		_ = c.InvalidateTag(ctx, "letters")
+		assertKeys(t, c, map[string]bool{"a": false, "b": false, "c": false, "d": false, "e": false})
+	})
+
+	t.Run("Redis", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		defer client.Close()
+		c := cache.NewSimpleRedisCache(client, "app")
+
+		// 标签集合不存在时直接返回
+		if err := c.InvalidateTag(ctx, "missing"); err != nil {
+			t.Fatalf("不存在的标签失效不应报错: %v", err)
+		}
+
+		_ = c.SetWithTags(ctx, "user:42:profile", "p", time.Minute, "user:42")
+		_ = c.Set(ctx, "config:site", "s", time.Minute)
+		if err := c.InvalidateTag(ctx, "user:42"); err != nil {
+			t.Fatalf("标签失效失败: %v", err)
+		}
+		assertKeys(t, c, map[string]bool{"user:42:profile": false, "config:site": true})
+	})
+
+	t.Run("Hybrid", func(t *testing.T) {
+		l2, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100})
+		bus := &memoryBus{}
+		replicaA := newReplica(t, l2, bus)
+		replicaB := newReplica(t, l2, bus)
+
+		_ = replicaA.SetWithTags(ctx, "product:1", "v1", time.Minute, "category:books")
+		// 实例B从L2回填L1，L1副本不带标签
+		if value, _ := replicaB.Get(ctx, "product:1"); value != "v1" {
+			t.Fatalf("实例B应读到v1, 得到 %v", value)
+		}
+
+		if err := replicaA.InvalidateTag(ctx, "category:books"); err != nil {
+			t.Fatalf("标签失效失败: %v", err)
+		}
+		waitForMiss(t, replicaB, "product:1")
+
+		_ = replicaA.Set(ctx, "product:2", "v2", time.Minute)
+		_, _ = replicaB.Get(ctx, "product:2")
+		if err := replicaA.DeletePrefix(ctx, "product:"); err != nil {
+			t.Fatalf("前缀删除失败: %v", err)
+		}
+		waitForMiss(t, replicaB, "product:2")
+	})
+
+	t.Run("WrapperClear", func(t *testing.T) {
+		manager := cache.NewManager()
+		manager.RegisterBuilder(cache.TypeMemory, &cache.MemoryBuilder{})
+		if err := manager.CreateCache(cache.Config{Name: "shared", Type: cache.TypeMemory}); err != nil {
+			t.Fatalf("缓存创建失败: %v", err)
+		}
+		underlying, _ := manager.GetCache("shared")
+		named := manager.GetNamedCache("shared")
+
+		_ = named.Set(ctx, "k", "v", time.Minute)
+		_ = underlying.Set(ctx, "other:k", "v", time.Minute)
+
+		if err := named.Clear(ctx); err != nil {
+			t.Fatalf("清空失败: %v", err)
+		}
+		assertKeys(t, underlying, map[string]bool{"shared:k": false, "other:k": true})
+	})
+
+	t.Run("WrapperTags", func(t *testing.T) {
+		manager := cache.NewManager()
+		manager.RegisterBuilder(cache.TypeMemory, &cache.MemoryBuilder{})
+		if err := manager.CreateCache(cache.Config{Name: "shared", Type: cache.TypeMemory}); err != nil {
+			t.Fatalf("缓存创建失败: %v", err)
+		}
+		underlying, _ := manager.GetCache("shared")
+		named := manager.GetNamedCache("shared").(cache.TaggableCache)
+
+		_ = named.SetWithTags(ctx, "user:1", "v", time.Minute, "users")
+		_ = underlying.(cache.TaggableCache).SetWithTags(ctx, "other:user:1", "v", time.Minute, "users")
+
+		keys, err := named.TagKeys(ctx, "users")
+		if err != nil || len(keys) != 1 || keys[0] != "user:1" {
+			t.Errorf("标签键应只含本命名空间的user:1, 得到 %v (%v)", keys, err)
+		}
+		if err := named.InvalidateTag(ctx, "users"); err != nil {
+			t.Fatalf("标签失效失败: %v", err)
+		}
+		assertKeys(t, underlying, map[string]bool{"shared:user:1": false, "other:user:1": true})
+	})
+}
+
+func assertKeys(t *testing.T, c cache.Cache, expected map[string]bool) {
+	t.Helper()
+	for key, want := range expected {
+		if exists, _ := c.Exists(context.Background(), key); exists != want {
+			t.Errorf("键 %s 存在性应为 %v", key, want)
+		}
+	}
+}
+
+func waitForMiss(t *testing.T, c cache.Cache, key string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := c.Get(context.Background(), key); err != nil {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Errorf("键 %s 应已失效", key)
+}
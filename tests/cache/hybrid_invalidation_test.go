@@ -0,0 +1,96 @@
+package cache_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/cache"
+)
+
+// memoryBus 进程内失效总线，模拟多个实例共享的Pub/Sub
+type memoryBus struct {
+	mu       sync.Mutex
+	handlers []cache.InvalidationHandler
+}
+
+func (b *memoryBus) Publish(ctx context.Context, msg *cache.InvalidationMessage) error {
+	b.mu.Lock()
+	handlers := append([]cache.InvalidationHandler(nil), b.handlers...)
+	b.mu.Unlock()
+	for _, handler := range handlers {
+		go handler(msg)
+	}
+	return nil
+}
+
+func (b *memoryBus) Subscribe(ctx context.Context, handler cache.InvalidationHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+	return nil
+}
+
+func (b *memoryBus) Close() error {
+	return nil
+}
+
+// sharedBuilder 返回同一个缓存实例，模拟多个实例共享的Redis L2
+type sharedBuilder struct {
+	cache cache.Cache
+}
+
+func (b *sharedBuilder) Build(config cache.Config) (cache.Cache, error) {
+	return b.cache, nil
+}
+
+func newReplica(t *testing.T, l2 cache.Cache, bus cache.InvalidationBus) *cache.HybridCache {
+	manager := cache.NewManager()
+	manager.RegisterBuilder(cache.TypeRedis, &sharedBuilder{cache: l2})
+
+	replica, err := cache.NewHybridCache(cache.HybridConfig{
+		L1Config:        cache.Config{Type: cache.TypeMemory, Name: "l1"},
+		L2Config:        cache.Config{Type: cache.TypeRedis, Name: "products"},
+		SyncStrategy:    cache.SyncStrategyWriteThrough,
+		InvalidationBus: bus,
+	}, manager)
+	if err != nil {
+		t.Fatalf("混合缓存创建失败: %v", err)
+	}
+	return replica
+}
+
+func TestHybridCacheInvalidation(t *testing.T) {
+	ctx := context.Background()
+
+	l2, err := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100})
+	if err != nil {
+		t.Fatalf("L2缓存创建失败: %v", err)
+	}
+	bus := &memoryBus{}
+	replicaA := newReplica(t, l2, bus)
+	replicaB := newReplica(t, l2, bus)
+
+	replicaA.Set(ctx, "product:1", "v1", 0)
+	if value, _ := replicaB.Get(ctx, "product:1"); value != "v1" {
+		t.Fatalf("实例B应读到v1, 得到 %v", value)
+	}
+
+	// 实例A更新后，实例B的L1副本应被淘汰
+	replicaA.Set(ctx, "product:1", "v2", 0)
+	deadline := time.Now().Add(time.Second)
+	for replicaB.GetStats().Invalidations < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if value, _ := replicaB.Get(ctx, "product:1"); value != "v2" {
+		t.Errorf("实例B应读到v2, 得到 %v", value)
+	}
+
+	// 实例忽略自己发出的消息
+	time.Sleep(50 * time.Millisecond)
+	if invalidations := replicaA.GetStats().Invalidations; invalidations != 0 {
+		t.Errorf("实例A不应处理自己的失效消息, 处理了 %d 条", invalidations)
+	}
+	t.Log("✅ L1跨实例失效正常")
+}
@@ -0,0 +1,206 @@
+package cache_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/cache"
+)
+
+func newWriteBackReplica(t *testing.T, l2 cache.Cache, config cache.HybridConfig) *cache.HybridCache {
+	manager := cache.NewManager()
+	manager.RegisterBuilder(cache.TypeRedis, &sharedBuilder{cache: l2})
+
+	config.L1Config = cache.Config{Type: cache.TypeMemory, Name: "l1"}
+	config.L2Config = cache.Config{Type: cache.TypeRedis, Name: "orders"}
+	config.SyncStrategy = cache.SyncStrategyWriteBack
+	config.WriteBackEnabled = true
+	if config.WriteBackInterval == 0 {
+		config.WriteBackInterval = time.Hour
+	}
+
+	replica, err := cache.NewHybridCache(config, manager)
+	if err != nil {
+		t.Fatalf("混合缓存创建失败: %v", err)
+	}
+	return replica
+}
+
+// blockingCache Set 阻塞直到release关闭的缓存，用于模拟写回过程中的慢L2
+type blockingCache struct {
+	cache.Cache
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (b *blockingCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	b.once.Do(func() { close(b.started) })
+	<-b.release
+	return b.Cache.Set(ctx, key, value, expiration)
+}
+
+func TestHybridCacheWriteBack(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("JournalReplay", func(t *testing.T) {
+		l2, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100})
+		path := filepath.Join(t.TempDir(), "orders.journal")
+
+		journal, err := cache.NewFileJournal(path, false)
+		if err != nil {
+			t.Fatalf("写回日志创建失败: %v", err)
+		}
+		crashed := newWriteBackReplica(t, l2, cache.HybridConfig{WriteBackJournal: journal})
+		defer crashed.Close()
+		_ = crashed.Set(ctx, "order:1", "v1", time.Minute)
+		_ = crashed.Set(ctx, "order:1", "v2", time.Minute)
+		_ = crashed.Set(ctx, "order:2", "v1", time.Minute)
+
+		stats := crashed.GetStats()
+		if stats.WriteBackQueued != 3 || stats.WriteBackCoalesced != 1 || stats.WriteBackPending != 2 {
+			t.Errorf("写回统计错误: %+v", stats)
+		}
+		if exists, _ := l2.Exists(ctx, "order:1"); exists {
+			t.Fatal("写回前L2不应有数据")
+		}
+
+		// 模拟进程崩溃：不调用Close，由新实例重放日志
+		reopened, err := cache.NewFileJournal(path, false)
+		if err != nil {
+			t.Fatalf("写回日志打开失败: %v", err)
+		}
+		restarted := newWriteBackReplica(t, l2, cache.HybridConfig{WriteBackJournal: reopened})
+		defer restarted.Close()
+
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if value, _ := l2.Get(ctx, "order:1"); value == "v2" {
+				break
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		if value, _ := l2.Get(ctx, "order:1"); value != "v2" {
+			t.Errorf("重放后L2应为合并后的最新值v2, 得到 %v", value)
+		}
+		if value, _ := l2.Get(ctx, "order:2"); value != "v1" {
+			t.Errorf("重放后L2应有order:2, 得到 %v", value)
+		}
+	})
+
+	t.Run("FlushOnClose", func(t *testing.T) {
+		l2, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100})
+		replica := newWriteBackReplica(t, l2, cache.HybridConfig{})
+
+		_ = replica.Set(ctx, "order:1", "v1", time.Minute)
+		_ = replica.Set(ctx, "order:2", "v1", time.Minute)
+		_ = replica.Delete(ctx, "order:2")
+		if err := replica.Close(); err != nil {
+			t.Fatalf("关闭失败: %v", err)
+		}
+
+		if value, _ := l2.Get(ctx, "order:1"); value != "v1" {
+			t.Errorf("Close后应写回L2, 得到 %v", value)
+		}
+		if exists, _ := l2.Exists(ctx, "order:2"); exists {
+			t.Error("已删除的键不应被写回")
+		}
+	})
+
+	t.Run("DeleteDuringFlush", func(t *testing.T) {
+		memory, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100})
+		l2 := &blockingCache{Cache: memory, started: make(chan struct{}), release: make(chan struct{})}
+		replica := newWriteBackReplica(t, l2, cache.HybridConfig{})
+		defer replica.Close()
+
+		_ = replica.Set(ctx, "order:1", "v1", time.Minute)
+		flushed := make(chan error, 1)
+		go func() { flushed <- replica.Flush(ctx) }()
+		<-l2.started
+
+		// 写回批次已取出，删除需等待批次写完，之后不应复活
+		deleted := make(chan error, 1)
+		go func() { deleted <- replica.Delete(ctx, "order:1") }()
+		time.Sleep(20 * time.Millisecond)
+		close(l2.release)
+
+		if err := <-flushed; err != nil {
+			t.Fatalf("写回失败: %v", err)
+		}
+		if err := <-deleted; err != nil {
+			t.Fatalf("删除失败: %v", err)
+		}
+		if exists, _ := memory.Exists(ctx, "order:1"); exists {
+			t.Error("写回期间删除的键不应写入L2")
+		}
+	})
+
+	t.Run("InvalidateTagAndPrefix", func(t *testing.T) {
+		l2, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100})
+		replica := newWriteBackReplica(t, l2, cache.HybridConfig{})
+
+		_ = replica.SetWithTags(ctx, "order:1", "v1", time.Minute, "user:1")
+		_ = replica.Set(ctx, "order:2", "v1", time.Minute)
+		_ = replica.Set(ctx, "item:1", "v1", time.Minute)
+		if err := replica.InvalidateTag(ctx, "user:1"); err != nil {
+			t.Fatalf("按标签失效失败: %v", err)
+		}
+		if err := replica.DeletePrefix(ctx, "order:"); err != nil {
+			t.Fatalf("按前缀删除失败: %v", err)
+		}
+		if pending := replica.GetStats().WriteBackPending; pending != 1 {
+			t.Errorf("待写回记录应只剩item:1, 得到 %d", pending)
+		}
+		if err := replica.Close(); err != nil {
+			t.Fatalf("关闭失败: %v", err)
+		}
+
+		for _, key := range []string{"order:1", "order:2"} {
+			if exists, _ := l2.Exists(ctx, key); exists {
+				t.Errorf("已失效的键 %s 不应被写回", key)
+			}
+		}
+		if value, _ := l2.Get(ctx, "item:1"); value != "v1" {
+			t.Errorf("未失效的键应写回L2, 得到 %v", value)
+		}
+	})
+
+	t.Run("OverflowDrop", func(t *testing.T) {
+		l2, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100})
+		replica := newWriteBackReplica(t, l2, cache.HybridConfig{
+			WriteBackMaxPending: 1,
+			WriteBackOverflow:   cache.WriteBackOverflowDrop,
+		})
+		defer replica.Close()
+
+		_ = replica.Set(ctx, "order:1", "v1", time.Minute)
+		_ = replica.Set(ctx, "order:2", "v1", time.Minute)
+		if stats := replica.GetStats(); stats.WriteBackDropped != 1 || stats.WriteBackPending != 1 {
+			t.Errorf("队列满时应丢弃: %+v", stats)
+		}
+	})
+
+	t.Run("OverflowBlock", func(t *testing.T) {
+		l2, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100})
+		replica := newWriteBackReplica(t, l2, cache.HybridConfig{
+			WriteBackMaxPending: 1,
+			WriteBackOverflow:   cache.WriteBackOverflowBlock,
+		})
+		defer replica.Close()
+
+		_ = replica.Set(ctx, "order:1", "v1", time.Minute)
+
+		// 队列满时阻塞，直到写回协程腾出空间
+		timeoutCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		if err := replica.Set(timeoutCtx, "order:2", "v1", time.Minute); err != nil {
+			t.Fatalf("阻塞写入失败: %v", err)
+		}
+		if value, _ := l2.Get(ctx, "order:1"); value != "v1" {
+			t.Errorf("阻塞期间应已写回order:1, 得到 %v", value)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/qiaojinxia/distributed-service/framework/cache"
+	"github.com/qiaojinxia/distributed-service/framework/metrics"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestCacheInstrumentation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Metrics", func(t *testing.T) {
+		manager := cache.NewManager()
+		manager.RegisterBuilder(cache.TypeMemory, &cache.MemoryBuilder{})
+		if err := manager.CreateCache(cache.Config{Name: "instrumented-users", Type: cache.TypeMemory}); err != nil {
+			t.Fatalf("缓存创建失败: %v", err)
+		}
+		c, _ := manager.GetCache("instrumented-users")
+		if _, ok := cache.Unwrap(c).(*cache.MemoryCache); !ok {
+			t.Fatalf("Unwrap应返回底层内存缓存, 得到 %T", cache.Unwrap(c))
+		}
+
+		_ = c.Set(ctx, "a", "1", time.Minute)
+		_, _ = c.Get(ctx, "a")
+		_, _ = c.Get(ctx, "missing")
+		_, _ = c.(cache.BatchCache).MGet(ctx, []string{"a", "b", "c"})
+
+		if hits := testutil.ToFloat64(metrics.CacheTierHits.WithLabelValues("instrumented-users", cache.TierDefault)); hits != 2 {
+			t.Errorf("命中数应为2, 得到 %v", hits)
+		}
+		if misses := testutil.ToFloat64(metrics.CacheTierMisses.WithLabelValues("instrumented-users", cache.TierDefault)); misses != 3 {
+			t.Errorf("未命中数应为3, 得到 %v", misses)
+		}
+		if hits := testutil.ToFloat64(metrics.CacheHits.WithLabelValues("instrumented-users")); hits != 2 {
+			t.Errorf("按缓存统计的命中数应为2, 得到 %v", hits)
+		}
+		if n := testutil.CollectAndCount(metrics.CacheOperationDuration, "cache_operation_duration_seconds"); n == 0 {
+			t.Error("应记录操作耗时")
+		}
+	})
+
+	t.Run("HybridTiers", func(t *testing.T) {
+		l2, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100})
+		manager := cache.NewManager()
+		manager.RegisterBuilder(cache.TypeRedis, &sharedBuilder{cache: l2})
+
+		hybrid, err := cache.NewHybridCache(cache.HybridConfig{
+			Name:         "instrumented-products",
+			L1Config:     cache.Config{Type: cache.TypeMemory, Name: "l1"},
+			L2Config:     cache.Config{Type: cache.TypeRedis, Name: "l2-instrumented-products"},
+			SyncStrategy: cache.SyncStrategyWriteThrough,
+		}, manager)
+		if err != nil {
+			t.Fatalf("混合缓存创建失败: %v", err)
+		}
+		defer hybrid.Close()
+
+		_ = l2.Set(ctx, "p", "v", time.Minute)
+		_, _ = hybrid.Get(ctx, "p") // L1未命中，L2命中
+		_, _ = hybrid.Get(ctx, "p") // L1命中
+
+		l1Hits := testutil.ToFloat64(metrics.CacheTierHits.WithLabelValues("instrumented-products", cache.TierL1))
+		l1Misses := testutil.ToFloat64(metrics.CacheTierMisses.WithLabelValues("instrumented-products", cache.TierL1))
+		l2Hits := testutil.ToFloat64(metrics.CacheTierHits.WithLabelValues("instrumented-products", cache.TierL2))
+		if l1Hits != 1 || l1Misses != 1 || l2Hits != 1 {
+			t.Errorf("分层统计错误: l1命中=%v l1未命中=%v l2命中=%v", l1Hits, l1Misses, l2Hits)
+		}
+		// 按缓存统计时不计入L1/L2层，避免同一次读取重复计数
+		if hits := testutil.ToFloat64(metrics.CacheHits.WithLabelValues("instrumented-products")); hits != 0 {
+			t.Errorf("L1/L2层不应计入按缓存统计的命中数, 得到 %v", hits)
+		}
+	})
+
+	t.Run("Capabilities", func(t *testing.T) {
+		memory, _ := cache.NewMemoryCache(cache.MemoryConfig{MaxSize: 100})
+		// 只实现基础接口的缓存
+		plain := struct{ cache.Cache }{memory}
+		c := cache.NewInstrumentedCache(plain, "plain", cache.TierDefault, "memory", cache.InstrumentationOptions{})
+
+		if err := c.SetObject(ctx, "obj", map[string]int{"a": 1}, time.Minute); !errors.Is(err, cache.ErrNotSupported) {
+			t.Errorf("不支持序列化时应返回ErrNotSupported, 得到 %v", err)
+		}
+		if err := c.GetObject(ctx, "obj", &map[string]int{}); !errors.Is(err, cache.ErrNotSupported) {
+			t.Errorf("不支持序列化时应返回ErrNotSupported, 得到 %v", err)
+		}
+		if err := c.Flush(ctx); !errors.Is(err, cache.ErrNotSupported) {
+			t.Errorf("不支持写回时应返回ErrNotSupported, 得到 %v", err)
+		}
+		if err := c.SetWithTags(ctx, "k", "v", time.Minute, "t"); !errors.Is(err, cache.ErrTagsNotSupported) {
+			t.Errorf("不支持标签时应返回ErrTagsNotSupported, 得到 %v", err)
+		}
+
+		// 底层缓存不支持统计时由装饰器统计
+		_ = c.Set(ctx, "k", "v", time.Minute)
+		_, _ = c.Get(ctx, "k")
+		_, _ = c.Get(ctx, "missing")
+		_ = c.Delete(ctx, "k")
+		if stats := c.GetStats(); stats.Sets != 1 || stats.Hits != 1 || stats.Misses != 1 || stats.Deletes != 1 {
+			t.Errorf("装饰器统计错误: %+v", stats)
+		}
+		c.ResetStats()
+		if stats := c.GetStats(); stats.Sets != 0 || stats.Hits != 0 {
+			t.Errorf("重置后统计应清零: %+v", stats)
+		}
+	})
+
+	t.Run("TracingSampling", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		previous := otel.GetTracerProvider()
+		otel.SetTracerProvider(provider)
+		defer otel.SetTracerProvider(previous)
+
+		manager := cache.NewManager()
+		manager.RegisterBuilder(cache.TypeMemory, &cache.MemoryBuilder{})
+		manager.SetInstrumentation(&cache.InstrumentationOptions{TraceSampleRate: 1, TraceKeys: true})
+		_ = manager.CreateCache(cache.Config{Name: "traced", Type: cache.TypeMemory})
+		c, _ := manager.GetCache("traced")
+
+		// 没有上游span时不创建span
+		_, _ = c.Get(ctx, "k")
+		if n := len(recorder.Ended()); n != 0 {
+			t.Fatalf("没有上游span时不应创建span, 得到 %d", n)
+		}
+
+		parentCtx, parent := otel.Tracer("test").Start(ctx, "request")
+		_ = c.Set(parentCtx, "k", "v", time.Minute)
+		_, _ = c.Get(parentCtx, "k")
+		parent.End()
+
+		var names []string
+		for _, span := range recorder.Ended() {
+			names = append(names, span.Name())
+		}
+		if len(names) != 3 || names[0] != "cache.set" || names[1] != "cache.get" {
+			t.Errorf("应记录cache.set、cache.get两个子span, 得到 %v", names)
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/cache"
+)
+
+func newLoaderCache(t *testing.T) cache.Cache {
+	c, err := cache.NewMemoryCache(cache.MemoryConfig{
+		MaxSize:         100,
+		DefaultTTL:      time.Minute,
+		CleanupInterval: time.Minute,
+		EvictionPolicy:  cache.EvictionPolicyLRU,
+	})
+	if err != nil {
+		t.Fatalf("内存缓存创建失败: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestLoaderGetOrLoad(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Singleflight", func(t *testing.T) {
+		loader := cache.NewLoader(newLoaderCache(t))
+
+		var calls int32
+		release := make(chan struct{})
+		load := func(ctx context.Context, key string) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return "value-" + key, nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				value, err := loader.GetOrLoad(ctx, "user:1", load, time.Minute)
+				if err != nil || value != "value-user:1" {
+					t.Errorf("加载结果错误: %v, %v", value, err)
+				}
+			}()
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if calls != 1 {
+			t.Errorf("并发加载应合并为1次，实际%d次", calls)
+		}
+		if _, err := loader.GetOrLoad(ctx, "user:1", load, time.Minute); err != nil || calls != 1 {
+			t.Errorf("已缓存的值不应再次加载，实际%d次: %v", calls, err)
+		}
+	})
+
+	t.Run("NegativeCaching", func(t *testing.T) {
+		loader := cache.NewLoader(newLoaderCache(t), cache.LoaderOptions{NegativeTTL: time.Minute})
+
+		var calls int32
+		load := func(ctx context.Context, key string) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, cache.ErrKeyNotFound
+		}
+
+		for i := 0; i < 3; i++ {
+			if _, err := loader.GetOrLoad(ctx, "missing", load, time.Minute); !errors.Is(err, cache.ErrKeyNotFound) {
+				t.Fatalf("期望ErrKeyNotFound，实际: %v", err)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("未找到结果应被缓存，实际加载%d次", calls)
+		}
+	})
+
+	t.Run("LoadErrorNotCached", func(t *testing.T) {
+		loader := cache.NewLoader(newLoaderCache(t))
+
+		failure := errors.New("db down")
+		var calls int32
+		load := func(ctx context.Context, key string) (interface{}, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return nil, failure
+			}
+			return "ok", nil
+		}
+
+		if _, err := loader.GetOrLoad(ctx, "flaky", load, time.Minute); !errors.Is(err, failure) {
+			t.Fatalf("期望加载错误，实际: %v", err)
+		}
+		if value, err := loader.GetOrLoad(ctx, "flaky", load, time.Minute); err != nil || value != "ok" {
+			t.Errorf("加载错误不应被缓存: %v, %v", value, err)
+		}
+	})
+
+	t.Run("StaleWhileRevalidate", func(t *testing.T) {
+		loader := cache.NewLoader(newLoaderCache(t), cache.LoaderOptions{StaleTTL: time.Minute})
+
+		var version int32
+		load := func(ctx context.Context, key string) (interface{}, error) {
+			return atomic.AddInt32(&version, 1), nil
+		}
+
+		if value, _ := loader.GetOrLoad(ctx, "config", load, 20*time.Millisecond); value != int32(1) {
+			t.Fatalf("首次加载应为1，实际: %v", value)
+		}
+		time.Sleep(40 * time.Millisecond)
+
+		// 过期后先返回旧值，同时后台刷新
+		if value, _ := loader.GetOrLoad(ctx, "config", load, 20*time.Millisecond); value != int32(1) {
+			t.Errorf("过期后应返回旧值，实际: %v", value)
+		}
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if value, _ := loader.GetOrLoad(ctx, "config", load, time.Minute); value == int32(2) {
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Error("后台刷新未完成")
+	})
+
+	t.Run("EarlyExpiration", func(t *testing.T) {
+		loader := cache.NewLoader(newLoaderCache(t), cache.LoaderOptions{EarlyExpirationBeta: 1})
+
+		var calls int32
+		load := func(ctx context.Context, key string) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(20 * time.Millisecond)
+			return "hot", nil
+		}
+
+		// 加载耗时远大于剩余有效期，过期前应触发提前刷新
+		if _, err := loader.GetOrLoad(ctx, "hot", load, 30*time.Millisecond); err != nil {
+			t.Fatalf("加载失败: %v", err)
+		}
+		time.Sleep(25 * time.Millisecond)
+		for i := 0; i < 20 && atomic.LoadInt32(&calls) < 2; i++ {
+			if _, err := loader.GetOrLoad(ctx, "hot", load, time.Minute); err != nil {
+				t.Fatalf("读取失败: %v", err)
+			}
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(30 * time.Millisecond)
+		if atomic.LoadInt32(&calls) < 2 {
+			t.Errorf("临近过期应提前刷新，实际加载%d次", calls)
+		}
+	})
+
+	t.Run("CallerCancel", func(t *testing.T) {
+		loader := cache.NewLoader(newLoaderCache(t))
+
+		release := make(chan struct{})
+		load := func(ctx context.Context, key string) (interface{}, error) {
+			select {
+			case <-release:
+				return "value", nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		// 首个调用方取消后只有它自己返回，合并的加载继续为其他调用方完成
+		firstCtx, cancel := context.WithCancel(ctx)
+		first := make(chan error, 1)
+		go func() {
+			_, err := loader.GetOrLoad(firstCtx, "user:1", load, time.Minute)
+			first <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		second := make(chan interface{}, 1)
+		go func() {
+			value, _ := loader.GetOrLoad(ctx, "user:1", load, time.Minute)
+			second <- value
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		cancel()
+		if err := <-first; !errors.Is(err, context.Canceled) {
+			t.Errorf("取消的调用方应返回context.Canceled, 得到 %v", err)
+		}
+		close(release)
+		if value := <-second; value != "value" {
+			t.Errorf("其他调用方应得到加载结果, 得到 %v", value)
+		}
+	})
+
+	t.Run("ManagerLoader", func(t *testing.T) {
+		manager := cache.NewManager()
+		manager.RegisterBuilder(cache.TypeMemory, &cache.MemoryBuilder{})
+		if err := manager.CreateCache(cache.Config{Name: "users", Type: cache.TypeMemory}); err != nil {
+			t.Fatalf("缓存创建失败: %v", err)
+		}
+
+		load := func(ctx context.Context, key string) (interface{}, error) { return "value", nil }
+		if value, err := manager.GetOrLoad(ctx, "users", "user:1", load, time.Minute); err != nil || value != "value" {
+			t.Fatalf("加载失败: %v %v", value, err)
+		}
+		first, _ := manager.Loader("users")
+
+		if err := manager.RemoveCache("users"); err != nil {
+			t.Fatalf("移除缓存失败: %v", err)
+		}
+		if _, err := manager.Loader("users"); err == nil {
+			t.Error("缓存移除后不应再返回加载器")
+		}
+
+		_ = manager.CreateCache(cache.Config{Name: "users", Type: cache.TypeMemory})
+		if second, _ := manager.Loader("users"); second == first {
+			t.Error("重新创建的缓存应使用新的加载器")
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/qiaojinxia/distributed-service/framework/cache"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type typedUser struct {
+	ID    int64    `json:"id"`
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+func TestTypedCache(t *testing.T) {
+	ctx := context.Background()
+
+	for _, name := range []string{"json", "gob", "msgpack"} {
+		t.Run(name, func(t *testing.T) {
+			codec, err := cache.NewCodec(name)
+			if err != nil {
+				t.Fatalf("编解码器创建失败: %v", err)
+			}
+			users := cache.NewTypedCache[typedUser](newLoaderCache(t), codec)
+
+			user := typedUser{ID: 1, Name: "alice", Roles: []string{"admin"}}
+			if err := users.Set(ctx, "user:1", user, time.Minute); err != nil {
+				t.Fatalf("设置失败: %v", err)
+			}
+
+			got, err := users.Get(ctx, "user:1")
+			if err != nil {
+				t.Fatalf("获取失败: %v", err)
+			}
+			if got.ID != 1 || got.Name != "alice" || len(got.Roles) != 1 || got.Roles[0] != "admin" {
+				t.Errorf("解码结果错误: %+v", got)
+			}
+
+			// 返回的是副本，修改不影响缓存
+			got.Roles[0] = "guest"
+			again, _ := users.Get(ctx, "user:1")
+			if again.Roles[0] != "admin" {
+				t.Errorf("缓存中的值被外部修改: %+v", again)
+			}
+
+			if _, err := users.Get(ctx, "user:2"); !errors.Is(err, cache.ErrKeyNotFound) {
+				t.Errorf("期望ErrKeyNotFound，实际: %v", err)
+			}
+		})
+	}
+
+	t.Run("protobuf", func(t *testing.T) {
+		messages := cache.NewTypedCache[*wrapperspb.StringValue](newLoaderCache(t), cache.ProtobufCodec{})
+		if err := messages.Set(ctx, "greeting", wrapperspb.String("hello"), time.Minute); err != nil {
+			t.Fatalf("设置失败: %v", err)
+		}
+		got, err := messages.Get(ctx, "greeting")
+		if err != nil || got.GetValue() != "hello" {
+			t.Errorf("protobuf解码错误: %v, %v", got, err)
+		}
+	})
+
+	t.Run("BatchOnHybrid", func(t *testing.T) {
+		replica := newReplica(t, newLoaderCache(t), &memoryBus{})
+		users := cache.NewTypedCache[typedUser](replica, nil)
+
+		if err := users.MSet(ctx, map[string]typedUser{
+			"user:1": {ID: 1, Name: "alice"},
+			"user:2": {ID: 2, Name: "bob"},
+		}, time.Minute); err != nil {
+			t.Fatalf("批量设置失败: %v", err)
+		}
+
+		got, err := users.MGet(ctx, []string{"user:1", "user:2", "user:3"})
+		if err != nil {
+			t.Fatalf("批量获取失败: %v", err)
+		}
+		if len(got) != 2 || got["user:1"].Name != "alice" || got["user:2"].Name != "bob" {
+			t.Errorf("批量获取结果错误: %+v", got)
+		}
+	})
+}